@@ -76,6 +76,13 @@ func buildJobCmd() *cobra.Command {
 			},
 			InitFlags: job.InitDeleteFlags,
 		},
+		"explain": {
+			Short: "explain why a job is pending",
+			RunFunction: func(cmd *cobra.Command, args []string) {
+				util.CheckError(cmd, job.ExplainJob(cmd.Context()))
+			},
+			InitFlags: job.InitExplainFlags,
+		},
 	}
 
 	for command, config := range jobCommandMap {
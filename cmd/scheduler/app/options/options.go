@@ -46,6 +46,9 @@ const (
 	defaultPercentageOfNodesToFind    = 0
 	defaultLockObjectNamespace        = "volcano-system"
 	defaultNodeWorkers                = 20
+	defaultSchedulingTraceSessions    = 10
+	defaultScheduleDebouncePeriod     = 50 * time.Millisecond
+	defaultMinSchedulePeriod          = 200 * time.Millisecond
 )
 
 // ServerOption is the main context object for the controller manager.
@@ -91,6 +94,36 @@ type ServerOption struct {
 	// not be counted in pod pvc resource request and node.Allocatable, because the spec.drivers of csinode resource
 	// is always null, these provisioners usually are host path csi controllers like rancher.io/local-path and hostpath.csi.k8s.io.
 	IgnoredCSIProvisioners []string
+
+	// SimulateSnapshot is the path of a cluster snapshot file; when set, vc-scheduler replays the snapshot
+	// through the configured actions and plugins offline, prints the scheduling result and exits.
+	SimulateSnapshot string
+
+	// EnableSchedulingTrace enables recording the scheduling decisions of each session, the traces of
+	// the latest SchedulingTraceSessions sessions are served at /debug/scheduling-traces of ListenAddress.
+	EnableSchedulingTrace   bool
+	SchedulingTraceSessions int
+
+	// EnableExplain enables serving the explanation of why a podgroup is pending at /debug/explain of ListenAddress.
+	EnableExplain bool
+
+	// EnableIncrementalSnapshot makes each session reuse the nodes and jobs cloned by the previous
+	// session when they have not changed since, instead of deep copying the whole cache.
+	EnableIncrementalSnapshot bool
+
+	// EnableEventDrivenScheduling makes the cache trigger a scheduling cycle before the schedule period
+	// elapses when relevant events arrive. The triggers arriving within ScheduleDebouncePeriod are merged
+	// into one cycle, and two cycles are at least MinSchedulePeriod apart.
+	EnableEventDrivenScheduling bool
+	ScheduleDebouncePeriod      time.Duration
+	MinSchedulePeriod           time.Duration
+
+	// ValidateConfig is the path of a scheduler configuration file; when set, vc-scheduler validates
+	// the file strictly, prints the errors found and exits.
+	ValidateConfig string
+	// SchedulerConfigMap is the <namespace>/<name> of the ConfigMap mounted as SchedulerConf, the
+	// events of refusing to load an invalid scheduler configuration are recorded on it.
+	SchedulerConfigMap string
 }
 
 // DecryptFunc is custom function to parse ca file
@@ -137,7 +170,7 @@ func (s *ServerOption) AddFlags(fs *pflag.FlagSet) {
 	// The percentage of nodes that would be scored in each scheduling cycle; if <= 0, an adaptive percentage will be calculated
 	fs.Int32Var(&s.PercentageOfNodesToFind, "percentage-nodes-to-find", defaultPercentageOfNodesToFind, "The percentage of nodes to find and score, if <=0 will be calculated based on the cluster size")
 
-	fs.StringVar(&s.PluginsDir, "plugins-dir", defaultPluginsDir, "vc-scheduler will load custom plugins which are in this directory")
+	fs.StringVar(&s.PluginsDir, "plugins-dir", defaultPluginsDir, "vc-scheduler will load custom plugins which are in this directory, including Go plugins (*.so) and manifests of remote gRPC plugins (*.grpc.yaml)")
 	fs.BoolVar(&s.EnableCSIStorage, "csi-storage", false,
 		"Enable tracking of available storage capacity that CSI drivers provide; it is false by default")
 	fs.BoolVar(&s.EnableHealthz, "enable-healthz", false, "Enable the health check; it is false by default")
@@ -148,6 +181,16 @@ func (s *ServerOption) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&s.CacheDumpFileDir, "cache-dump-dir", "/tmp", "The target dir where the json file put at when dump cache info to json file")
 	fs.Uint32Var(&s.NodeWorkerThreads, "node-worker-threads", defaultNodeWorkers, "The number of threads syncing node operations.")
 	fs.StringSliceVar(&s.IgnoredCSIProvisioners, "ignored-provisioners", nil, "The provisioners that will be ignored during pod pvc request computation and preemption.")
+	fs.BoolVar(&s.EnableSchedulingTrace, "enable-scheduling-trace", false, "Enable recording the scheduling decisions of each session and serving them at /debug/scheduling-traces; it is false by default")
+	fs.IntVar(&s.SchedulingTraceSessions, "scheduling-trace-sessions", defaultSchedulingTraceSessions, "The number of latest sessions whose scheduling traces are kept")
+	fs.BoolVar(&s.EnableExplain, "enable-explain", false, "Enable serving the explanation of why a podgroup is pending at /debug/explain, which is computed by a dry-run session; it is false by default")
+	fs.BoolVar(&s.EnableIncrementalSnapshot, "enable-incremental-snapshot", false, "Enable reusing the nodes and jobs that did not change since the last session when taking the session snapshot; it is false by default")
+	fs.BoolVar(&s.EnableEventDrivenScheduling, "enable-event-driven-scheduling", false, "Enable starting a scheduling cycle early when a podgroup becomes inqueue, a pod is deleted or a node is added, the periodic cycle is kept as fallback; it is false by default")
+	fs.DurationVar(&s.ScheduleDebouncePeriod, "schedule-debounce-period", defaultScheduleDebouncePeriod, "The period to wait for more events before starting an event triggered scheduling cycle")
+	fs.DurationVar(&s.MinSchedulePeriod, "min-schedule-period", defaultMinSchedulePeriod, "The minimum interval between the end of a scheduling cycle and the start of an event triggered one")
+	fs.StringVar(&s.SimulateSnapshot, "simulate-snapshot", "", "The path of a cluster snapshot file dumped by the cache dumper to replay offline with --scheduler-conf; the binds, evictions and pipelined tasks are printed and vc-scheduler exits without touching the cluster")
+	fs.StringVar(&s.ValidateConfig, "validate-config", "", "The path of a scheduler configuration file to validate strictly with --scheduler-name and --plugins-dir; the errors found are printed and vc-scheduler exits")
+	fs.StringVar(&s.SchedulerConfigMap, "scheduler-configmap", "", "The <namespace>/<name> of the ConfigMap mounted as --scheduler-conf, the events of refusing to load an invalid scheduler configuration are recorded on it")
 }
 
 // CheckOptionOrDie check leader election flag when LeaderElection is enabled.
@@ -82,6 +82,9 @@ func TestAddFlags(t *testing.T) {
 		PercentageOfNodesToFind:    defaultPercentageOfNodesToFind,
 		NodeWorkerThreads:          defaultNodeWorkers,
 		CacheDumpFileDir:           "/tmp",
+		SchedulingTraceSessions:    defaultSchedulingTraceSessions,
+		ScheduleDebouncePeriod:     defaultScheduleDebouncePeriod,
+		MinSchedulePeriod:          defaultMinSchedulePeriod,
 	}
 	expectedFeatureGates := map[featuregate.Feature]bool{
 		features.PodDisruptionBudgetsSupport: false,
@@ -29,6 +29,7 @@ import (
 	"volcano.sh/volcano/pkg/kube"
 	"volcano.sh/volcano/pkg/scheduler"
 	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/plugins/remote"
 	"volcano.sh/volcano/pkg/signals"
 	commonutil "volcano.sh/volcano/pkg/util"
 
@@ -58,7 +59,7 @@ func Run(opt *options.ServerOption) error {
 	}
 
 	if opt.PluginsDir != "" {
-		err := framework.LoadCustomPlugins(opt.PluginsDir)
+		err := loadCustomPlugins(opt.PluginsDir)
 		if err != nil {
 			klog.Errorf("Fail to load custom plugins: %v", err)
 			return err
@@ -67,11 +68,11 @@ func Run(opt *options.ServerOption) error {
 
 	sched, err := scheduler.NewScheduler(config, opt)
 	if err != nil {
-		panic(err)
+		return fmt.Errorf("failed to create scheduler: %v", err)
 	}
 
-	if opt.EnableMetrics || opt.EnablePprof {
-		go startMetricsServer(opt)
+	if opt.EnableMetrics || opt.EnablePprof || opt.EnableSchedulingTrace || opt.EnableExplain {
+		go startMetricsServer(opt, sched)
 	}
 
 	if opt.EnableHealthz {
@@ -141,7 +142,15 @@ func Run(opt *options.ServerOption) error {
 	return fmt.Errorf("lost lease")
 }
 
-func startMetricsServer(opt *options.ServerOption) {
+// loadCustomPlugins loads the Go plugins (*.so) and the remote plugins (*.grpc.yaml) in pluginsDir
+func loadCustomPlugins(pluginsDir string) error {
+	if err := framework.LoadCustomPlugins(pluginsDir); err != nil {
+		return err
+	}
+	return remote.LoadPlugins(pluginsDir)
+}
+
+func startMetricsServer(opt *options.ServerOption, sched *scheduler.Scheduler) {
 	mux := http.NewServeMux()
 
 	if opt.EnableMetrics {
@@ -156,6 +165,14 @@ func startMetricsServer(opt *options.ServerOption) {
 		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
 	}
 
+	if handler := sched.TraceHandler(); handler != nil {
+		mux.Handle("/debug/scheduling-traces", handler)
+	}
+
+	if opt.EnableExplain {
+		mux.Handle("/debug/explain", sched.ExplainHandler())
+	}
+
 	server := &http.Server{
 		Addr:    opt.ListenAddress,
 		Handler: mux,
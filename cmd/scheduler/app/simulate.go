@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"volcano.sh/volcano/cmd/scheduler/app/options"
+	"volcano.sh/volcano/pkg/scheduler/simulator"
+)
+
+// Simulate replays the cluster snapshot given by opt.SimulateSnapshot through the actions and
+// plugins configured in opt.SchedulerConf, and writes the scheduling result to out in json.
+func Simulate(opt *options.ServerOption, out io.Writer) error {
+	if opt.PluginsDir != "" {
+		if err := loadCustomPlugins(opt.PluginsDir); err != nil {
+			return fmt.Errorf("failed to load custom plugins: %v", err)
+		}
+	}
+
+	snapshot, err := simulator.LoadClusterInfo(opt.SimulateSnapshot)
+	if err != nil {
+		return err
+	}
+
+	var schedulerConf string
+	if opt.SchedulerConf != "" {
+		data, err := os.ReadFile(opt.SchedulerConf)
+		if err != nil {
+			return fmt.Errorf("failed to read scheduler config %s: %v", opt.SchedulerConf, err)
+		}
+		schedulerConf = strings.TrimSpace(string(data))
+	}
+
+	result, err := simulator.Simulate(snapshot, schedulerConf)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2026 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"volcano.sh/volcano/cmd/scheduler/app/options"
+	"volcano.sh/volcano/pkg/scheduler"
+)
+
+// ValidateConfig validates the scheduler configuration file given by opt.ValidateConfig strictly
+// against the scheduler names and the custom plugins, and writes the errors found to out.
+func ValidateConfig(opt *options.ServerOption, out io.Writer) error {
+	if opt.PluginsDir != "" {
+		if err := loadCustomPlugins(opt.PluginsDir); err != nil {
+			return fmt.Errorf("failed to load custom plugins: %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(opt.ValidateConfig)
+	if err != nil {
+		return fmt.Errorf("failed to read scheduler config %s: %v", opt.ValidateConfig, err)
+	}
+
+	err = scheduler.ValidateSchedulerConf(strings.TrimSpace(string(data)), opt.SchedulerNames)
+	if err == nil {
+		fmt.Fprintf(out, "scheduler config %s is valid\n", opt.ValidateConfig)
+		return nil
+	}
+
+	errs := []error{err}
+	if agg, ok := err.(utilerrors.Aggregate); ok {
+		errs = agg.Errors()
+	}
+	for _, e := range errs {
+		fmt.Fprintf(out, "%s: %v\n", opt.ValidateConfig, e)
+	}
+	return fmt.Errorf("scheduler config %s is invalid: %d errors found", opt.ValidateConfig, len(errs))
+}
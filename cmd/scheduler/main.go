@@ -67,6 +67,22 @@ func main() {
 		return
 	}
 
+	if s.SimulateSnapshot != "" {
+		if err := app.Simulate(s, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if s.ValidateConfig != "" {
+		if err := app.ValidateConfig(s, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	if err := s.CheckOptionOrDie(); err != nil {
 		fmt.Fprintf(os.Stderr, "%v\n", err)
 		os.Exit(1)
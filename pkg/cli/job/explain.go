@@ -0,0 +1,236 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"volcano.sh/apis/pkg/client/clientset/versioned"
+	"volcano.sh/volcano/pkg/cli/util"
+)
+
+type explainFlags struct {
+	util.CommonFlags
+
+	Namespace string
+	JobName   string
+	PodGroup  string
+
+	SchedulerNamespace string
+	SchedulerService   string
+	SchedulerPort      string
+}
+
+var explainJobFlags = &explainFlags{}
+
+// taskExplanation is the explanation of a pending task returned by vc-scheduler.
+type taskExplanation struct {
+	Name                 string                       `json:"name"`
+	PrePredicateFailures map[string]string            `json:"prePredicateFailures,omitempty"`
+	PredicateFailures    map[string]map[string]string `json:"predicateFailures,omitempty"`
+	FeasibleNodes        []string                     `json:"feasibleNodes,omitempty"`
+	Allocatable          map[string]bool              `json:"allocatable,omitempty"`
+}
+
+// jobExplanation is the explanation of a pending podgroup returned by vc-scheduler.
+type jobExplanation struct {
+	Namespace    string             `json:"namespace"`
+	Name         string             `json:"name"`
+	Queue        string             `json:"queue"`
+	Phase        string             `json:"phase"`
+	MinAvailable int32              `json:"minAvailable"`
+	Invalid      map[string]string  `json:"invalid,omitempty"`
+	Overused     map[string]bool    `json:"overused,omitempty"`
+	Enqueueable  map[string]int     `json:"enqueueable,omitempty"`
+	Ready        map[string]bool    `json:"ready,omitempty"`
+	FitErrors    string             `json:"fitErrors,omitempty"`
+	Tasks        []*taskExplanation `json:"tasks,omitempty"`
+}
+
+// InitExplainFlags init the explain command flags.
+func InitExplainFlags(cmd *cobra.Command) {
+	util.InitFlags(cmd, &explainJobFlags.CommonFlags)
+
+	cmd.Flags().StringVarP(&explainJobFlags.Namespace, "namespace", "n", "default", "the namespace of job")
+	cmd.Flags().StringVarP(&explainJobFlags.JobName, "name", "N", "", "the name of job")
+	cmd.Flags().StringVarP(&explainJobFlags.PodGroup, "podgroup", "", "", "the name of podgroup to explain instead of job, e.g. the podgroup of pods not created by vcjob")
+	cmd.Flags().StringVarP(&explainJobFlags.SchedulerNamespace, "scheduler-namespace", "", "volcano-system", "the namespace of vc-scheduler service")
+	cmd.Flags().StringVarP(&explainJobFlags.SchedulerService, "scheduler-service", "", "volcano-scheduler-service", "the name of vc-scheduler service, vc-scheduler should be started with --enable-explain")
+	cmd.Flags().StringVarP(&explainJobFlags.SchedulerPort, "scheduler-port", "", "8080", "the port of vc-scheduler service serving /debug/explain")
+}
+
+// ExplainJob asks vc-scheduler why the job is pending.
+func ExplainJob(ctx context.Context) error {
+	config, err := util.BuildConfig(explainJobFlags.Master, explainJobFlags.Kubeconfig)
+	if err != nil {
+		return err
+	}
+	if explainJobFlags.JobName == "" && explainJobFlags.PodGroup == "" {
+		err := fmt.Errorf("job name (specified by --name or -N) or podgroup name (specified by --podgroup) is mandatory to explain a particular job")
+		return err
+	}
+
+	pgName := explainJobFlags.PodGroup
+	if pgName == "" {
+		pgName, err = getJobPodGroupName(ctx, config, explainJobFlags.Namespace, explainJobFlags.JobName)
+		if err != nil {
+			return err
+		}
+	}
+
+	kubeClient := kubernetes.NewForConfigOrDie(config)
+	data, err := kubeClient.CoreV1().Services(explainJobFlags.SchedulerNamespace).ProxyGet("http",
+		explainJobFlags.SchedulerService, explainJobFlags.SchedulerPort, "/debug/explain",
+		map[string]string{"namespace": explainJobFlags.Namespace, "name": pgName}).DoRaw(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get explanation of podgroup <%s/%s> from vc-scheduler: %v %s",
+			explainJobFlags.Namespace, pgName, err, strings.TrimSpace(string(data)))
+	}
+
+	explanation := &jobExplanation{}
+	if err := json.Unmarshal(data, explanation); err != nil {
+		return fmt.Errorf("failed to decode explanation of podgroup <%s/%s>: %v", explainJobFlags.Namespace, pgName, err)
+	}
+	printExplanation(explanation, os.Stdout)
+	return nil
+}
+
+// getJobPodGroupName returns the name of podgroup created for the job.
+func getJobPodGroupName(ctx context.Context, config *rest.Config, namespace, name string) (string, error) {
+	jobClient := versioned.NewForConfigOrDie(config)
+	job, err := jobClient.BatchV1alpha1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	pgList, err := jobClient.SchedulingV1beta1().PodGroups(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return "", err
+	}
+	for _, pg := range pgList.Items {
+		for _, owner := range pg.OwnerReferences {
+			if owner.UID == job.UID {
+				return pg.Name, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("podgroup of job <%s/%s> not found", namespace, name)
+}
+
+// printExplanation prints the explanation of a pending podgroup into writer.
+func printExplanation(explanation *jobExplanation, writer io.Writer) {
+	WriteLine(writer, Level0, "Name:         \t%s\n", explanation.Name)
+	WriteLine(writer, Level0, "Namespace:    \t%s\n", explanation.Namespace)
+	WriteLine(writer, Level0, "Queue:        \t%s\n", explanation.Queue)
+	WriteLine(writer, Level0, "Phase:        \t%s\n", explanation.Phase)
+	WriteLine(writer, Level0, "Min Available:\t%d\n", explanation.MinAvailable)
+	if explanation.FitErrors != "" {
+		WriteLine(writer, Level0, "Fit Errors:   \t%s\n", explanation.FitErrors)
+	}
+
+	if len(explanation.Invalid) > 0 {
+		WriteLine(writer, Level0, "Invalid:\n")
+		for _, plugin := range sortedKeys(explanation.Invalid) {
+			WriteLine(writer, Level1, "%s:\t%s\n", plugin, explanation.Invalid[plugin])
+		}
+	}
+	if len(explanation.Overused) > 0 {
+		WriteLine(writer, Level0, "Queue Overused:\n")
+		for _, plugin := range sortedKeys(explanation.Overused) {
+			WriteLine(writer, Level1, "%s:\t%t\n", plugin, explanation.Overused[plugin])
+		}
+	}
+	if len(explanation.Enqueueable) > 0 {
+		WriteLine(writer, Level0, "Enqueueable:\n")
+		for _, plugin := range sortedKeys(explanation.Enqueueable) {
+			WriteLine(writer, Level1, "%s:\t%s\n", plugin, voteString(explanation.Enqueueable[plugin]))
+		}
+	}
+	if len(explanation.Ready) > 0 {
+		WriteLine(writer, Level0, "Job Ready:\n")
+		for _, plugin := range sortedKeys(explanation.Ready) {
+			WriteLine(writer, Level1, "%s:\t%t\n", plugin, explanation.Ready[plugin])
+		}
+	}
+
+	if len(explanation.Tasks) == 0 {
+		WriteLine(writer, Level0, "Pending Tasks:\t<none>\n")
+		return
+	}
+	WriteLine(writer, Level0, "Pending Tasks:\n")
+	for _, task := range explanation.Tasks {
+		WriteLine(writer, Level1, "%s:\n", task.Name)
+		if len(task.Allocatable) > 0 {
+			WriteLine(writer, Level2, "Queue Allocatable:\n")
+			for _, plugin := range sortedKeys(task.Allocatable) {
+				WriteLine(writer, Level2+1, "%s:\t%t\n", plugin, task.Allocatable[plugin])
+			}
+		}
+		if len(task.PrePredicateFailures) > 0 {
+			WriteLine(writer, Level2, "PrePredicate Failures:\n")
+			for _, plugin := range sortedKeys(task.PrePredicateFailures) {
+				WriteLine(writer, Level2+1, "%s:\t%s\n", plugin, task.PrePredicateFailures[plugin])
+			}
+		}
+		if len(task.FeasibleNodes) > 0 {
+			WriteLine(writer, Level2, "Feasible Nodes:\t%s\n", strings.Join(task.FeasibleNodes, ", "))
+		} else {
+			WriteLine(writer, Level2, "Feasible Nodes:\t<none>\n")
+		}
+		if len(task.PredicateFailures) > 0 {
+			WriteLine(writer, Level2, "Predicate Failures:\n")
+			for _, node := range sortedKeys(task.PredicateFailures) {
+				WriteLine(writer, Level2+1, "%s:\n", node)
+				for _, plugin := range sortedKeys(task.PredicateFailures[node]) {
+					WriteLine(writer, Level2+2, "%s:\t%s\n", plugin, task.PredicateFailures[node][plugin])
+				}
+			}
+		}
+	}
+}
+
+func voteString(vote int) string {
+	switch {
+	case vote > 0:
+		return "permit"
+	case vote < 0:
+		return "reject"
+	default:
+		return "abstain"
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
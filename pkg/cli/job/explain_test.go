@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package job
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+)
+
+func TestExplainJob(t *testing.T) {
+	job := v1alpha1.Job{ObjectMeta: metav1.ObjectMeta{Name: "testjob", Namespace: "test", UID: "uid"}}
+	pgList := schedulingv1beta1.PodGroupList{Items: []schedulingv1beta1.PodGroup{
+		{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "test"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "testjob-uid", Namespace: "test", OwnerReferences: []metav1.OwnerReference{{UID: "uid"}}}},
+	}}
+	explanation := jobExplanation{Namespace: "test", Name: "testjob-uid"}
+
+	var explained string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var response interface{}
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/jobs/testjob"):
+			response = job
+		case strings.HasSuffix(r.URL.Path, "/podgroups"):
+			response = pgList
+		case strings.HasSuffix(r.URL.Path, "/services/http:volcano-scheduler-service:8080/proxy/debug/explain"):
+			explained = r.URL.Query().Get("namespace") + "/" + r.URL.Query().Get("name")
+			response = explanation
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		val, err := json.Marshal(response)
+		if err == nil {
+			w.Write(val)
+		}
+	})
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	explainJobFlags.Master = server.URL
+	explainJobFlags.Namespace = "test"
+	explainJobFlags.SchedulerNamespace = "volcano-system"
+	explainJobFlags.SchedulerService = "volcano-scheduler-service"
+	explainJobFlags.SchedulerPort = "8080"
+
+	explainJobFlags.JobName = ""
+	if err := ExplainJob(context.TODO()); err == nil {
+		t.Errorf("expect error when neither job nor podgroup is specified")
+	}
+
+	explainJobFlags.JobName = "testjob"
+	if err := ExplainJob(context.TODO()); err != nil {
+		t.Fatalf("failed to explain job: %v", err)
+	}
+	if explained != "test/testjob-uid" {
+		t.Errorf("expect podgroup test/testjob-uid to be explained, got %s", explained)
+	}
+}
+
+func TestInitExplainFlags(t *testing.T) {
+	var cmd cobra.Command
+	InitExplainFlags(&cmd)
+
+	for _, name := range []string{"namespace", "name", "podgroup", "scheduler-namespace", "scheduler-service", "scheduler-port"} {
+		if cmd.Flag(name) == nil {
+			t.Errorf("Could not find the flag %s", name)
+		}
+	}
+}
+
+func TestPrintExplanation(t *testing.T) {
+	explanation := &jobExplanation{
+		Namespace:   "test",
+		Name:        "pg1",
+		Queue:       "default",
+		Phase:       "Pending",
+		Enqueueable: map[string]int{"proportion": -1, "overcommit": 0},
+		Tasks: []*taskExplanation{{
+			Name:              "p1",
+			PredicateFailures: map[string]map[string]string{"n1": {"predicates": "node(s) had untolerated taint"}},
+		}},
+	}
+
+	var buf bytes.Buffer
+	printExplanation(explanation, &buf)
+	output := buf.String()
+	for _, expected := range []string{"proportion:\treject", "overcommit:\tabstain", "Feasible Nodes:\t<none>", "predicates:\tnode(s) had untolerated taint"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expect %q in output:\n%s", expected, output)
+		}
+	}
+}
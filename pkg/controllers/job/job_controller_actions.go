@@ -37,6 +37,7 @@ import (
 	"volcano.sh/volcano/pkg/controllers/apis"
 	jobhelpers "volcano.sh/volcano/pkg/controllers/job/helpers"
 	"volcano.sh/volcano/pkg/controllers/job/state"
+	schedulerapi "volcano.sh/volcano/pkg/scheduler/api"
 )
 
 var calMutex sync.Mutex
@@ -744,7 +745,7 @@ func (cc *jobcontroller) createOrUpdatePodGroup(job *batch.Job) error {
 				Namespace: job.Namespace,
 				// add job.UID into its name when create new PodGroup
 				Name:        cc.generateRelatedPodGroupName(job),
-				Annotations: job.Annotations,
+				Annotations: podGroupAnnotations(job),
 				Labels:      job.Labels,
 				OwnerReferences: []metav1.OwnerReference{
 					*metav1.NewControllerRef(job, helpers.JobKind),
@@ -782,6 +783,18 @@ func (cc *jobcontroller) createOrUpdatePodGroup(job *batch.Job) error {
 		pgShouldUpdate = true
 	}
 
+	if estimate := runningEstimate(job); pg.Annotations[schedulerapi.JobRunningEstimate] != estimate {
+		if estimate == "" {
+			delete(pg.Annotations, schedulerapi.JobRunningEstimate)
+		} else {
+			if pg.Annotations == nil {
+				pg.Annotations = map[string]string{}
+			}
+			pg.Annotations[schedulerapi.JobRunningEstimate] = estimate
+		}
+		pgShouldUpdate = true
+	}
+
 	if pg.Spec.MinTaskMember == nil {
 		pgShouldUpdate = true
 		pg.Spec.MinTaskMember = make(map[string]int32)
@@ -818,6 +831,29 @@ func (cc *jobcontroller) createOrUpdatePodGroup(job *batch.Job) error {
 	return err
 }
 
+// podGroupAnnotations returns the annotations of the job together with its running estimate.
+func podGroupAnnotations(job *batch.Job) map[string]string {
+	estimate := runningEstimate(job)
+	if estimate == "" {
+		return job.Annotations
+	}
+
+	annotations := make(map[string]string, len(job.Annotations)+1)
+	for key, value := range job.Annotations {
+		annotations[key] = value
+	}
+	annotations[schedulerapi.JobRunningEstimate] = estimate
+	return annotations
+}
+
+// runningEstimate returns the running estimate of the job as annotation value, empty if it's not set.
+func runningEstimate(job *batch.Job) string {
+	if job.Spec.RunningEstimate == nil {
+		return ""
+	}
+	return job.Spec.RunningEstimate.Duration.String()
+}
+
 func (cc *jobcontroller) deleteJobPod(jobName string, pod *v1.Pod) error {
 	err := cc.kubeClient.CoreV1().Pods(pod.Namespace).Delete(context.TODO(), pod.Name, metav1.DeleteOptions{})
 	if err != nil && !apierrors.IsNotFound(err) {
@@ -569,9 +569,10 @@ func TestCreatePodGroupIfNotExistFunc(t *testing.T) {
 	namespace := "test"
 
 	testcases := []struct {
-		Name      string
-		Job       *v1alpha1.Job
-		ExpextVal error
+		Name              string
+		Job               *v1alpha1.Job
+		ExpextVal         error
+		ExpectAnnotations map[string]string
 	}{
 		{
 			Name: "CreatePodGroup success Case",
@@ -585,6 +586,23 @@ func TestCreatePodGroupIfNotExistFunc(t *testing.T) {
 			},
 			ExpextVal: nil,
 		},
+		{
+			Name: "CreatePodGroup with running estimate Case",
+			Job: &v1alpha1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:       namespace,
+					Name:            "job2",
+					ResourceVersion: "100",
+					UID:             "e7f18111-1cec-11ea-b688-fa163ec79501",
+					Annotations:     map[string]string{"foo": "bar"},
+				},
+				Spec: v1alpha1.JobSpec{
+					RunningEstimate: &metav1.Duration{Duration: 90 * time.Minute},
+				},
+			},
+			ExpextVal:         nil,
+			ExpectAnnotations: map[string]string{"foo": "bar", "volcano.sh/running-estimate": "1h30m0s"},
+		},
 	}
 
 	for _, testcase := range testcases {
@@ -597,9 +615,13 @@ func TestCreatePodGroupIfNotExistFunc(t *testing.T) {
 			}
 
 			pgName := testcase.Job.Name + "-" + string(testcase.Job.UID)
-			_, err = fakeController.vcClient.SchedulingV1beta1().PodGroups(namespace).Get(context.TODO(), pgName, metav1.GetOptions{})
+			pg, err := fakeController.vcClient.SchedulingV1beta1().PodGroups(namespace).Get(context.TODO(), pgName, metav1.GetOptions{})
 			if err != nil {
 				t.Error("Expected PodGroup to get created, but not created")
+				return
+			}
+			if testcase.ExpectAnnotations != nil && !reflect.DeepEqual(pg.Annotations, testcase.ExpectAnnotations) {
+				t.Errorf("Expected PodGroup annotations %v, but got %v", testcase.ExpectAnnotations, pg.Annotations)
 			}
 		})
 
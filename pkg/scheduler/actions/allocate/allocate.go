@@ -29,15 +29,28 @@ import (
 	"volcano.sh/volcano/pkg/scheduler/util"
 )
 
+// ArgumentsSchema is the schema of the arguments of allocate action
+var ArgumentsSchema = framework.ArgumentsSchema{
+	conf.EnablePredicateErrCacheKey: framework.BoolArgument,
+	conf.ParallelismKey:             framework.IntArgument,
+	conf.PercentageOfNodesToFindKey: framework.IntArgument,
+}
+
 type Action struct {
 	session *framework.Session
 	// configured flag for error cache
 	enablePredicateErrorCache bool
+	// parallelism is the number of workers predicating and scoring nodes for a task
+	parallelism int
+	// percentageOfNodesToFind is the percentage of nodes to find feasible and score for a task,
+	// the one in options is used if it's not greater than 0
+	percentageOfNodesToFind int
 }
 
 func New() *Action {
 	return &Action{
 		enablePredicateErrorCache: true, // default to enable it
+		parallelism:               util.DefaultParallelism,
 	}
 }
 
@@ -50,6 +63,8 @@ func (alloc *Action) Initialize() {}
 func (alloc *Action) parseArguments(ssn *framework.Session) {
 	arguments := framework.GetArgOfActionFromConf(ssn.Configurations, alloc.Name())
 	arguments.GetBool(&alloc.enablePredicateErrorCache, conf.EnablePredicateErrCacheKey)
+	arguments.GetInt(&alloc.parallelism, conf.ParallelismKey)
+	arguments.GetInt(&alloc.percentageOfNodesToFind, conf.PercentageOfNodesToFindKey)
 }
 
 func (alloc *Action) Execute(ssn *framework.Session) {
@@ -187,7 +202,7 @@ func (alloc *Action) allocateResources(queues *util.PriorityQueue, jobsMap map[a
 func (alloc *Action) allocateResourcesForTasks(tasks *util.PriorityQueue, job *api.JobInfo, jobs *util.PriorityQueue, queue *api.QueueInfo, allNodes []*api.NodeInfo) {
 	ssn := alloc.session
 	stmt := framework.NewStatement(ssn)
-	ph := util.NewPredicateHelper()
+	ph := util.NewPredicateHelperWithOptions(alloc.parallelism, int32(alloc.percentageOfNodesToFind))
 
 	for !tasks.Empty() {
 		task := tasks.Pop().(*api.TaskInfo)
@@ -277,7 +292,7 @@ func (alloc *Action) allocateResourcesForTasks(tasks *util.PriorityQueue, job *a
 			case len(nodes) == 1: // If only one node after predicate, just use it.
 				bestNode = nodes[0]
 			case len(nodes) > 1: // If more than one node after predicate, using "the best" one
-				nodeScores := util.PrioritizeNodes(task, nodes, ssn.BatchNodeOrderFn, ssn.NodeOrderMapFn, ssn.NodeOrderReduceFn)
+				nodeScores := util.PrioritizeNodesWithParallelism(alloc.parallelism, task, nodes, ssn.BatchNodeOrderFn, ssn.NodeOrderMapFn, ssn.NodeOrderReduceFn)
 
 				bestNode = ssn.BestNodeFn(task, nodeScores)
 				if bestNode == nil {
@@ -28,13 +28,30 @@ import (
 	"volcano.sh/volcano/pkg/scheduler/util"
 )
 
+// ArgumentsSchema is the schema of the arguments of backfill action
+var ArgumentsSchema = framework.ArgumentsSchema{
+	conf.EnablePredicateErrCacheKey: framework.BoolArgument,
+	conf.ParallelismKey:             framework.IntArgument,
+	conf.PercentageOfNodesToFindKey: framework.IntArgument,
+	conf.BackfillModeKey:            framework.StringArgument,
+}
+
 type Action struct {
 	enablePredicateErrorCache bool
+	// parallelism is the number of workers predicating and scoring nodes for a task
+	parallelism int
+	// percentageOfNodesToFind is the percentage of nodes to find feasible and score for a task,
+	// the one in options is used if it's not greater than 0
+	percentageOfNodesToFind int
+	// mode is the backfill mode, one of besteffort, easy and conservative
+	mode string
 }
 
 func New() *Action {
 	return &Action{
 		enablePredicateErrorCache: true, // default to enable it
+		parallelism:               util.DefaultParallelism,
+		mode:                      modeBestEffort,
 	}
 }
 
@@ -47,6 +64,15 @@ func (backfill *Action) Initialize() {}
 func (backfill *Action) parseArguments(ssn *framework.Session) {
 	arguments := framework.GetArgOfActionFromConf(ssn.Configurations, backfill.Name())
 	arguments.GetBool(&backfill.enablePredicateErrorCache, conf.EnablePredicateErrCacheKey)
+	arguments.GetInt(&backfill.parallelism, conf.ParallelismKey)
+	arguments.GetInt(&backfill.percentageOfNodesToFind, conf.PercentageOfNodesToFindKey)
+	arguments.GetString(&backfill.mode, conf.BackfillModeKey)
+	switch backfill.mode {
+	case modeBestEffort, modeEasy, modeConservative:
+	default:
+		klog.Warningf("Invalid backfill mode %s, use the default mode %s", backfill.mode, modeBestEffort)
+		backfill.mode = modeBestEffort
+	}
 }
 
 func (backfill *Action) Execute(ssn *framework.Session) {
@@ -61,7 +87,7 @@ func (backfill *Action) Execute(ssn *framework.Session) {
 	pendingTasks := backfill.pickUpPendingTasks(ssn)
 	for _, task := range pendingTasks {
 		job := ssn.Jobs[task.Job]
-		ph := util.NewPredicateHelper()
+		ph := util.NewPredicateHelperWithOptions(backfill.parallelism, int32(backfill.percentageOfNodesToFind))
 		fe := api.NewFitErrors()
 
 		if err := ssn.PrePredicateFn(task); err != nil {
@@ -81,7 +107,7 @@ func (backfill *Action) Execute(ssn *framework.Session) {
 
 		node := predicateNodes[0]
 		if len(predicateNodes) > 1 {
-			nodeScores := util.PrioritizeNodes(task, predicateNodes, ssn.BatchNodeOrderFn, ssn.NodeOrderMapFn, ssn.NodeOrderReduceFn)
+			nodeScores := util.PrioritizeNodesWithParallelism(backfill.parallelism, task, predicateNodes, ssn.BatchNodeOrderFn, ssn.NodeOrderMapFn, ssn.NodeOrderReduceFn)
 			node = ssn.BestNodeFn(task, nodeScores)
 			if node == nil {
 				node = util.SelectBestNode(nodeScores)
@@ -101,6 +127,10 @@ func (backfill *Action) Execute(ssn *framework.Session) {
 
 		// TODO (k82cn): backfill for other case.
 	}
+
+	if backfill.mode == modeEasy || backfill.mode == modeConservative {
+		backfill.timeWindowBackfill(ssn)
+	}
 }
 
 func (backfill *Action) UnInitialize() {}
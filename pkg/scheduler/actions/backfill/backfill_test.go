@@ -1,7 +1,9 @@
 package backfill
 
 import (
+	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
@@ -11,15 +13,24 @@ import (
 	"k8s.io/client-go/tools/record"
 	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 
+	"volcano.sh/volcano/cmd/scheduler/app/options"
 	"volcano.sh/volcano/pkg/scheduler/api"
 	"volcano.sh/volcano/pkg/scheduler/cache"
 	"volcano.sh/volcano/pkg/scheduler/conf"
 	"volcano.sh/volcano/pkg/scheduler/framework"
 	"volcano.sh/volcano/pkg/scheduler/plugins/drf"
+	"volcano.sh/volcano/pkg/scheduler/plugins/gang"
 	"volcano.sh/volcano/pkg/scheduler/plugins/priority"
+	"volcano.sh/volcano/pkg/scheduler/plugins/proportion"
+	"volcano.sh/volcano/pkg/scheduler/uthelper"
 	"volcano.sh/volcano/pkg/scheduler/util"
 )
 
+func TestMain(m *testing.M) {
+	options.Default()
+	os.Exit(m.Run())
+}
+
 func TestPickUpPendingTasks(t *testing.T) {
 	framework.RegisterPluginBuilder("priority", priority.New)
 	framework.RegisterPluginBuilder("drf", drf.New)
@@ -160,3 +171,188 @@ func TestPickUpPendingTasks(t *testing.T) {
 		}
 	}
 }
+
+func TestTimeWindowBackfill(t *testing.T) {
+	plugins := map[string]framework.PluginBuilder{gang.PluginName: gang.New, proportion.PluginName: proportion.New}
+	trueValue := true
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:               gang.PluginName,
+					EnabledJobReady:    &trueValue,
+					EnabledJobStarving: &trueValue,
+				},
+				{
+					Name:               proportion.PluginName,
+					EnabledOverused:    &trueValue,
+					EnabledAllocatable: &trueValue,
+				},
+			},
+		},
+	}
+
+	now := time.Now()
+	// buildPodGroup builds a podgroup created the given minutes ago with the running estimate if it is not empty
+	buildPodGroup := func(name string, createdAgo time.Duration, estimate string, phase schedulingv1beta1.PodGroupPhase) *schedulingv1beta1.PodGroup {
+		pg := util.BuildPodGroup(name, "c1", "q1", 1, nil, phase)
+		pg.CreationTimestamp = metav1.NewTime(now.Add(-createdAgo))
+		if estimate != "" {
+			pg.Annotations = map[string]string{api.JobRunningEstimate: estimate}
+		}
+		return pg
+	}
+	running := util.BuildPod("c1", "r1", "n1", v1.PodRunning, api.BuildResourceList("7", "7Gi"), "pg-run", nil, nil)
+	running.Status.StartTime = &metav1.Time{Time: now}
+	// overrun has run for 2h beyond its running estimate of 1h, next to a running pod ending in 1h
+	overrun := util.BuildPod("c1", "o1", "n1", v1.PodRunning, api.BuildResourceList("2", "2Gi"), "pg-over", nil, nil)
+	overrun.Status.StartTime = &metav1.Time{Time: now.Add(-3 * time.Hour)}
+	runningWithOverrun := util.BuildPod("c1", "r1", "n1", v1.PodRunning, api.BuildResourceList("5", "5Gi"), "pg-run", nil, nil)
+	runningWithOverrun.Status.StartTime = &metav1.Time{Time: now}
+	pendingPod := func(name, cpu, pg string) *v1.Pod {
+		return util.BuildPod("c1", name, "", v1.PodPending, api.BuildResourceList(cpu, "1Gi"), pg, nil, nil)
+	}
+
+	// the queue q2 can't hold a task requesting 1 cpu
+	limited := util.BuildQueue("q2", 1, api.BuildResourceList("500m", "1Gi"))
+	pgInQueue := func(pg *schedulingv1beta1.PodGroup, queue string) *schedulingv1beta1.PodGroup {
+		pg.Spec.Queue = queue
+		return pg
+	}
+
+	tests := []struct {
+		uthelper.TestCommonStruct
+		mode string
+	}{
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "a short job is backfilled before the shadow time of the head job",
+				PodGroups: []*schedulingv1beta1.PodGroup{
+					buildPodGroup("pg-run", 4*time.Hour, "1h", schedulingv1beta1.PodGroupRunning),
+					buildPodGroup("pg-head", 3*time.Hour, "1h", schedulingv1beta1.PodGroupInqueue),
+					buildPodGroup("pg-short", 2*time.Hour, "30m", schedulingv1beta1.PodGroupInqueue),
+				},
+				Pods:           []*v1.Pod{running, pendingPod("head", "8", "pg-head"), pendingPod("short", "1", "pg-short")},
+				ExpectBindMap:  map[string]string{"c1/short": "n1"},
+				ExpectBindsNum: 1,
+			},
+			mode: modeEasy,
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "a long job is not backfilled as it delays the head job",
+				PodGroups: []*schedulingv1beta1.PodGroup{
+					buildPodGroup("pg-run", 4*time.Hour, "1h", schedulingv1beta1.PodGroupRunning),
+					buildPodGroup("pg-head", 3*time.Hour, "1h", schedulingv1beta1.PodGroupInqueue),
+					buildPodGroup("pg-long", 2*time.Hour, "2h", schedulingv1beta1.PodGroupInqueue),
+				},
+				Pods:          []*v1.Pod{running, pendingPod("head", "8", "pg-head"), pendingPod("long", "1", "pg-long")},
+				ExpectBindMap: map[string]string{},
+			},
+			mode: modeEasy,
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "a job without running estimate is not backfilled as it delays the head job",
+				PodGroups: []*schedulingv1beta1.PodGroup{
+					buildPodGroup("pg-run", 4*time.Hour, "1h", schedulingv1beta1.PodGroupRunning),
+					buildPodGroup("pg-head", 3*time.Hour, "1h", schedulingv1beta1.PodGroupInqueue),
+					buildPodGroup("pg-unknown", 2*time.Hour, "", schedulingv1beta1.PodGroupInqueue),
+				},
+				Pods:          []*v1.Pod{running, pendingPod("head", "8", "pg-head"), pendingPod("unknown", "1", "pg-unknown")},
+				ExpectBindMap: map[string]string{},
+			},
+			mode: modeEasy,
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "a short job is not backfilled in besteffort mode",
+				PodGroups: []*schedulingv1beta1.PodGroup{
+					buildPodGroup("pg-run", 4*time.Hour, "1h", schedulingv1beta1.PodGroupRunning),
+					buildPodGroup("pg-head", 3*time.Hour, "1h", schedulingv1beta1.PodGroupInqueue),
+					buildPodGroup("pg-short", 2*time.Hour, "30m", schedulingv1beta1.PodGroupInqueue),
+				},
+				Pods:          []*v1.Pod{running, pendingPod("head", "8", "pg-head"), pendingPod("short", "1", "pg-short")},
+				ExpectBindMap: map[string]string{},
+			},
+			mode: modeBestEffort,
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "a short job is not backfilled beyond the capability of its queue",
+				PodGroups: []*schedulingv1beta1.PodGroup{
+					buildPodGroup("pg-run", 4*time.Hour, "1h", schedulingv1beta1.PodGroupRunning),
+					buildPodGroup("pg-head", 3*time.Hour, "1h", schedulingv1beta1.PodGroupInqueue),
+					pgInQueue(buildPodGroup("pg-short", 2*time.Hour, "30m", schedulingv1beta1.PodGroupInqueue), "q2"),
+				},
+				Queues:        []*schedulingv1beta1.Queue{util.BuildQueue("q1", 1, nil), limited},
+				Pods:          []*v1.Pod{running, pendingPod("head", "8", "pg-head"), pendingPod("short", "1", "pg-short")},
+				ExpectBindMap: map[string]string{},
+			},
+			mode: modeEasy,
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "the resources of an overrun job are not expected to be free at the shadow time of the head job",
+				PodGroups: []*schedulingv1beta1.PodGroup{
+					buildPodGroup("pg-over", 5*time.Hour, "1h", schedulingv1beta1.PodGroupRunning),
+					buildPodGroup("pg-run", 4*time.Hour, "1h", schedulingv1beta1.PodGroupRunning),
+					buildPodGroup("pg-head", 3*time.Hour, "1h", schedulingv1beta1.PodGroupInqueue),
+					buildPodGroup("pg-long", 2*time.Hour, "3h", schedulingv1beta1.PodGroupInqueue),
+				},
+				Pods: []*v1.Pod{overrun, runningWithOverrun, pendingPod("head", "6", "pg-head"),
+					pendingPod("long", "1", "pg-long")},
+				ExpectBindMap: map[string]string{},
+			},
+			mode: modeEasy,
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "a job delaying the second blocked job is backfilled in easy mode",
+				PodGroups: []*schedulingv1beta1.PodGroup{
+					buildPodGroup("pg-run", 4*time.Hour, "1h", schedulingv1beta1.PodGroupRunning),
+					buildPodGroup("pg-head", 3*time.Hour, "1h", schedulingv1beta1.PodGroupInqueue),
+					buildPodGroup("pg-second", 150*time.Minute, "1h", schedulingv1beta1.PodGroupInqueue),
+					buildPodGroup("pg-medium", 2*time.Hour, "150m", schedulingv1beta1.PodGroupInqueue),
+				},
+				Pods: []*v1.Pod{running, pendingPod("head", "6", "pg-head"), pendingPod("second", "8", "pg-second"),
+					pendingPod("medium", "1", "pg-medium")},
+				ExpectBindMap:  map[string]string{"c1/medium": "n1"},
+				ExpectBindsNum: 1,
+			},
+			mode: modeEasy,
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "a job delaying the second blocked job is not backfilled in conservative mode",
+				PodGroups: []*schedulingv1beta1.PodGroup{
+					buildPodGroup("pg-run", 4*time.Hour, "1h", schedulingv1beta1.PodGroupRunning),
+					buildPodGroup("pg-head", 3*time.Hour, "1h", schedulingv1beta1.PodGroupInqueue),
+					buildPodGroup("pg-second", 150*time.Minute, "1h", schedulingv1beta1.PodGroupInqueue),
+					buildPodGroup("pg-medium", 2*time.Hour, "150m", schedulingv1beta1.PodGroupInqueue),
+				},
+				Pods: []*v1.Pod{running, pendingPod("head", "6", "pg-head"), pendingPod("second", "8", "pg-second"),
+					pendingPod("medium", "1", "pg-medium")},
+				ExpectBindMap: map[string]string{},
+			},
+			mode: modeConservative,
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			test.Plugins = plugins
+			test.Nodes = []*v1.Node{util.BuildNode("n1", api.BuildResourceList("8", "16Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil)}
+			if test.Queues == nil {
+				test.Queues = []*schedulingv1beta1.Queue{util.BuildQueue("q1", 1, nil)}
+			}
+			config := []conf.Configuration{{Name: "backfill", Arguments: map[string]interface{}{conf.BackfillModeKey: test.mode}}}
+			test.RegisterSession(tiers, config)
+			defer test.Close()
+			test.Run([]framework.Action{New()})
+			if err := test.CheckAll(i); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,298 @@
+/*
+Copyright 2026 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backfill
+
+import (
+	"sort"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/metrics"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+const (
+	// modeBestEffort only backfills the BestEffort tasks, which is the default.
+	modeBestEffort = "besteffort"
+	// modeEasy also backfills the jobs which do not delay the first starving job that can not run now.
+	modeEasy = "easy"
+	// modeConservative also backfills the jobs which do not delay any starving job that can not run now.
+	modeConservative = "conservative"
+)
+
+// resourceProfile is the resources expected to be free over time, free[i] is free from times[i] until times[i+1].
+// The resources are released at the running estimates of the jobs, the ones of the jobs without estimate are never released.
+type resourceProfile struct {
+	times []time.Time
+	free  []*api.Resource
+}
+
+func newResourceProfile(now time.Time, idle *api.Resource) *resourceProfile {
+	return &resourceProfile{
+		times: []time.Time{now},
+		free:  []*api.Resource{idle},
+	}
+}
+
+// split makes sure there is a time point at the given time and returns its index.
+func (p *resourceProfile) split(at time.Time) int {
+	if !at.After(p.times[0]) {
+		return 0
+	}
+	i := sort.Search(len(p.times), func(i int) bool {
+		return !p.times[i].Before(at)
+	})
+	if i < len(p.times) && p.times[i].Equal(at) {
+		return i
+	}
+
+	p.times = append(p.times, time.Time{})
+	copy(p.times[i+1:], p.times[i:])
+	p.times[i] = at
+	p.free = append(p.free, nil)
+	copy(p.free[i+1:], p.free[i:])
+	p.free[i] = p.free[i-1].Clone()
+	return i
+}
+
+// release adds the resources released at the given time to the free resources from then on.
+func (p *resourceProfile) release(at time.Time, resreq *api.Resource) {
+	for i := p.split(at); i < len(p.free); i++ {
+		p.free[i].Add(resreq)
+	}
+}
+
+// fits checks whether the resources are free from the i-th time point for the duration, forever if it's nil.
+func (p *resourceProfile) fits(i int, resreq *api.Resource, duration *time.Duration) bool {
+	for j := i; j < len(p.times); j++ {
+		if duration != nil && !p.times[j].Before(p.times[i].Add(*duration)) {
+			break
+		}
+		if !resreq.LessEqual(p.free[j], api.Zero) {
+			return false
+		}
+	}
+	return true
+}
+
+// earliestStart returns the first time point from which the resources are free for the duration.
+func (p *resourceProfile) earliestStart(resreq *api.Resource, duration *time.Duration) (time.Time, bool) {
+	for i := range p.times {
+		if p.fits(i, resreq, duration) {
+			return p.times[i], true
+		}
+	}
+	return time.Time{}, false
+}
+
+// reserve takes the resources from the start for the duration, forever if it's nil.
+func (p *resourceProfile) reserve(start time.Time, resreq *api.Resource, duration *time.Duration) {
+	i := p.split(start)
+	end := len(p.times)
+	if duration != nil {
+		end = p.split(start.Add(*duration))
+	}
+	for ; i < end; i++ {
+		p.free[i].Sub(resreq)
+	}
+}
+
+// timeWindowBackfill backfills the starving jobs which are able to run now without delaying the jobs holding a
+// reservation. In easy mode the first starving job that can not run now reserves the resources from its shadow time,
+// which is the earliest time the resources it needs are expected to be free according to the running estimates of
+// the running jobs; in conservative mode every starving job that can not run now does.
+func (backfill *Action) timeWindowBackfill(ssn *framework.Session) {
+	now := time.Now()
+	profile := newResourceProfile(now, api.EmptyResource())
+	for _, node := range ssn.Nodes {
+		if !node.Ready() {
+			continue
+		}
+		profile.release(now, node.FutureIdle())
+		for _, task := range node.Tasks {
+			if !api.AllocatedStatus(task.Status) {
+				continue
+			}
+			job, found := ssn.Jobs[task.Job]
+			if !found || job.RunningEstimate == nil {
+				continue
+			}
+			start := now
+			if task.Pod != nil && task.Pod.Status.StartTime != nil {
+				start = task.Pod.Status.StartTime.Time
+			}
+			// the task running beyond its estimate is not expected to end at any known time
+			if end := start.Add(*job.RunningEstimate); end.After(now) {
+				profile.release(end, task.Resreq)
+			}
+		}
+	}
+
+	reserved := 0
+	for _, job := range backfill.pickUpStarvingJobs(ssn) {
+		if ssn.Overused(ssn.Queues[job.Queue]) {
+			klog.V(3).Infof("Queue <%s> is overused, skip job <%s/%s> in backfill.", job.Queue, job.Namespace, job.Name)
+			continue
+		}
+		tasks, resreq := neededTasks(ssn, job)
+		if len(tasks) == 0 {
+			continue
+		}
+
+		if profile.fits(0, resreq, job.RunningEstimate) && backfill.allocateJob(ssn, job, tasks) {
+			profile.reserve(now, resreq, job.RunningEstimate)
+			continue
+		}
+
+		if backfill.mode == modeEasy && reserved > 0 {
+			continue
+		}
+		shadow, found := profile.earliestStart(resreq, job.RunningEstimate)
+		if !found {
+			klog.V(4).Infof("No shadow time of job <%s/%s> in backfill, the resources it needs are not expected to be free",
+				job.Namespace, job.Name)
+			continue
+		}
+		klog.V(3).Infof("Reserve resources <%v> for job <%s/%s> from shadow time %v in backfill",
+			resreq, job.Namespace, job.Name, shadow)
+		profile.reserve(shadow, resreq, job.RunningEstimate)
+		reserved++
+	}
+}
+
+// pickUpStarvingJobs returns the starving jobs with pending tasks that are not BestEffort, in queue and job order.
+func (backfill *Action) pickUpStarvingJobs(ssn *framework.Session) []*api.JobInfo {
+	queues := util.NewPriorityQueue(ssn.QueueOrderFn)
+	jobs := map[api.QueueID]*util.PriorityQueue{}
+	for _, job := range ssn.Jobs {
+		if job.IsPending() || !ssn.JobStarving(job) {
+			continue
+		}
+
+		if vr := ssn.JobValid(job); vr != nil && !vr.Pass {
+			klog.V(4).Infof("Job <%s/%s> Queue <%s> skip backfill, reason: %v, message %v", job.Namespace, job.Name, job.Queue, vr.Reason, vr.Message)
+			continue
+		}
+
+		queue, found := ssn.Queues[job.Queue]
+		if !found {
+			continue
+		}
+
+		if _, existed := jobs[queue.UID]; !existed {
+			queues.Push(queue)
+			jobs[job.Queue] = util.NewPriorityQueue(ssn.JobOrderFn)
+		}
+		jobs[job.Queue].Push(job)
+	}
+
+	var starvingJobs []*api.JobInfo
+	for !queues.Empty() {
+		queue := queues.Pop().(*api.QueueInfo)
+		for !jobs[queue.UID].Empty() {
+			starvingJobs = append(starvingJobs, jobs[queue.UID].Pop().(*api.JobInfo))
+		}
+	}
+	return starvingJobs
+}
+
+// neededTasks returns the pending tasks the job needs to be ready in task order and the resources they request.
+func neededTasks(ssn *framework.Session, job *api.JobInfo) ([]*api.TaskInfo, *api.Resource) {
+	tasks := make([]*api.TaskInfo, 0, len(job.TaskStatusIndex[api.Pending]))
+	for _, task := range job.TaskStatusIndex[api.Pending] {
+		if task.BestEffort || task.SchGated {
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return ssn.TaskOrderFn(tasks[i], tasks[j])
+	})
+	if needed := int(job.MinAvailable - job.ReadyTaskNum() - job.WaitingTaskNum()); needed > 0 && len(tasks) > needed {
+		tasks = tasks[:needed]
+	}
+
+	resreq := api.EmptyResource()
+	for _, task := range tasks {
+		resreq.Add(task.InitResreq)
+	}
+	return tasks, resreq
+}
+
+// allocateJob allocates the tasks of the job on the idle resources of the nodes, the allocation is only
+// committed if the queue can hold all the tasks and the job is ready then. The nodes reserved for other
+// jobs are filtered out by the predicates.
+func (backfill *Action) allocateJob(ssn *framework.Session, job *api.JobInfo, tasks []*api.TaskInfo) bool {
+	queue := ssn.Queues[job.Queue]
+	predicateFunc := func(task *api.TaskInfo, node *api.NodeInfo) error {
+		if ok, resources := task.InitResreq.LessEqualWithResourcesName(node.Idle, api.Zero); !ok {
+			return api.NewFitErrWithStatus(task, node, &api.Status{Code: api.Unschedulable, Reason: api.WrapInsufficientResourceReason(resources)})
+		}
+		return ssn.PredicateForAllocateAction(task, node)
+	}
+
+	stmt := framework.NewStatement(ssn)
+	for _, task := range tasks {
+		if !ssn.Allocatable(queue, task) {
+			klog.V(3).Infof("Queue <%s> is overused when considering task <%s/%s> in backfill.", queue.Name, task.Namespace, task.Name)
+			stmt.Discard()
+			return false
+		}
+		if err := ssn.PrePredicateFn(task); err != nil {
+			klog.V(3).Infof("PrePredicate for task %s/%s failed in backfill for: %v", task.Namespace, task.Name, err)
+			stmt.Discard()
+			return false
+		}
+
+		ph := util.NewPredicateHelperWithOptions(backfill.parallelism, int32(backfill.percentageOfNodesToFind))
+		predicateNodes, fitErrors := ph.PredicateNodes(task, ssn.NodeList, predicateFunc, backfill.enablePredicateErrorCache)
+		if len(predicateNodes) == 0 {
+			job.NodesFitErrors[task.UID] = fitErrors
+			stmt.Discard()
+			return false
+		}
+
+		node := predicateNodes[0]
+		if len(predicateNodes) > 1 {
+			nodeScores := util.PrioritizeNodesWithParallelism(backfill.parallelism, task, predicateNodes, ssn.BatchNodeOrderFn, ssn.NodeOrderMapFn, ssn.NodeOrderReduceFn)
+			node = ssn.BestNodeFn(task, nodeScores)
+			if node == nil {
+				node = util.SelectBestNode(nodeScores)
+			}
+		}
+
+		if err := stmt.Allocate(task, node); err != nil {
+			klog.Errorf("Failed to allocate Task %v on %v in Session %v in backfill: %v", task.UID, node.Name, ssn.UID, err)
+			stmt.Discard()
+			return false
+		}
+	}
+
+	if !ssn.JobReady(job) {
+		stmt.Discard()
+		return false
+	}
+
+	klog.V(3).Infof("Backfill job <%s/%s> with %d tasks", job.Namespace, job.Name, len(tasks))
+	stmt.Commit()
+	metrics.UpdateE2eSchedulingDurationByJob(job.Name, string(job.Queue), job.Namespace, metrics.Duration(job.CreationTimestamp.Time))
+	metrics.UpdateE2eSchedulingLastTimeByJob(job.Name, string(job.Queue), job.Namespace, time.Now())
+	return true
+}
@@ -33,4 +33,9 @@ func init() {
 	framework.RegisterAction(preempt.New())
 	framework.RegisterAction(enqueue.New())
 	framework.RegisterAction(shuffle.New())
+
+	framework.RegisterActionArgumentsSchema("reclaim", reclaim.ArgumentsSchema)
+	framework.RegisterActionArgumentsSchema("allocate", allocate.ArgumentsSchema)
+	framework.RegisterActionArgumentsSchema("backfill", backfill.ArgumentsSchema)
+	framework.RegisterActionArgumentsSchema("preempt", preempt.ArgumentsSchema)
 }
@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/klog/v2"
 
 	"volcano.sh/volcano/pkg/scheduler/api"
@@ -29,8 +30,21 @@ import (
 	"volcano.sh/volcano/pkg/scheduler/util"
 )
 
+// ArgumentsSchema is the schema of the arguments of preempt action
+var ArgumentsSchema = framework.ArgumentsSchema{
+	conf.EnablePredicateErrCacheKey: framework.BoolArgument,
+	conf.PreemptionCostKey:          framework.StringArgument,
+	conf.GangEvictionModeKey:        framework.StringArgument,
+}
+
 type Action struct {
 	enablePredicateErrorCache bool
+	// costCriteria are the criteria comparing the victims on the nodes, the preemptor preempts on
+	// the first node where it fits if it's empty
+	costCriteria []string
+	pdbs         []*policyv1.PodDisruptionBudget
+	// gangEvictionMode is how the tasks of the victim gang jobs are evicted
+	gangEvictionMode framework.GangEvictionMode
 }
 
 func New() *Action {
@@ -48,6 +62,15 @@ func (pmpt *Action) Initialize() {}
 func (pmpt *Action) parseArguments(ssn *framework.Session) {
 	arguments := framework.GetArgOfActionFromConf(ssn.Configurations, pmpt.Name())
 	arguments.GetBool(&pmpt.enablePredicateErrorCache, conf.EnablePredicateErrCacheKey)
+
+	cost := ""
+	arguments.GetString(&cost, conf.PreemptionCostKey)
+	pmpt.costCriteria = parseCostCriteria(cost)
+	pmpt.pdbs = listPDBs(ssn, pmpt.costCriteria)
+
+	mode := ""
+	arguments.GetString(&mode, conf.GangEvictionModeKey)
+	pmpt.gangEvictionMode = framework.ParseGangEvictionMode(mode)
 }
 
 func (pmpt *Action) Execute(ssn *framework.Session) {
@@ -256,22 +279,18 @@ func (pmpt *Action) preempt(
 
 	currentQueue := ssn.Queues[job.Queue]
 
+	if len(pmpt.costCriteria) > 0 {
+		return pmpt.preemptOnCheapestNode(ssn, stmt, preemptor, selectedNodes, filter, func(idle, released *api.Resource) bool {
+			return ssn.Allocatable(currentQueue, remainingRequest(preemptor, released)) && preemptor.InitResreq.LessEqual(idle, api.Zero)
+		}), nil
+	}
+
 	for _, node := range selectedNodes {
 		klog.V(3).Infof("Considering Task <%s/%s> on Node <%s>.",
 			preemptor.Namespace, preemptor.Name, node.Name)
 
-		var preemptees []*api.TaskInfo
-		for _, task := range node.Tasks {
-			if filter == nil {
-				preemptees = append(preemptees, task.Clone())
-			} else if filter(task) {
-				preemptees = append(preemptees, task.Clone())
-			}
-		}
-		victims := ssn.Preemptable(preemptor, preemptees)
-		metrics.UpdatePreemptionVictimsCount(len(victims))
-
-		if err := util.ValidateVictims(preemptor, node, victims); err != nil {
+		victims, err := nodeVictims(ssn, preemptor, node, filter)
+		if err != nil {
 			klog.V(3).Infof("No validated victims on Node <%s>: %v", node.Name, err)
 			continue
 		}
@@ -279,6 +298,8 @@ func (pmpt *Action) preempt(
 		victimsQueue := ssn.BuildVictimsPriorityQueue(victims, preemptor)
 		// Preempt victims for tasks, pick lowest priority task first.
 		preempted := api.EmptyResource()
+		evicted := make(map[api.TaskID]bool)
+		var preemptees []*api.TaskInfo
 
 		for !victimsQueue.Empty() {
 			// If reclaimed enough resources, break loop to avoid Sub panic.
@@ -294,16 +315,27 @@ func (pmpt *Action) preempt(
 			if ssn.Allocatable(currentQueue, preemptor) && preemptor.InitResreq.LessEqual(node.FutureIdle(), api.Zero) {
 				break
 			}
-			preemptee := victimsQueue.Pop().(*api.TaskInfo)
-			klog.V(3).Infof("Try to preempt Task <%s/%s> for Task <%s/%s>",
-				preemptee.Namespace, preemptee.Name, preemptor.Namespace, preemptor.Name)
-			if err := stmt.Evict(preemptee, "preempt"); err != nil {
-				klog.Errorf("Failed to preempt Task <%s/%s> for Task <%s/%s>: %v",
-					preemptee.Namespace, preemptee.Name, preemptor.Namespace, preemptor.Name, err)
+			victim := victimsQueue.Pop().(*api.TaskInfo)
+			if evicted[victim.UID] {
 				continue
 			}
-			preempted.Add(preemptee.Resreq)
+			for _, preemptee := range ssn.GangVictims(preemptor, victim, pmpt.gangEvictionMode, nil, filter, ssn.Preemptable) {
+				if evicted[preemptee.UID] {
+					continue
+				}
+				klog.V(3).Infof("Try to preempt Task <%s/%s> for Task <%s/%s>",
+					preemptee.Namespace, preemptee.Name, preemptor.Namespace, preemptor.Name)
+				if err := stmt.Evict(preemptee, "preempt"); err != nil {
+					klog.Errorf("Failed to preempt Task <%s/%s> for Task <%s/%s>: %v",
+						preemptee.Namespace, preemptee.Name, preemptor.Namespace, preemptor.Name, err)
+					continue
+				}
+				evicted[preemptee.UID] = true
+				preempted.Add(preemptee.Resreq)
+				preemptees = append(preemptees, preemptee)
+			}
 		}
+		stmt.RecordPreemption(pmpt.Name(), preemptor, preemptees)
 
 		evictionOccurred := false
 		if !preempted.IsEmpty() {
@@ -316,14 +348,8 @@ func (pmpt *Action) preempt(
 
 		// If preemptor's queue is overused, it means preemptor can not be allocated. So no need care about the node idle resource
 		if ssn.Allocatable(currentQueue, preemptor) && preemptor.InitResreq.LessEqual(node.FutureIdle(), api.Zero) {
-			if err := stmt.Pipeline(preemptor, node.Name, evictionOccurred); err != nil {
-				klog.Errorf("Failed to pipeline Task <%s/%s> on Node <%s>",
-					preemptor.Namespace, preemptor.Name, node.Name)
-				if rollbackErr := stmt.UnPipeline(preemptor); rollbackErr != nil {
-					klog.Errorf("Failed to unpipeline Task %v on %v in Session %v for %v.",
-						preemptor.UID, node.Name, ssn.UID, rollbackErr)
-				}
-			}
+			metrics.UpdatePreemptionVictimsCount(len(preemptees))
+			pipeline(ssn, stmt, preemptor, node, evictionOccurred)
 
 			// Ignore pipeline error, will be corrected in next scheduling loop.
 			assigned = true
@@ -335,6 +361,57 @@ func (pmpt *Action) preempt(
 	return assigned, nil
 }
 
+// preemptOnCheapestNode evicts the victims on the node with the lowest preemption cost, and pipelines the preemptor onto the node.
+func (pmpt *Action) preemptOnCheapestNode(
+	ssn *framework.Session,
+	stmt *framework.Statement,
+	preemptor *api.TaskInfo,
+	nodes []*api.NodeInfo,
+	filter func(*api.TaskInfo) bool,
+	fits fitFn,
+) bool {
+	best := pmpt.pickOneNodeForPreemption(ssn, preemptor, nodes, filter, fits)
+	metrics.RegisterPreemptionAttempts()
+	if best == nil {
+		klog.V(3).Infof("No node found for Task <%s/%s> to preempt.", preemptor.Namespace, preemptor.Name)
+		return false
+	}
+
+	klog.V(3).Infof("Task <%s/%s> preempts %d tasks on Node <%s> at cost %v.",
+		preemptor.Namespace, preemptor.Name, len(best.victims), best.node.Name, best.cost)
+	metrics.UpdatePreemptionVictimsCount(len(best.victims))
+	var preemptees []*api.TaskInfo
+	for _, preemptee := range best.victims {
+		klog.V(3).Infof("Try to preempt Task <%s/%s> for Task <%s/%s>",
+			preemptee.Namespace, preemptee.Name, preemptor.Namespace, preemptor.Name)
+		if err := stmt.Evict(preemptee, "preempt"); err != nil {
+			klog.Errorf("Failed to preempt Task <%s/%s> for Task <%s/%s>: %v",
+				preemptee.Namespace, preemptee.Name, preemptor.Namespace, preemptor.Name, err)
+			continue
+		}
+		preemptees = append(preemptees, preemptee)
+	}
+	stmt.RecordPreemption(pmpt.Name(), preemptor, preemptees)
+	// the session already accounts for the victims evicted by the statement
+	if !fits(best.node.FutureIdle(), api.EmptyResource()) {
+		return false
+	}
+	pipeline(ssn, stmt, preemptor, best.node, len(best.victims) > 0)
+	return true
+}
+
+// pipeline pipelines the preemptor onto the node, the error is ignored and corrected in next scheduling loop.
+func pipeline(ssn *framework.Session, stmt *framework.Statement, preemptor *api.TaskInfo, node *api.NodeInfo, evictionOccurred bool) {
+	if err := stmt.Pipeline(preemptor, node.Name, evictionOccurred); err != nil {
+		klog.Errorf("Failed to pipeline Task <%s/%s> on Node <%s>",
+			preemptor.Namespace, preemptor.Name, node.Name)
+		if rollbackErr := stmt.UnPipeline(preemptor); rollbackErr != nil {
+			klog.Errorf("Failed to unpipeline Task %v on %v in Session %v for %v.",
+				preemptor.UID, node.Name, ssn.UID, rollbackErr)
+		}
+	}
+}
+
 func (pmpt *Action) taskEligibleToPreempt(preemptor *api.TaskInfo) error {
 	if preemptor.Pod.Spec.PreemptionPolicy != nil && *preemptor.Pod.Spec.PreemptionPolicy == v1.PreemptNever {
 		return fmt.Errorf("not eligible to preempt other tasks due to preemptionPolicy is Never")
@@ -17,10 +17,14 @@ limitations under the License.
 package preempt
 
 import (
+	"os"
+	"reflect"
 	"testing"
 
 	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	schedulingv1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 	"volcano.sh/volcano/cmd/scheduler/app/options"
@@ -35,6 +39,11 @@ import (
 	"volcano.sh/volcano/pkg/scheduler/util"
 )
 
+func TestMain(m *testing.M) {
+	options.Default()
+	os.Exit(m.Run())
+}
+
 func TestPreempt(t *testing.T) {
 	plugins := map[string]framework.PluginBuilder{
 		conformance.PluginName: conformance.New,
@@ -44,7 +53,6 @@ func TestPreempt(t *testing.T) {
 	}
 	highPrio := util.BuildPriorityClass("high-priority", 100000)
 	lowPrio := util.BuildPriorityClass("low-priority", 10)
-	options.Default()
 
 	tests := []uthelper.TestCommonStruct{
 		{
@@ -300,3 +308,526 @@ func TestPreempt(t *testing.T) {
 		})
 	}
 }
+
+func TestPreemptWithMinimalCost(t *testing.T) {
+	plugins := map[string]framework.PluginBuilder{
+		conformance.PluginName: conformance.New,
+		gang.PluginName:        gang.New,
+		priority.PluginName:    priority.New,
+		proportion.PluginName:  proportion.New,
+	}
+	highPrio := util.BuildPriorityClass("high-priority", 100000)
+	lowPrio := util.BuildPriorityClass("low-priority", 10)
+
+	lowTaskPrio, highTaskPrio := int32(1), int32(100)
+	preemptable := map[string]string{schedulingv1beta1.PodPreemptable: "true"}
+	tests := []struct {
+		uthelper.TestCommonStruct
+		cost string
+	}{
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "preempt on the node with the fewest victims",
+				PodGroups: []*schedulingv1beta1.PodGroup{
+					util.BuildPodGroupWithPrio("pg1", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning, "low-priority"),
+					util.BuildPodGroupWithPrio("pg2", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue, "high-priority"),
+				},
+				Pods: []*v1.Pod{
+					util.BuildPod("c1", "preemptee1", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", preemptable, make(map[string]string)),
+					util.BuildPod("c1", "preemptee2", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", preemptable, make(map[string]string)),
+					util.BuildPod("c1", "preemptee3", "n2", v1.PodRunning, api.BuildResourceList("2", "2G"), "pg1", preemptable, make(map[string]string)),
+					util.BuildPod("c1", "preemptor1", "", v1.PodPending, api.BuildResourceList("2", "2G"), "pg2", make(map[string]string), make(map[string]string)),
+				},
+				Nodes: []*v1.Node{
+					util.BuildNode("n1", api.BuildResourceList("2", "2G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string)),
+					util.BuildNode("n2", api.BuildResourceList("2", "2G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string)),
+				},
+				Queues: []*schedulingv1beta1.Queue{
+					util.BuildQueue("q1", 1, nil),
+				},
+				ExpectEvicted:  []string{"c1/preemptee3"},
+				ExpectEvictNum: 1,
+			},
+			cost: "victims",
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "preempt on the node whose victims have the lowest priority",
+				PodGroups: []*schedulingv1beta1.PodGroup{
+					util.BuildPodGroupWithPrio("pg1", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning, "low-priority"),
+					util.BuildPodGroupWithPrio("pg2", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue, "high-priority"),
+				},
+				Pods: []*v1.Pod{
+					util.BuildPodWithPriority("c1", "preemptee1", "n1", v1.PodRunning, api.BuildResourceList("2", "2G"), "pg1", preemptable, make(map[string]string), &highTaskPrio),
+					util.BuildPodWithPriority("c1", "preemptee2", "n2", v1.PodRunning, api.BuildResourceList("2", "2G"), "pg1", preemptable, make(map[string]string), &lowTaskPrio),
+					util.BuildPod("c1", "preemptor1", "", v1.PodPending, api.BuildResourceList("2", "2G"), "pg2", make(map[string]string), make(map[string]string)),
+				},
+				Nodes: []*v1.Node{
+					util.BuildNode("n1", api.BuildResourceList("2", "2G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string)),
+					util.BuildNode("n2", api.BuildResourceList("2", "2G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string)),
+				},
+				Queues: []*schedulingv1beta1.Queue{
+					util.BuildQueue("q1", 1, nil),
+				},
+				ExpectEvicted:  []string{"c1/preemptee2"},
+				ExpectEvictNum: 1,
+			},
+			cost: "highestPriority,victims",
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "reprieve the victims whose eviction is not needed",
+				PodGroups: []*schedulingv1beta1.PodGroup{
+					util.BuildPodGroupWithPrio("pg1", "c1", "q1", 0, nil, schedulingv1beta1.PodGroupRunning, "low-priority"),
+					util.BuildPodGroupWithPrio("pg2", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue, "high-priority"),
+				},
+				// There is no idle resource, evicting the 2 cpus task is enough for the preemptor.
+				Pods: []*v1.Pod{
+					util.BuildPodWithPriority("c1", "preemptee1", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", preemptable, make(map[string]string), &lowTaskPrio),
+					util.BuildPodWithPriority("c1", "preemptee2", "n1", v1.PodRunning, api.BuildResourceList("2", "2G"), "pg1", preemptable, make(map[string]string), &highTaskPrio),
+					util.BuildPod("c1", "preemptor1", "", v1.PodPending, api.BuildResourceList("2", "2G"), "pg2", make(map[string]string), make(map[string]string)),
+				},
+				Nodes: []*v1.Node{
+					util.BuildNode("n1", api.BuildResourceList("3", "3G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string)),
+				},
+				Queues: []*schedulingv1beta1.Queue{
+					util.BuildQueue("q1", 1, nil),
+				},
+				ExpectEvicted:  []string{"c1/preemptee2"},
+				ExpectEvictNum: 1,
+			},
+			cost: "default",
+		},
+	}
+
+	trueValue := true
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:               conformance.PluginName,
+					EnabledPreemptable: &trueValue,
+				},
+				{
+					Name:                gang.PluginName,
+					EnabledPreemptable:  &trueValue,
+					EnabledJobPipelined: &trueValue,
+					EnabledJobStarving:  &trueValue,
+				},
+				{
+					Name:                priority.PluginName,
+					EnabledTaskOrder:    &trueValue,
+					EnabledJobOrder:     &trueValue,
+					EnabledPreemptable:  &trueValue,
+					EnabledJobPipelined: &trueValue,
+					EnabledJobStarving:  &trueValue,
+				},
+				{
+					Name:               proportion.PluginName,
+					EnabledOverused:    &trueValue,
+					EnabledAllocatable: &trueValue,
+					EnabledQueueOrder:  &trueValue,
+				},
+			},
+		}}
+
+	for i, test := range tests {
+		test.Plugins = plugins
+		test.PriClass = []*schedulingv1.PriorityClass{highPrio, lowPrio}
+		t.Run(test.Name, func(t *testing.T) {
+			config := []conf.Configuration{{Name: "preempt", Arguments: map[string]interface{}{conf.PreemptionCostKey: test.cost}}}
+			test.RegisterSession(tiers, config)
+			defer test.Close()
+			test.Run([]framework.Action{New()})
+			if err := test.CheckAll(i); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestPreemptGangEviction(t *testing.T) {
+	plugins := map[string]framework.PluginBuilder{
+		conformance.PluginName: conformance.New,
+		gang.PluginName:        gang.New,
+		priority.PluginName:    priority.New,
+		proportion.PluginName:  proportion.New,
+	}
+	highPrio := util.BuildPriorityClass("high-priority", 100000)
+	midPrio := util.BuildPriorityClass("mid-priority", 1000)
+	lowPrio := util.BuildPriorityClass("low-priority", 10)
+
+	lowTaskPrio, highTaskPrio := int32(1), int32(100)
+	preemptable := map[string]string{schedulingv1beta1.PodPreemptable: "true"}
+	tests := []struct {
+		uthelper.TestCommonStruct
+		mode string
+		cost string
+	}{
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "evict the whole victim job if evicting its task breaks the gang",
+				PodGroups: []*schedulingv1beta1.PodGroup{
+					util.BuildPodGroupWithPrio("pg1", "c1", "q1", 2, nil, schedulingv1beta1.PodGroupRunning, "low-priority"),
+					util.BuildPodGroupWithPrio("pg2", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue, "high-priority"),
+				},
+				Pods: []*v1.Pod{
+					util.BuildPod("c1", "preemptee1", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", preemptable, make(map[string]string)),
+					util.BuildPod("c1", "preemptee2", "n2", v1.PodRunning, api.BuildResourceList("2", "2G"), "pg1", preemptable, make(map[string]string)),
+					util.BuildPod("c1", "preemptor1", "", v1.PodPending, api.BuildResourceList("2", "2G"), "pg2", make(map[string]string), make(map[string]string)),
+				},
+				Nodes: []*v1.Node{
+					util.BuildNode("n1", api.BuildResourceList("2", "2G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string)),
+					util.BuildNode("n2", api.BuildResourceList("2", "2G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string)),
+				},
+				Queues: []*schedulingv1beta1.Queue{
+					util.BuildQueue("q1", 1, nil),
+				},
+				ExpectEvicted:  []string{"c1/preemptee1", "c1/preemptee2"},
+				ExpectEvictNum: 2,
+			},
+			mode: "wholeJob",
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "evict the whole victim job with the minimal cost victim selection",
+				PodGroups: []*schedulingv1beta1.PodGroup{
+					util.BuildPodGroupWithPrio("pg1", "c1", "q1", 2, nil, schedulingv1beta1.PodGroupRunning, "low-priority"),
+					util.BuildPodGroupWithPrio("pg2", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue, "high-priority"),
+				},
+				Pods: []*v1.Pod{
+					util.BuildPod("c1", "preemptee1", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", preemptable, make(map[string]string)),
+					util.BuildPod("c1", "preemptee2", "n2", v1.PodRunning, api.BuildResourceList("2", "2G"), "pg1", preemptable, make(map[string]string)),
+					util.BuildPod("c1", "preemptor1", "", v1.PodPending, api.BuildResourceList("2", "2G"), "pg2", make(map[string]string), make(map[string]string)),
+				},
+				Nodes: []*v1.Node{
+					util.BuildNode("n1", api.BuildResourceList("2", "2G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string)),
+					util.BuildNode("n2", api.BuildResourceList("2", "2G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string)),
+				},
+				Queues: []*schedulingv1beta1.Queue{
+					util.BuildQueue("q1", 1, nil),
+				},
+				ExpectEvicted:  []string{"c1/preemptee1", "c1/preemptee2"},
+				ExpectEvictNum: 2,
+			},
+			mode: "wholeJob",
+			cost: "default",
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "choose other victims if evicting the task breaks the gang",
+				PodGroups: []*schedulingv1beta1.PodGroup{
+					util.BuildPodGroupWithPrio("pg1", "c1", "q1", 2, nil, schedulingv1beta1.PodGroupRunning, "low-priority"),
+					util.BuildPodGroupWithPrio("pg2", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue, "high-priority"),
+					util.BuildPodGroupWithPrio("pg3", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning, "mid-priority"),
+				},
+				// The task of pg1 is preempted first for its lower priority, but it breaks the gang of pg1.
+				Pods: []*v1.Pod{
+					util.BuildPod("c1", "preemptee1", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", preemptable, make(map[string]string)),
+					util.BuildPod("c1", "preemptee2", "n2", v1.PodRunning, api.BuildResourceList("2", "2G"), "pg1", preemptable, make(map[string]string)),
+					util.BuildPod("c1", "preemptee3", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg3", preemptable, make(map[string]string)),
+					util.BuildPod("c1", "preemptor1", "", v1.PodPending, api.BuildResourceList("2", "2G"), "pg2", make(map[string]string), make(map[string]string)),
+				},
+				Nodes: []*v1.Node{
+					util.BuildNode("n1", api.BuildResourceList("3", "3G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string)),
+					util.BuildNode("n2", api.BuildResourceList("2", "2G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string)),
+				},
+				Queues: []*schedulingv1beta1.Queue{
+					util.BuildQueue("q1", 1, nil),
+				},
+				ExpectEvicted:  []string{"c1/preemptee3"},
+				ExpectEvictNum: 1,
+			},
+			mode: "avoid",
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "evict the whole victim job once two of its victims break the gang",
+				PodGroups: []*schedulingv1beta1.PodGroup{
+					util.BuildPodGroupWithPrio("pg1", "c1", "q1", 3, nil, schedulingv1beta1.PodGroupRunning, "low-priority"),
+					util.BuildPodGroupWithPrio("pg2", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue, "high-priority"),
+				},
+				// Evicting one task of pg1 keeps the gang, evicting two of them breaks it.
+				Pods: []*v1.Pod{
+					util.BuildPod("c1", "preemptee1", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", preemptable, make(map[string]string)),
+					util.BuildPod("c1", "preemptee2", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", preemptable, make(map[string]string)),
+					util.BuildPod("c1", "preemptee3", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", preemptable, make(map[string]string)),
+					util.BuildPod("c1", "preemptee4", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", preemptable, make(map[string]string)),
+					util.BuildPod("c1", "preemptor1", "", v1.PodPending, api.BuildResourceList("2", "2G"), "pg2", make(map[string]string), make(map[string]string)),
+				},
+				Nodes: []*v1.Node{
+					util.BuildNode("n1", api.BuildResourceList("4", "4G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string)),
+				},
+				Queues: []*schedulingv1beta1.Queue{
+					util.BuildQueue("q1", 1, nil),
+				},
+				ExpectEvicted:  []string{"c1/preemptee1", "c1/preemptee2", "c1/preemptee3", "c1/preemptee4"},
+				ExpectEvictNum: 4,
+			},
+			mode: "wholeJob",
+			cost: "default",
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "avoid the second victim of the same job breaking the gang",
+				PodGroups: []*schedulingv1beta1.PodGroup{
+					util.BuildPodGroupWithPrio("pg1", "c1", "q1", 3, nil, schedulingv1beta1.PodGroupRunning, "low-priority"),
+					util.BuildPodGroupWithPrio("pg2", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue, "high-priority"),
+				},
+				// Evicting one task of pg1 keeps the gang, evicting two of them breaks it.
+				Pods: []*v1.Pod{
+					util.BuildPod("c1", "preemptee1", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", preemptable, make(map[string]string)),
+					util.BuildPod("c1", "preemptee2", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", preemptable, make(map[string]string)),
+					util.BuildPod("c1", "preemptee3", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", preemptable, make(map[string]string)),
+					util.BuildPod("c1", "preemptee4", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", preemptable, make(map[string]string)),
+					util.BuildPod("c1", "preemptor1", "", v1.PodPending, api.BuildResourceList("2", "2G"), "pg2", make(map[string]string), make(map[string]string)),
+				},
+				Nodes: []*v1.Node{
+					util.BuildNode("n1", api.BuildResourceList("4", "4G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string)),
+				},
+				Queues: []*schedulingv1beta1.Queue{
+					util.BuildQueue("q1", 1, nil),
+				},
+				ExpectEvicted:  []string{},
+				ExpectEvictNum: 0,
+			},
+			mode: "avoid",
+			cost: "default",
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "preempt the task of the same job without evicting the whole job",
+				PodGroups: []*schedulingv1beta1.PodGroup{
+					util.BuildPodGroupWithPrio("pg1", "c1", "q1", 3, nil, schedulingv1beta1.PodGroupRunning, "low-priority"),
+				},
+				// The job preempts its own task, which breaks its gang but must not evict the whole job.
+				Pods: []*v1.Pod{
+					util.BuildPodWithPriority("c1", "preemptee1", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", preemptable, make(map[string]string), &lowTaskPrio),
+					util.BuildPodWithPriority("c1", "preemptee2", "n2", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", preemptable, make(map[string]string), &highTaskPrio),
+					util.BuildPodWithPriority("c1", "preemptor1", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg1", make(map[string]string), make(map[string]string), &highTaskPrio),
+				},
+				Nodes: []*v1.Node{
+					util.BuildNode("n1", api.BuildResourceList("1", "1G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string)),
+					util.BuildNode("n2", api.BuildResourceList("1", "1G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string)),
+				},
+				Queues: []*schedulingv1beta1.Queue{
+					util.BuildQueue("q1", 1, nil),
+				},
+				ExpectEvicted:  []string{"c1/preemptee1"},
+				ExpectEvictNum: 1,
+			},
+			mode: "wholeJob",
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "preempt the task of the same job without avoiding the gang",
+				PodGroups: []*schedulingv1beta1.PodGroup{
+					util.BuildPodGroupWithPrio("pg1", "c1", "q1", 3, nil, schedulingv1beta1.PodGroupRunning, "low-priority"),
+				},
+				// The job preempts its own task, which breaks its gang but must not evict the whole job.
+				Pods: []*v1.Pod{
+					util.BuildPodWithPriority("c1", "preemptee1", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", preemptable, make(map[string]string), &lowTaskPrio),
+					util.BuildPodWithPriority("c1", "preemptee2", "n2", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", preemptable, make(map[string]string), &highTaskPrio),
+					util.BuildPodWithPriority("c1", "preemptor1", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg1", make(map[string]string), make(map[string]string), &highTaskPrio),
+				},
+				Nodes: []*v1.Node{
+					util.BuildNode("n1", api.BuildResourceList("1", "1G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string)),
+					util.BuildNode("n2", api.BuildResourceList("1", "1G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string)),
+				},
+				Queues: []*schedulingv1beta1.Queue{
+					util.BuildQueue("q1", 1, nil),
+				},
+				ExpectEvicted:  []string{"c1/preemptee1"},
+				ExpectEvictNum: 1,
+			},
+			mode: "avoid",
+		},
+	}
+
+	// The gang plugin does not filter the victims, so that the victim gang jobs are handled by the gang eviction mode.
+	trueValue := true
+	falseValue := false
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:               conformance.PluginName,
+					EnabledPreemptable: &trueValue,
+				},
+				{
+					Name:                gang.PluginName,
+					EnabledPreemptable:  &falseValue,
+					EnabledJobPipelined: &trueValue,
+					EnabledJobStarving:  &trueValue,
+				},
+				{
+					Name:                priority.PluginName,
+					EnabledTaskOrder:    &trueValue,
+					EnabledJobOrder:     &trueValue,
+					EnabledPreemptable:  &trueValue,
+					EnabledJobPipelined: &trueValue,
+					EnabledJobStarving:  &trueValue,
+				},
+				{
+					Name:               proportion.PluginName,
+					EnabledOverused:    &trueValue,
+					EnabledAllocatable: &trueValue,
+					EnabledQueueOrder:  &trueValue,
+				},
+			},
+		}}
+
+	for i, test := range tests {
+		test.Plugins = plugins
+		test.PriClass = []*schedulingv1.PriorityClass{highPrio, midPrio, lowPrio}
+		t.Run(test.Name, func(t *testing.T) {
+			config := []conf.Configuration{{Name: "preempt", Arguments: map[string]interface{}{
+				conf.GangEvictionModeKey: test.mode,
+				conf.PreemptionCostKey:   test.cost,
+			}}}
+			test.RegisterSession(tiers, config)
+			defer test.Close()
+			test.Run([]framework.Action{New()})
+			if err := test.CheckAll(i); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestPickOneNodeForPreemptionIsDryRun(t *testing.T) {
+	preemptable := map[string]string{schedulingv1beta1.PodPreemptable: "true"}
+	test := uthelper.TestCommonStruct{
+		Name: "pick the node without evicting the victims",
+		Plugins: map[string]framework.PluginBuilder{
+			conformance.PluginName: conformance.New,
+			proportion.PluginName:  proportion.New,
+		},
+		PodGroups: []*schedulingv1beta1.PodGroup{
+			util.BuildPodGroup("pg1", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning),
+			util.BuildPodGroup("pg2", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue),
+		},
+		Pods: []*v1.Pod{
+			util.BuildPod("c1", "preemptee1", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", preemptable, make(map[string]string)),
+			util.BuildPod("c1", "preemptee2", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", preemptable, make(map[string]string)),
+			util.BuildPod("c1", "preemptee3", "n2", v1.PodRunning, api.BuildResourceList("2", "2G"), "pg1", preemptable, make(map[string]string)),
+			util.BuildPod("c1", "preemptor1", "", v1.PodPending, api.BuildResourceList("2", "2G"), "pg2", make(map[string]string), make(map[string]string)),
+		},
+		Nodes: []*v1.Node{
+			util.BuildNode("n1", api.BuildResourceList("2", "2G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string)),
+			util.BuildNode("n2", api.BuildResourceList("2", "2G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string)),
+		},
+		Queues: []*schedulingv1beta1.Queue{
+			util.BuildQueue("q1", 1, nil),
+		},
+	}
+	trueValue := true
+	tiers := []conf.Tier{{
+		Plugins: []conf.PluginOption{
+			{Name: conformance.PluginName, EnabledPreemptable: &trueValue},
+			{Name: proportion.PluginName, EnabledAllocatable: &trueValue},
+		},
+	}}
+	ssn := test.RegisterSession(tiers, nil)
+	defer test.Close()
+
+	events := 0
+	ssn.AddEventHandler(&framework.EventHandler{
+		AllocateFunc:   func(*framework.Event) { events++ },
+		DeallocateFunc: func(*framework.Event) { events++ },
+	})
+
+	var preemptor *api.TaskInfo
+	for _, job := range ssn.Jobs {
+		for _, task := range job.TaskStatusIndex[api.Pending] {
+			preemptor = task
+		}
+	}
+	queue := ssn.Queues[ssn.Jobs[preemptor.Job].Queue]
+	idle := map[string]*api.Resource{}
+	for name, node := range ssn.Nodes {
+		idle[name] = node.FutureIdle()
+	}
+
+	pmpt := New()
+	pmpt.costCriteria = []string{costVictims}
+	best := pmpt.pickOneNodeForPreemption(ssn, preemptor, ssn.NodeList, func(task *api.TaskInfo) bool {
+		return task.Preemptable && task.Job != preemptor.Job
+	}, func(idle, released *api.Resource) bool {
+		return ssn.Allocatable(queue, remainingRequest(preemptor, released)) && preemptor.InitResreq.LessEqual(idle, api.Zero)
+	})
+	if best == nil || best.node.Name != "n2" || len(best.victims) != 1 || best.victims[0].Name != "preemptee3" {
+		t.Fatalf("expected to preempt c1/preemptee3 on n2, got %+v", best)
+	}
+	if events != 0 {
+		t.Errorf("expected no events fired while picking the node, got %d", events)
+	}
+	for name, node := range ssn.Nodes {
+		if !node.FutureIdle().Equal(idle[name], api.Zero) {
+			t.Errorf("expected the future idle of node %s unchanged %v, got %v", name, idle[name], node.FutureIdle())
+		}
+		for _, task := range node.Tasks {
+			if task.Status != api.Running {
+				t.Errorf("expected task %s running, got %v", task.Name, task.Status)
+			}
+		}
+	}
+}
+
+func TestParseCostCriteria(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected []string
+	}{
+		{value: "", expected: nil},
+		{value: "firstFit", expected: nil},
+		{value: "default", expected: defaultCostCriteria},
+		{value: "victims, prioritySum", expected: []string{costVictims, costPrioritySum}},
+		{value: "unknown,brokenGangs", expected: []string{costBrokenGangs}},
+		{value: "unknown", expected: nil},
+	}
+	for _, test := range tests {
+		if got := parseCostCriteria(test.value); !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("parseCostCriteria(%q): expected %v, got %v", test.value, test.expected, got)
+		}
+	}
+}
+
+func TestVictimsCost(t *testing.T) {
+	labels := map[string]string{"app": "web"}
+	victims := []*api.TaskInfo{
+		api.NewTaskInfo(util.BuildPodWithPriority("c1", "p1", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", labels, nil, int32Ptr(10))),
+		api.NewTaskInfo(util.BuildPodWithPriority("c1", "p2", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", labels, nil, int32Ptr(30))),
+		api.NewTaskInfo(util.BuildPodWithPriority("c1", "p3", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg2", nil, nil, int32Ptr(20))),
+	}
+	pdbs := []*policyv1.PodDisruptionBudget{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "c1", Name: "web"},
+			Spec:       policyv1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: labels}},
+			Status:     policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 1},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "c2", Name: "web"},
+			Spec:       policyv1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: labels}},
+		},
+	}
+
+	gangJob := api.NewJobInfo("c1/pg1", victims[0], victims[1])
+	gangJob.MinAvailable = 2
+	ssn := &framework.Session{Jobs: map[api.JobID]*api.JobInfo{gangJob.UID: gangJob}}
+
+	expected := map[string]int64{
+		costPDBViolations:   1,
+		costBrokenGangs:     1,
+		costHighestPriority: 30,
+		costPrioritySum:     60,
+		costVictims:         3,
+	}
+	if got := victimsCost(ssn, victims, pdbs); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected cost %v, got %v", expected, got)
+	}
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
@@ -0,0 +1,387 @@
+/*
+Copyright 2026 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preempt
+
+import (
+	"math"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/features"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+// The criteria of the cost of the victims on a node, the node with the lowest cost is preempted on.
+const (
+	// costPDBViolations is the number of victims whose eviction violates the pod disruption budgets
+	costPDBViolations = "pdbViolations"
+	// costBrokenGangs is the number of gang jobs whose ready tasks are fewer than minAvailable after the eviction
+	costBrokenGangs = "brokenGangs"
+	// costHighestPriority is the highest priority of the victims
+	costHighestPriority = "highestPriority"
+	// costPrioritySum is the sum of the priorities of the victims
+	costPrioritySum = "prioritySum"
+	// costVictims is the number of victims
+	costVictims = "victims"
+
+	// defaultCost is the alias of the criteria in the order of kube-scheduler
+	defaultCost = "default"
+	// firstFitCost preempts on the first node in score order where the preemptor fits
+	firstFitCost = "firstFit"
+)
+
+var defaultCostCriteria = []string{costPDBViolations, costBrokenGangs, costHighestPriority, costPrioritySum, costVictims}
+
+// parseCostCriteria parses the comma separated criteria of the preemption cost, nil means preempting on the
+// first node where the preemptor fits.
+func parseCostCriteria(value string) []string {
+	value = strings.TrimSpace(value)
+	switch value {
+	case "", firstFitCost:
+		return nil
+	case defaultCost:
+		return defaultCostCriteria
+	}
+
+	criteria := make([]string, 0, len(defaultCostCriteria))
+	for _, criterion := range strings.Split(value, ",") {
+		criterion = strings.TrimSpace(criterion)
+		switch criterion {
+		case costPDBViolations, costBrokenGangs, costHighestPriority, costPrioritySum, costVictims:
+			criteria = append(criteria, criterion)
+		default:
+			klog.Warningf("Unknown preemption cost criterion %q is ignored, the valid criteria are %v", criterion, defaultCostCriteria)
+		}
+	}
+	if len(criteria) == 0 {
+		return nil
+	}
+	return criteria
+}
+
+// fitFn checks whether the preemptor fits once the victims are evicted, idle is the future idle resources of the
+// node and released is the resources released in the queue of the preemptor by the eviction.
+type fitFn func(idle, released *api.Resource) bool
+
+// eviction evicts the victims in a dry run against the cloned future idle resources of the node, and accumulates
+// the resources released in the queue of the preemptor, the session and the plugins are left untouched.
+type eviction struct {
+	node     *api.NodeInfo
+	queue    api.QueueID
+	idle     *api.Resource
+	released *api.Resource
+}
+
+func newEviction(ssn *framework.Session, preemptor *api.TaskInfo, node *api.NodeInfo) *eviction {
+	e := &eviction{
+		node:     node,
+		idle:     node.FutureIdle(),
+		released: api.EmptyResource(),
+	}
+	if job, found := ssn.Jobs[preemptor.Job]; found {
+		e.queue = job.Queue
+	}
+	return e
+}
+
+// evict releases the resources of the victim, it returns false if the victim is unknown to the session.
+func (e *eviction) evict(ssn *framework.Session, victim *api.TaskInfo) bool {
+	job, found := ssn.Jobs[victim.Job]
+	if !found {
+		return false
+	}
+	if victim.NodeName == e.node.Name {
+		e.idle.Add(victim.Resreq)
+	}
+	if job.Queue == e.queue {
+		e.released.Add(victim.Resreq)
+	}
+	return true
+}
+
+func (e *eviction) fits(fits fitFn) bool {
+	return fits(e.idle, e.released)
+}
+
+// remainingRequest returns the request of the preemptor less the resources released in its queue, the queue is
+// only checked on the dimensions where the released resources do not cover the request.
+func remainingRequest(preemptor *api.TaskInfo, released *api.Resource) *api.TaskInfo {
+	candidate := preemptor.Clone()
+	candidate.Resreq.MilliCPU -= released.MilliCPU
+	candidate.Resreq.Memory -= released.Memory
+	for name, quant := range released.ScalarResources {
+		if candidate.Resreq.ScalarResources == nil {
+			candidate.Resreq.ScalarResources = map[v1.ResourceName]float64{}
+		}
+		candidate.Resreq.ScalarResources[name] -= quant
+	}
+	return candidate
+}
+
+// candidate is the victims on a node which make room for the preemptor once evicted.
+type candidate struct {
+	node    *api.NodeInfo
+	victims []*api.TaskInfo
+	cost    map[string]int64
+}
+
+// lessCost compares the cost of the candidates by the criteria in order.
+func lessCost(l, r *candidate, criteria []string) bool {
+	for _, criterion := range criteria {
+		if l.cost[criterion] != r.cost[criterion] {
+			return l.cost[criterion] < r.cost[criterion]
+		}
+	}
+	return false
+}
+
+// listPDBs returns the pod disruption budgets if the preemption cost needs them.
+func listPDBs(ssn *framework.Session, criteria []string) []*policyv1.PodDisruptionBudget {
+	needed := false
+	for _, criterion := range criteria {
+		needed = needed || criterion == costPDBViolations
+	}
+	factory := ssn.InformerFactory()
+	if !needed || factory == nil || !utilfeature.DefaultFeatureGate.Enabled(features.PodDisruptionBudgetsSupport) {
+		return nil
+	}
+	pdbs, err := factory.Policy().V1().PodDisruptionBudgets().Lister().List(labels.Everything())
+	if err != nil {
+		klog.Errorf("Failed to list pdbs for preemption: %v", err)
+		return nil
+	}
+	return pdbs
+}
+
+// pickOneNodeForPreemption selects the victims on every node in a dry run, and returns the candidate with the
+// lowest cost, the candidates with the same cost are in the order of the nodes.
+func (pmpt *Action) pickOneNodeForPreemption(
+	ssn *framework.Session,
+	preemptor *api.TaskInfo,
+	nodes []*api.NodeInfo,
+	filter func(*api.TaskInfo) bool,
+	fits fitFn,
+) *candidate {
+	var best *candidate
+	for _, node := range nodes {
+		victims, err := nodeVictims(ssn, preemptor, node, filter)
+		if err != nil {
+			klog.V(3).Infof("No validated victims on Node <%s>: %v", node.Name, err)
+			continue
+		}
+		selected, ok := selectVictimsOnNode(ssn, preemptor, node, victims, filter, fits, pmpt.gangEvictionMode)
+		if !ok {
+			klog.V(4).Infof("Task <%s/%s> does not fit Node <%s> after preempting all victims.",
+				preemptor.Namespace, preemptor.Name, node.Name)
+			continue
+		}
+		c := &candidate{
+			node:    node,
+			victims: selected,
+			cost:    victimsCost(ssn, selected, pmpt.pdbs),
+		}
+		klog.V(4).Infof("Task <%s/%s> could preempt %d tasks on Node <%s> at cost %v.",
+			preemptor.Namespace, preemptor.Name, len(selected), node.Name, c.cost)
+		if best == nil || lessCost(c, best, pmpt.costCriteria) {
+			best = c
+		}
+	}
+	return best
+}
+
+// nodeVictims returns the tasks on the node which the plugins allow the preemptor to preempt.
+func nodeVictims(ssn *framework.Session, preemptor *api.TaskInfo, node *api.NodeInfo, filter func(*api.TaskInfo) bool) ([]*api.TaskInfo, error) {
+	var preemptees []*api.TaskInfo
+	for _, task := range node.Tasks {
+		if filter == nil || filter(task) {
+			preemptees = append(preemptees, task.Clone())
+		}
+	}
+	victims := ssn.Preemptable(preemptor, preemptees)
+
+	if err := util.ValidateVictims(preemptor, node, victims); err != nil {
+		return nil, err
+	}
+	return victims, nil
+}
+
+// selectVictimsOnNode evicts the victims from the lowest priority in a dry run until the preemptor fits the node,
+// then reprieves the evicted victims from the highest priority whose eviction is not needed for the preemptor to fit.
+// The victims evicted together under the gang eviction mode are reprieved together, including the tasks of the job
+// evicted before its whole job is.
+func selectVictimsOnNode(
+	ssn *framework.Session,
+	preemptor *api.TaskInfo,
+	node *api.NodeInfo,
+	victims []*api.TaskInfo,
+	filter func(*api.TaskInfo) bool,
+	fits fitFn,
+	mode framework.GangEvictionMode,
+) ([]*api.TaskInfo, bool) {
+	groups := make([][]*api.TaskInfo, 0)
+	evicted := make(map[api.TaskID]bool)
+	// evictedJobs is the number of evicted tasks of each job, the status of the tasks is not changed in the dry run
+	evictedJobs := make(map[api.JobID]int32)
+	victimsQueue := ssn.BuildVictimsPriorityQueue(victims, preemptor)
+	dryRun := newEviction(ssn, preemptor, node)
+	for !dryRun.fits(fits) && !victimsQueue.Empty() {
+		victim := victimsQueue.Pop().(*api.TaskInfo)
+		if evicted[victim.UID] {
+			continue
+		}
+		group := make([]*api.TaskInfo, 0, 1)
+		preemptees := ssn.GangVictims(preemptor, victim, mode, evictedJobs, filter, ssn.Preemptable)
+		for _, preemptee := range preemptees {
+			if evicted[preemptee.UID] {
+				continue
+			}
+			if !dryRun.evict(ssn, preemptee) {
+				continue
+			}
+			evicted[preemptee.UID] = true
+			evictedJobs[preemptee.Job]++
+			group = append(group, preemptee)
+		}
+		if len(preemptees) > 1 {
+			groups, group = mergeJobGroups(groups, group, victim.Job)
+		}
+		if len(group) > 0 {
+			groups = append(groups, group)
+		}
+	}
+	if !dryRun.fits(fits) {
+		return nil, false
+	}
+
+	for i := len(groups) - 1; i >= 0; i-- {
+		reprieved := make([][]*api.TaskInfo, 0, len(groups)-1)
+		reprieved = append(reprieved, groups[:i]...)
+		reprieved = append(reprieved, groups[i+1:]...)
+		if fitsAfterEviction(ssn, preemptor, node, flatten(reprieved), fits) {
+			groups = reprieved
+		}
+	}
+	return flatten(groups), true
+}
+
+// mergeJobGroups moves the groups of the job into the group evicting the whole job, so that the tasks of the job are
+// reprieved together. The tasks of a group are in the same job.
+func mergeJobGroups(groups [][]*api.TaskInfo, group []*api.TaskInfo, job api.JobID) ([][]*api.TaskInfo, []*api.TaskInfo) {
+	others := make([][]*api.TaskInfo, 0, len(groups))
+	var merged []*api.TaskInfo
+	for _, g := range groups {
+		if len(g) > 0 && g[0].Job == job {
+			merged = append(merged, g...)
+			continue
+		}
+		others = append(others, g)
+	}
+	return others, append(merged, group...)
+}
+
+// flatten returns the tasks of the groups in order.
+func flatten(groups [][]*api.TaskInfo) []*api.TaskInfo {
+	tasks := make([]*api.TaskInfo, 0, len(groups))
+	for _, group := range groups {
+		tasks = append(tasks, group...)
+	}
+	return tasks
+}
+
+// fitsAfterEviction checks whether the preemptor fits the node after the victims are evicted in a dry run.
+func fitsAfterEviction(ssn *framework.Session, preemptor *api.TaskInfo, node *api.NodeInfo, victims []*api.TaskInfo, fits fitFn) bool {
+	dryRun := newEviction(ssn, preemptor, node)
+	for _, victim := range victims {
+		if !dryRun.evict(ssn, victim) {
+			return false
+		}
+	}
+	return dryRun.fits(fits)
+}
+
+// victimsCost returns the cost of evicting the victims by every criterion.
+func victimsCost(ssn *framework.Session, victims []*api.TaskInfo, pdbs []*policyv1.PodDisruptionBudget) map[string]int64 {
+	cost := map[string]int64{
+		costVictims:         int64(len(victims)),
+		costHighestPriority: math.MinInt64,
+	}
+	evicted := make(map[api.JobID]int32)
+	for _, victim := range victims {
+		cost[costPrioritySum] += int64(victim.Priority)
+		if int64(victim.Priority) > cost[costHighestPriority] {
+			cost[costHighestPriority] = int64(victim.Priority)
+		}
+		evicted[victim.Job]++
+	}
+	for jobID, num := range evicted {
+		job, found := ssn.Jobs[jobID]
+		if !found || job.MinAvailable <= 1 {
+			continue
+		}
+		if ready := job.ReadyTaskNum(); ready >= job.MinAvailable && ready-num < job.MinAvailable {
+			cost[costBrokenGangs]++
+		}
+	}
+	cost[costPDBViolations] = int64(pdbViolations(victims, pdbs))
+	return cost
+}
+
+// pdbViolations returns the number of victims whose eviction violates the pod disruption budgets,
+// the same as the filterPodsWithPDBViolation of kube-scheduler.
+func pdbViolations(victims []*api.TaskInfo, pdbs []*policyv1.PodDisruptionBudget) int {
+	allowed := make([]int32, len(pdbs))
+	for i, pdb := range pdbs {
+		allowed[i] = pdb.Status.DisruptionsAllowed
+	}
+
+	violations := 0
+	for _, victim := range victims {
+		if victim.Pod == nil || len(victim.Pod.Labels) == 0 {
+			continue
+		}
+		violated := false
+		for i, pdb := range pdbs {
+			if pdb.Namespace != victim.Namespace {
+				continue
+			}
+			selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+			if err != nil || selector.Empty() || !selector.Matches(labels.Set(victim.Pod.Labels)) {
+				continue
+			}
+			// the victim is already counted in the disrupted pods of the pdb
+			if _, disrupted := pdb.Status.DisruptedPods[victim.Name]; disrupted {
+				continue
+			}
+			allowed[i]--
+			if allowed[i] < 0 {
+				violated = true
+			}
+		}
+		if violated {
+			violations++
+		}
+	}
+	return violations
+}
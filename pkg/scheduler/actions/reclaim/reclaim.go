@@ -21,11 +21,20 @@ import (
 	"k8s.io/klog/v2"
 
 	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/conf"
 	"volcano.sh/volcano/pkg/scheduler/framework"
 	"volcano.sh/volcano/pkg/scheduler/util"
 )
 
-type Action struct{}
+// ArgumentsSchema is the schema of the arguments of reclaim action
+var ArgumentsSchema = framework.ArgumentsSchema{
+	conf.GangEvictionModeKey: framework.StringArgument,
+}
+
+type Action struct {
+	// gangEvictionMode is how the tasks of the victim gang jobs are evicted
+	gangEvictionMode framework.GangEvictionMode
+}
 
 func New() *Action {
 	return &Action{}
@@ -37,10 +46,19 @@ func (ra *Action) Name() string {
 
 func (ra *Action) Initialize() {}
 
+func (ra *Action) parseArguments(ssn *framework.Session) {
+	arguments := framework.GetArgOfActionFromConf(ssn.Configurations, ra.Name())
+	mode := ""
+	arguments.GetString(&mode, conf.GangEvictionModeKey)
+	ra.gangEvictionMode = framework.ParseGangEvictionMode(mode)
+}
+
 func (ra *Action) Execute(ssn *framework.Session) {
 	klog.V(5).Infof("Enter Reclaim ...")
 	defer klog.V(5).Infof("Leaving Reclaim ...")
 
+	ra.parseArguments(ssn)
+
 	queues := util.NewPriorityQueue(ssn.QueueOrderFn)
 	queueMap := map[api.QueueID]*api.QueueInfo{}
 
@@ -136,6 +154,20 @@ func (ra *Action) Execute(ssn *framework.Session) {
 		}
 
 		assigned := false
+		reclaimable := func(reclaimee *api.TaskInfo) bool {
+			// Ignore non running task.
+			if reclaimee.Status != api.Running {
+				return false
+			}
+			if !reclaimee.Preemptable {
+				return false
+			}
+			j, found := ssn.Jobs[reclaimee.Job]
+			if !found || j.Queue == job.Queue {
+				return false
+			}
+			return ssn.Queues[j.Queue].Reclaimable()
+		}
 		// we should filter out those nodes that are UnschedulableAndUnresolvable status got in allocate action
 		totalNodes := ssn.GetUnschedulableAndUnresolvableNodesForTask(task)
 		for _, n := range totalNodes {
@@ -150,21 +182,7 @@ func (ra *Action) Execute(ssn *framework.Session) {
 
 			var reclaimees []*api.TaskInfo
 			for _, task := range n.Tasks {
-				// Ignore non running task.
-				if task.Status != api.Running {
-					continue
-				}
-				if !task.Preemptable {
-					continue
-				}
-
-				if j, found := ssn.Jobs[task.Job]; !found {
-					continue
-				} else if j.Queue != job.Queue {
-					q := ssn.Queues[j.Queue]
-					if !q.Reclaimable() {
-						continue
-					}
+				if reclaimable(task) {
 					// Clone task to avoid modify Task's status on node.
 					reclaimees = append(reclaimees, task.Clone())
 				}
@@ -187,23 +205,40 @@ func (ra *Action) Execute(ssn *framework.Session) {
 			resreq := task.InitResreq.Clone()
 			reclaimed := api.EmptyResource()
 
+			evicted := make(map[api.TaskID]bool)
+			var reclaimedTasks []*api.TaskInfo
 			// Reclaim victims for tasks.
 			for !victimsQueue.Empty() {
-				reclaimee := victimsQueue.Pop().(*api.TaskInfo)
-				klog.Errorf("Try to reclaim Task <%s/%s> for Tasks <%s/%s>",
-					reclaimee.Namespace, reclaimee.Name, task.Namespace, task.Name)
-				if err := ssn.Evict(reclaimee, "reclaim"); err != nil {
-					klog.Errorf("Failed to reclaim Task <%s/%s> for Tasks <%s/%s>: %v",
-						reclaimee.Namespace, reclaimee.Name, task.Namespace, task.Name, err)
+				victim := victimsQueue.Pop().(*api.TaskInfo)
+				if evicted[victim.UID] {
 					continue
 				}
-				reclaimed.Add(reclaimee.Resreq)
+				for _, reclaimee := range ssn.GangVictims(task, victim, ra.gangEvictionMode, nil, reclaimable, ssn.Reclaimable) {
+					if evicted[reclaimee.UID] {
+						continue
+					}
+					klog.Errorf("Try to reclaim Task <%s/%s> for Tasks <%s/%s>",
+						reclaimee.Namespace, reclaimee.Name, task.Namespace, task.Name)
+					if err := ssn.Evict(reclaimee, "reclaim"); err != nil {
+						klog.Errorf("Failed to reclaim Task <%s/%s> for Tasks <%s/%s>: %v",
+							reclaimee.Namespace, reclaimee.Name, task.Namespace, task.Name, err)
+						continue
+					}
+					evicted[reclaimee.UID] = true
+					reclaimedTasks = append(reclaimedTasks, reclaimee)
+					// Only the tasks on the node make room for the reclaimer.
+					if reclaimee.NodeName == n.Name {
+						reclaimed.Add(reclaimee.Resreq)
+					}
+				}
 				// If reclaimed enough resources, break loop to avoid Sub panic.
 				if resreq.LessEqual(reclaimed, api.Zero) {
 					break
 				}
 			}
 
+			ssn.RecordPreemption(ra.Name(), task, reclaimedTasks)
+
 			klog.V(3).Infof("Reclaimed <%v> for task <%s/%s> requested <%v>.",
 				reclaimed, task.Namespace, task.Name, task.InitResreq)
 
@@ -199,3 +199,122 @@ func TestReclaim(t *testing.T) {
 		})
 	}
 }
+
+func TestReclaimGangEviction(t *testing.T) {
+	preemptable := map[string]string{schedulingv1beta1.PodPreemptable: "true"}
+	tests := []struct {
+		uthelper.TestCommonStruct
+		mode string
+	}{
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "reclaim one task of the overusing queue breaking the gang",
+				PodGroups: []*schedulingv1beta1.PodGroup{
+					util.BuildPodGroup("pg1", "c1", "q1", 2, nil, schedulingv1beta1.PodGroupRunning),
+					util.BuildPodGroup("pg2", "c1", "q2", 1, nil, schedulingv1beta1.PodGroupInqueue),
+				},
+				Pods: []*v1.Pod{
+					util.BuildPod("c1", "preemptee1", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", preemptable, make(map[string]string)),
+					util.BuildPod("c1", "preemptee2", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", map[string]string{schedulingv1beta1.PodPreemptable: "false"}, make(map[string]string)),
+					util.BuildPod("c1", "preemptor1", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg2", make(map[string]string), make(map[string]string)),
+				},
+				Nodes: []*v1.Node{
+					util.BuildNode("n1", api.BuildResourceList("2", "2G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string)),
+				},
+				Queues: []*schedulingv1beta1.Queue{
+					util.BuildQueue("q1", 1, nil),
+					util.BuildQueue("q2", 1, nil),
+				},
+				ExpectEvictNum: 1,
+				ExpectEvicted:  []string{"c1/preemptee1"},
+			},
+			mode: "none",
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "reclaim the whole job of the overusing queue if the gang is broken",
+				PodGroups: []*schedulingv1beta1.PodGroup{
+					util.BuildPodGroup("pg1", "c1", "q1", 2, nil, schedulingv1beta1.PodGroupRunning),
+					util.BuildPodGroup("pg2", "c1", "q2", 1, nil, schedulingv1beta1.PodGroupInqueue),
+				},
+				Pods: []*v1.Pod{
+					util.BuildPod("c1", "preemptee1", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", preemptable, make(map[string]string)),
+					util.BuildPod("c1", "preemptee2", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", preemptable, make(map[string]string)),
+					util.BuildPod("c1", "preemptor1", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg2", make(map[string]string), make(map[string]string)),
+				},
+				Nodes: []*v1.Node{
+					util.BuildNode("n1", api.BuildResourceList("2", "2G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string)),
+				},
+				Queues: []*schedulingv1beta1.Queue{
+					util.BuildQueue("q1", 1, nil),
+					util.BuildQueue("q2", 1, nil),
+				},
+				ExpectEvictNum: 2,
+				ExpectEvicted:  []string{"c1/preemptee1", "c1/preemptee2"},
+			},
+			mode: "wholeJob",
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "reclaim nothing if all the victims break the gang",
+				PodGroups: []*schedulingv1beta1.PodGroup{
+					util.BuildPodGroup("pg1", "c1", "q1", 2, nil, schedulingv1beta1.PodGroupRunning),
+					util.BuildPodGroup("pg2", "c1", "q2", 1, nil, schedulingv1beta1.PodGroupInqueue),
+				},
+				Pods: []*v1.Pod{
+					util.BuildPod("c1", "preemptee1", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", preemptable, make(map[string]string)),
+					util.BuildPod("c1", "preemptee2", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", preemptable, make(map[string]string)),
+					util.BuildPod("c1", "preemptor1", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg2", make(map[string]string), make(map[string]string)),
+				},
+				Nodes: []*v1.Node{
+					util.BuildNode("n1", api.BuildResourceList("2", "2G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string)),
+				},
+				Queues: []*schedulingv1beta1.Queue{
+					util.BuildQueue("q1", 1, nil),
+					util.BuildQueue("q2", 1, nil),
+				},
+				ExpectEvictNum: 0,
+				ExpectEvicted:  []string{},
+			},
+			mode: "avoid",
+		},
+	}
+
+	// The gang plugin does not filter the victims, so that the victim gang jobs are handled by the gang eviction mode.
+	trueValue := true
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:               conformance.PluginName,
+					EnabledReclaimable: &trueValue,
+				},
+				{
+					Name:               gang.PluginName,
+					EnabledJobStarving: &trueValue,
+				},
+				{
+					Name:               proportion.PluginName,
+					EnabledReclaimable: &trueValue,
+					EnabledQueueOrder:  &trueValue,
+				},
+			},
+		},
+	}
+	for i, test := range tests {
+		test.Plugins = map[string]framework.PluginBuilder{
+			conformance.PluginName: conformance.New,
+			gang.PluginName:        gang.New,
+			proportion.PluginName:  proportion.New,
+		}
+		t.Run(test.Name, func(t *testing.T) {
+			config := []conf.Configuration{{Name: "reclaim", Arguments: map[string]interface{}{conf.GangEvictionModeKey: test.mode}}}
+			test.RegisterSession(tiers, config)
+			defer test.Close()
+			test.Run([]framework.Action{New()})
+			if err := test.CheckAll(i); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -18,8 +18,18 @@ package api
 
 import (
 	"fmt"
+	"sync/atomic"
 )
 
+// generation is the latest generation of nodes and jobs.
+var generation int64
+
+// NextGeneration returns a generation greater than all the generations returned before,
+// it's assigned to the node or job changed.
+func NextGeneration() int64 {
+	return atomic.AddInt64(&generation, 1)
+}
+
 // ClusterInfo is a snapshot of cluster by cache.
 type ClusterInfo struct {
 	Jobs           map[JobID]*JobInfo
@@ -66,6 +66,10 @@ func (db *DisruptionBudget) Clone() *DisruptionBudget {
 // when job waits longer than waiting time, it should enqueue at once, and cluster should reserve resources for it
 const JobWaitingTime = "sla-waiting-time"
 
+// JobRunningEstimate is the annotation of the expected running duration of a job, which is propagated
+// to the podgroup from the runningEstimate of the volcano job and used by the time-window backfill
+const JobRunningEstimate = "volcano.sh/running-estimate"
+
 // TaskID is UID type for Task
 type TaskID types.UID
 
@@ -344,6 +348,8 @@ type JobInfo struct {
 	MinAvailable int32
 
 	WaitingTime *time.Duration
+	// RunningEstimate is the expected running duration of the job, nil if it is unknown
+	RunningEstimate *time.Duration
 
 	JobFitErrors   string
 	NodesFitErrors map[TaskID]*FitErrors
@@ -370,6 +376,10 @@ type JobInfo struct {
 	// * value means workload can use all the revocable node for during node active revocable time.
 	RevocableZone string
 	Budget        *DisruptionBudget
+
+	// Generation is bumped whenever the podgroup or tasks of job are changed, it tells
+	// the incremental snapshot whether the copy of job is still up to date.
+	Generation int64
 }
 
 // NewJobInfo creates a new jobInfo for set of tasks
@@ -395,10 +405,12 @@ func NewJobInfo(uid JobID, tasks ...*TaskInfo) *JobInfo {
 // UnsetPodGroup removes podGroup details from a job
 func (ji *JobInfo) UnsetPodGroup() {
 	ji.PodGroup = nil
+	ji.Generation = NextGeneration()
 }
 
 // SetPodGroup sets podGroup details to a job
 func (ji *JobInfo) SetPodGroup(pg *PodGroup) {
+	ji.Generation = NextGeneration()
 	ji.Name = pg.Name
 	ji.Namespace = pg.Namespace
 	ji.MinAvailable = pg.Spec.MinMember
@@ -421,6 +433,13 @@ func (ji *JobInfo) SetPodGroup(pg *PodGroup) {
 		}
 	}
 
+	ji.RunningEstimate, err = ji.extractRunningEstimate(pg)
+	if err != nil {
+		klog.Warningf("Error occurs in parsing running estimate for job <%s/%s>, err: %s.",
+			pg.Namespace, pg.Name, err.Error())
+		ji.RunningEstimate = nil
+	}
+
 	ji.Preemptable = ji.extractPreemptable(pg)
 	ji.RevocableZone = ji.extractRevocableZone(pg)
 	ji.Budget = ji.extractBudget(pg)
@@ -450,6 +469,25 @@ func (ji *JobInfo) extractWaitingTime(pg *PodGroup, waitingTimeKey string) (*tim
 	return &jobWaitingTime, nil
 }
 
+// extractRunningEstimate reads the running estimate of job from podgroup annotations
+func (ji *JobInfo) extractRunningEstimate(pg *PodGroup) (*time.Duration, error) {
+	value, exist := pg.Annotations[JobRunningEstimate]
+	if !exist {
+		return nil, nil
+	}
+
+	estimate, err := time.ParseDuration(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if estimate <= 0 {
+		return nil, errors.New("invalid running estimate")
+	}
+
+	return &estimate, nil
+}
+
 // extractPreemptable return volcano.sh/preemptable value for job
 func (ji *JobInfo) extractPreemptable(pg *PodGroup) bool {
 	// check annotation first
@@ -592,6 +630,7 @@ func (ji *JobInfo) addTaskIndex(ti *TaskInfo) {
 
 // AddTaskInfo is used to add a task to a job
 func (ji *JobInfo) AddTaskInfo(ti *TaskInfo) {
+	ji.Generation = NextGeneration()
 	ji.Tasks[ti.UID] = ti
 	ji.addTaskIndex(ti)
 	ji.TotalRequest.Add(ti.Resreq)
@@ -633,6 +672,7 @@ func (ji *JobInfo) deleteTaskIndex(ti *TaskInfo) {
 
 // DeleteTaskInfo is used to delete a task from a job
 func (ji *JobInfo) DeleteTaskInfo(ti *TaskInfo) error {
+	ji.Generation = NextGeneration()
 	if task, found := ji.Tasks[ti.UID]; found {
 		ji.TotalRequest.Sub(task.Resreq)
 		if AllocatedStatus(task.Status) {
@@ -657,12 +697,13 @@ func (ji *JobInfo) Clone() *JobInfo {
 		Queue:     ji.Queue,
 		Priority:  ji.Priority,
 
-		MinAvailable:   ji.MinAvailable,
-		WaitingTime:    ji.WaitingTime,
-		JobFitErrors:   ji.JobFitErrors,
-		NodesFitErrors: make(map[TaskID]*FitErrors),
-		Allocated:      EmptyResource(),
-		TotalRequest:   EmptyResource(),
+		MinAvailable:    ji.MinAvailable,
+		WaitingTime:     ji.WaitingTime,
+		RunningEstimate: ji.RunningEstimate,
+		JobFitErrors:    ji.JobFitErrors,
+		NodesFitErrors:  make(map[TaskID]*FitErrors),
+		Allocated:       EmptyResource(),
+		TotalRequest:    EmptyResource(),
 
 		PodGroup: ji.PodGroup.Clone(),
 
@@ -683,6 +724,7 @@ func (ji *JobInfo) Clone() *JobInfo {
 	for _, task := range ji.Tasks {
 		info.AddTaskInfo(task.Clone())
 	}
+	info.Generation = ji.Generation
 
 	return info
 }
@@ -31,7 +31,10 @@ import (
 )
 
 func jobInfoEqual(l, r *JobInfo) bool {
-	return equality.Semantic.DeepEqual(l, r)
+	// the generation of job is not predictable in tests
+	lc, rc := *l, *r
+	lc.Generation, rc.Generation = 0, 0
+	return equality.Semantic.DeepEqual(&lc, &rc)
 }
 
 func TestAddTaskInfo(t *testing.T) {
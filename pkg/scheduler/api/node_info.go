@@ -88,6 +88,10 @@ type NodeInfo struct {
 	// checking an image's existence and advanced usage (e.g., image locality scheduling policy) based on the image
 	// state information.
 	ImageStates map[string]*k8sframework.ImageStateSummary
+
+	// Generation is bumped whenever the node is changed, it tells the incremental
+	// snapshot whether the copy of node is still up to date.
+	Generation int64
 }
 
 // FutureIdle returns resources that will be idle in the future:
@@ -108,17 +112,30 @@ type NodeState struct {
 	Reason string
 }
 
-// NodeUsage defines the real load usage of node
+// NodeUsage defines the real load usage of node, the usages are keyed by the period of the average
 type NodeUsage struct {
 	MetricsTime time.Time
 	CPUUsageAvg map[string]float64
 	MEMUsageAvg map[string]float64
+	// LoadAvg is the load average of the node
+	LoadAvg map[string]float64
+	// NetworkReceiveAvg and NetworkTransmitAvg are the network throughput of the node in bytes per second
+	NetworkReceiveAvg  map[string]float64
+	NetworkTransmitAvg map[string]float64
+	// DiskReadAvg and DiskWriteAvg are the disk throughput of the node in bytes per second
+	DiskReadAvg  map[string]float64
+	DiskWriteAvg map[string]float64
 }
 
 func (nu *NodeUsage) DeepCopy() *NodeUsage {
 	newUsage := &NodeUsage{
-		CPUUsageAvg: make(map[string]float64),
-		MEMUsageAvg: make(map[string]float64),
+		CPUUsageAvg:        make(map[string]float64),
+		MEMUsageAvg:        make(map[string]float64),
+		LoadAvg:            make(map[string]float64),
+		NetworkReceiveAvg:  make(map[string]float64),
+		NetworkTransmitAvg: make(map[string]float64),
+		DiskReadAvg:        make(map[string]float64),
+		DiskWriteAvg:       make(map[string]float64),
 	}
 	newUsage.MetricsTime = nu.MetricsTime
 	for k, v := range nu.CPUUsageAvg {
@@ -127,6 +144,21 @@ func (nu *NodeUsage) DeepCopy() *NodeUsage {
 	for k, v := range nu.MEMUsageAvg {
 		newUsage.MEMUsageAvg[k] = v
 	}
+	for k, v := range nu.LoadAvg {
+		newUsage.LoadAvg[k] = v
+	}
+	for k, v := range nu.NetworkReceiveAvg {
+		newUsage.NetworkReceiveAvg[k] = v
+	}
+	for k, v := range nu.NetworkTransmitAvg {
+		newUsage.NetworkTransmitAvg[k] = v
+	}
+	for k, v := range nu.DiskReadAvg {
+		newUsage.DiskReadAvg[k] = v
+	}
+	for k, v := range nu.DiskWriteAvg {
+		newUsage.DiskWriteAvg[k] = v
+	}
 	return newUsage
 }
 
@@ -168,9 +200,16 @@ func NewNodeInfo(node *v1.Node) *NodeInfo {
 // RefreshNumaSchedulerInfoByCrd used to update scheduler numa information based the CRD numatopo
 func (ni *NodeInfo) RefreshNumaSchedulerInfoByCrd() {
 	if ni.NumaInfo == nil {
-		ni.NumaSchedulerInfo = nil
+		if ni.NumaSchedulerInfo != nil {
+			ni.NumaSchedulerInfo = nil
+			ni.Generation = NextGeneration()
+		}
 		return
 	}
+	if ni.NumaChgFlag == NumaInfoResetFlag {
+		return
+	}
+	ni.Generation = NextGeneration()
 
 	tmp := ni.NumaInfo.DeepCopy()
 	if ni.NumaChgFlag == NumaInfoMoreFlag {
@@ -218,6 +257,7 @@ func (ni *NodeInfo) Clone() *NodeInfo {
 
 	res.Others = ni.CloneOthers()
 	res.ImageStates = ni.CloneImageSummary()
+	res.Generation = ni.Generation
 	return res
 }
 
@@ -308,6 +348,7 @@ func (ni *NodeInfo) setNodeState(node *v1.Node) {
 
 // SetNode sets kubernetes node object to nodeInfo object
 func (ni *NodeInfo) SetNode(node *v1.Node) {
+	ni.Generation = NextGeneration()
 	ni.setNodeState(node)
 	if !ni.Ready() {
 		klog.Warningf("Failed to set node info for %s, phase: %s, reason: %s",
@@ -441,6 +482,7 @@ func (ni *NodeInfo) AddTask(task *TaskInfo) error {
 	task.NodeName = ni.Name
 	ti.NodeName = ni.Name
 	ni.Tasks[key] = ti
+	ni.Generation = NextGeneration()
 
 	return nil
 }
@@ -458,6 +500,8 @@ func (ni *NodeInfo) RemoveTask(ti *TaskInfo) error {
 		return nil
 	}
 
+	ni.Generation = NextGeneration()
+
 	if ni.Node != nil {
 		switch task.Status {
 		case Releasing:
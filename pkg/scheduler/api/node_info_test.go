@@ -29,7 +29,10 @@ import (
 )
 
 func nodeInfoEqual(l, r *NodeInfo) bool {
-	return reflect.DeepEqual(l, r)
+	// the generation of node is not predictable in tests
+	lc, rc := *l, *r
+	lc.Generation, rc.Generation = 0, 0
+	return reflect.DeepEqual(&lc, &rc)
 }
 
 func TestNodeInfo_AddPod(t *testing.T) {
@@ -158,6 +158,16 @@ type SchedulerCache struct {
 
 	nodeWorkers uint32
 
+	// scheduleTrigger is signaled when an event arrives which may make pending jobs schedulable.
+	scheduleTrigger chan struct{}
+
+	// pluginStates are the states kept by plugins across sessions: plugin name -> state
+	pluginStates sync.Map
+
+	// incrementalSnapshot keeps the clones of the last session snapshot, it is nil when
+	// the incremental snapshot is disabled and every session deep copies the whole cache.
+	incrementalSnapshot *snapshotState
+
 	// IgnoredCSIProvisioners contains a list of provisioners, and pod request pvc with these provisioners will
 	// not be counted in pod pvc resource request and node.Allocatable, because the spec.drivers of csinode resource
 	// is always null, these provisioners usually are host path csi controllers like rancher.io/local-path and hostpath.csi.k8s.io.
@@ -587,8 +597,13 @@ func newSchedulerCache(config *rest.Config, schedulerNames []string, defaultQueu
 		CSINodesStatus:      make(map[string]*schedulingapi.CSINodeStatusInfo),
 		imageStates:         make(map[string]*imageState),
 
-		NodeList:    []string{},
-		nodeWorkers: nodeWorkers,
+		NodeList:        []string{},
+		nodeWorkers:     nodeWorkers,
+		scheduleTrigger: make(chan struct{}, 1),
+	}
+
+	if options.ServerOpts != nil && options.ServerOpts.EnableIncrementalSnapshot {
+		sc.incrementalSnapshot = newSnapshotState()
 	}
 
 	sc.schedulerPodName, sc.c = getMultiSchedulerInfo()
@@ -1009,6 +1024,7 @@ func (sc *SchedulerCache) UpdateSchedulerNumaInfo(AllocatedSets map[string]sched
 		}
 
 		numaInfo.Allocate(sets)
+		sc.Nodes[nodeName].Generation = schedulingapi.NextGeneration()
 	}
 	return nil
 }
@@ -1314,6 +1330,23 @@ func (sc *SchedulerCache) Snapshot() *schedulingapi.ClusterInfo {
 	sc.Mutex.Lock()
 	defer sc.Mutex.Unlock()
 
+	return sc.snapshot(nil)
+}
+
+// SessionSnapshot returns the snapshot for a new session. When the incremental snapshot is enabled,
+// the nodes and jobs that did not change since the last session snapshot are not cloned again.
+func (sc *SchedulerCache) SessionSnapshot() *schedulingapi.ClusterInfo {
+	sc.Mutex.Lock()
+	defer sc.Mutex.Unlock()
+
+	return sc.snapshot(sc.incrementalSnapshot)
+}
+
+// snapshot deep copies the cache, reusing the clones kept by state if it is not nil.
+func (sc *SchedulerCache) snapshot(state *snapshotState) *schedulingapi.ClusterInfo {
+	state.begin()
+	defer state.end()
+
 	snapshot := &schedulingapi.ClusterInfo{
 		Nodes:          make(map[string]*schedulingapi.NodeInfo),
 		Jobs:           make(map[schedulingapi.JobID]*schedulingapi.JobInfo),
@@ -1338,7 +1371,7 @@ func (sc *SchedulerCache) Snapshot() *schedulingapi.ClusterInfo {
 			continue
 		}
 
-		snapshot.Nodes[value.Name] = value.Clone()
+		snapshot.Nodes[value.Name] = state.cloneNode(value)
 
 		if value.RevocableZone != "" {
 			snapshot.RevocableNodes[value.Name] = snapshot.Nodes[value.Name]
@@ -1366,7 +1399,7 @@ func (sc *SchedulerCache) Snapshot() *schedulingapi.ClusterInfo {
 				value.Namespace, value.Name, priName, value.Priority)
 		}
 
-		clonedJob := value.Clone()
+		clonedJob := state.cloneJob(value)
 
 		cloneJobLock.Lock()
 		snapshot.Jobs[value.UID] = clonedJob
@@ -1404,6 +1437,30 @@ func (sc *SchedulerCache) Snapshot() *schedulingapi.ClusterInfo {
 	return snapshot
 }
 
+// ScheduleTrigger returns the channel signaled when an event arrives which may make pending jobs schedulable.
+func (sc *SchedulerCache) ScheduleTrigger() <-chan struct{} {
+	return sc.scheduleTrigger
+}
+
+// PluginState returns the state the plugin keeps across sessions, which is created by newState at the first call.
+func (sc *SchedulerCache) PluginState(name string, newState func() interface{}) interface{} {
+	if state, found := sc.pluginStates.Load(name); found {
+		return state
+	}
+	state, _ := sc.pluginStates.LoadOrStore(name, newState())
+	return state
+}
+
+// triggerSchedule signals the schedule trigger without blocking, the signals which
+// are not consumed yet are merged into one.
+func (sc *SchedulerCache) triggerSchedule(reason string) {
+	select {
+	case sc.scheduleTrigger <- struct{}{}:
+		klog.V(4).Infof("Trigger scheduling for %s", reason)
+	default:
+	}
+}
+
 // String returns information about the cache in a string format
 func (sc *SchedulerCache) String() string {
 	sc.Mutex.Lock()
@@ -1546,7 +1603,7 @@ func (sc *SchedulerCache) GetMetricsData() {
 		return
 	}
 
-	client, err := source.NewMetricsClient(sc.restConfig, sc.metricsConf)
+	client, err := source.NewMetricsClient(sc.restConfig, sc.nodeInformer.Lister(), sc.metricsConf)
 	if err != nil {
 		klog.Errorf("Error creating client: %v\n", err)
 		return
@@ -1576,12 +1633,22 @@ func (sc *SchedulerCache) setMetricsData(usageInfo map[string]*source.NodeMetric
 
 	for nodeName, nodeMetric := range usageInfo {
 		nodeUsage := &schedulingapi.NodeUsage{
-			CPUUsageAvg: make(map[string]float64),
-			MEMUsageAvg: make(map[string]float64),
+			CPUUsageAvg:        make(map[string]float64),
+			MEMUsageAvg:        make(map[string]float64),
+			LoadAvg:            make(map[string]float64),
+			NetworkReceiveAvg:  make(map[string]float64),
+			NetworkTransmitAvg: make(map[string]float64),
+			DiskReadAvg:        make(map[string]float64),
+			DiskWriteAvg:       make(map[string]float64),
 		}
 		nodeUsage.MetricsTime = nodeMetric.MetricsTime
 		nodeUsage.CPUUsageAvg[source.NODE_METRICS_PERIOD] = nodeMetric.CPU
 		nodeUsage.MEMUsageAvg[source.NODE_METRICS_PERIOD] = nodeMetric.Memory
+		nodeUsage.LoadAvg[source.NODE_METRICS_PERIOD] = nodeMetric.Load
+		nodeUsage.NetworkReceiveAvg[source.NODE_METRICS_PERIOD] = nodeMetric.NetworkReceive
+		nodeUsage.NetworkTransmitAvg[source.NODE_METRICS_PERIOD] = nodeMetric.NetworkTransmit
+		nodeUsage.DiskReadAvg[source.NODE_METRICS_PERIOD] = nodeMetric.DiskRead
+		nodeUsage.DiskWriteAvg[source.NODE_METRICS_PERIOD] = nodeMetric.DiskWrite
 
 		nodeInfo, ok := sc.Nodes[nodeName]
 		if !ok {
@@ -1590,6 +1657,7 @@ func (sc *SchedulerCache) setMetricsData(usageInfo map[string]*source.NodeMetric
 		}
 		klog.V(5).Infof("node: %s, ResourceUsage: %+v => %+v", nodeName, *nodeInfo.ResourceUsage, nodeUsage)
 		nodeInfo.ResourceUsage = nodeUsage
+		nodeInfo.Generation = schedulingapi.NextGeneration()
 	}
 }
 
@@ -114,12 +114,16 @@ func newMockSchedulerCache(schedulerName string) *SchedulerCache {
 		NamespaceCollection: make(map[string]*schedulingapi.NamespaceCollection),
 		CSINodesStatus:      make(map[string]*schedulingapi.CSINodeStatusInfo),
 		imageStates:         make(map[string]*imageState),
+		scheduleTrigger:     make(chan struct{}, 1),
 
 		NodeList: []string{},
 	}
 	if options.ServerOpts != nil && len(options.ServerOpts.NodeSelector) > 0 {
 		msc.updateNodeSelectors(options.ServerOpts.NodeSelector)
 	}
+	if options.ServerOpts != nil && options.ServerOpts.EnableIncrementalSnapshot {
+		msc.incrementalSnapshot = newSnapshotState()
+	}
 	msc.setBatchBindParallel()
 	msc.nodeWorkers = getNodeWorkers()
 
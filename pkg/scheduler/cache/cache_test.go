@@ -28,6 +28,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 	"volcano.sh/volcano/pkg/scheduler/api"
 	"volcano.sh/volcano/pkg/scheduler/util"
 )
@@ -339,3 +340,52 @@ func TestBindTasks(t *testing.T) {
 		t.Fatalf("successfully binding task should have 1 event")
 	}
 }
+
+func TestScheduleTrigger(t *testing.T) {
+	sc := NewDefaultMockSchedulerCache("volcano")
+	triggered := func() bool {
+		select {
+		case <-sc.ScheduleTrigger():
+			return true
+		default:
+			return false
+		}
+	}
+
+	node := buildNode("n1", api.BuildResourceList("2000m", "10G"))
+	sc.AddOrUpdateNode(node)
+	if !triggered() {
+		t.Errorf("expected adding a node to trigger scheduling")
+	}
+	sc.AddOrUpdateNode(node)
+	if triggered() {
+		t.Errorf("expected updating a node not to trigger scheduling")
+	}
+
+	pg := util.BuildPodGroup("pg1", "c1", "default", 1, nil, schedulingv1beta1.PodGroupPending)
+	sc.AddPodGroupV1beta1(pg)
+	if triggered() {
+		t.Errorf("expected adding a pending podgroup not to trigger scheduling")
+	}
+	sc.AddPodGroupV1beta1(util.BuildPodGroup("pg2", "c1", "default", 1, nil, schedulingv1beta1.PodGroupInqueue))
+	if !triggered() {
+		t.Errorf("expected adding an inqueue podgroup to trigger scheduling")
+	}
+	inqueue := pg.DeepCopy()
+	inqueue.ResourceVersion = "2"
+	inqueue.Status.Phase = schedulingv1beta1.PodGroupInqueue
+	sc.UpdatePodGroupV1beta1(pg, inqueue)
+	if !triggered() {
+		t.Errorf("expected a podgroup becoming inqueue to trigger scheduling")
+	}
+
+	pod := util.BuildPod("c1", "p1", "n1", v1.PodRunning, api.BuildResourceList("1000m", "1G"), "pg1", nil, nil)
+	sc.AddPod(pod)
+	if triggered() {
+		t.Errorf("expected adding a running pod not to trigger scheduling")
+	}
+	sc.DeletePod(pod)
+	if !triggered() {
+		t.Errorf("expected deleting a pod from a node to trigger scheduling")
+	}
+}
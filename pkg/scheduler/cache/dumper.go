@@ -52,7 +52,12 @@ func (d *Dumper) dumpToJSONFile() {
 	}
 	defer file.Close()
 	klog.Infoln("Starting to dump info in scheduler cache to file", fName)
-	if err = json.NewEncoder(file).Encode(snapshot.Nodes); err != nil {
+	clusterSnapshot, err := NewClusterSnapshot(snapshot)
+	if err != nil {
+		klog.Errorf("Failed to dump info in scheduler cache, build snapshot error: %v", err)
+		return
+	}
+	if err = json.NewEncoder(file).Encode(clusterSnapshot); err != nil {
 		klog.Errorf("Failed to dump info in scheduler cache, json encode error: %v", err)
 		return
 	}
@@ -442,6 +442,9 @@ func (sc *SchedulerCache) DeletePod(obj interface{}) {
 		klog.Errorf("Failed to delete pod %v from cache: %v", pod.Name, err)
 		return
 	}
+	if len(pod.Spec.NodeName) != 0 {
+		sc.triggerSchedule("pod deleted")
+	}
 
 	klog.V(3).Infof("Deleted pod <%s/%v> from cache.", pod.Namespace, pod.Name)
 }
@@ -498,6 +501,7 @@ func (sc *SchedulerCache) AddOrUpdateNode(node *v1.Node) error {
 		sc.removeNodeImageStates(node.Name)
 	} else {
 		sc.Nodes[node.Name] = schedulingapi.NewNodeInfo(node)
+		sc.triggerSchedule("node added")
 	}
 	sc.addNodeImageStates(node, sc.Nodes[node.Name])
 
@@ -761,6 +765,10 @@ func (sc *SchedulerCache) AddPodGroupV1beta1(obj interface{}) {
 		klog.Errorf("Failed to add PodGroup %s into cache: %v", ss.Name, err)
 		return
 	}
+	// a pending podgroup has to be enqueued by the scheduling cycle first, which is triggered once it becomes inqueue
+	if podgroup.Status.Phase == scheduling.PodGroupInqueue {
+		sc.triggerSchedule("podgroup added")
+	}
 }
 
 // UpdatePodGroupV1beta1 add podgroup to scheduler cache
@@ -795,6 +803,9 @@ func (sc *SchedulerCache) UpdatePodGroupV1beta1(oldObj, newObj interface{}) {
 		klog.Errorf("Failed to update SchedulingSpec %s into cache: %v", pg.Name, err)
 		return
 	}
+	if oldSS.Status.Phase != schedulingv1beta1.PodGroupInqueue && newSS.Status.Phase == schedulingv1beta1.PodGroupInqueue {
+		sc.triggerSchedule("podgroup inqueue")
+	}
 }
 
 // DeletePodGroupV1beta1 delete podgroup from scheduler cache
@@ -1141,6 +1152,8 @@ func (sc *SchedulerCache) addNumaInfo(info *nodeinfov1alpha1.Numatopology) error
 		sc.Nodes[info.Name].NumaInfo = newLocalInfo
 	}
 
+	sc.Nodes[info.Name].Generation = schedulingapi.NextGeneration()
+
 	for resName, NumaResInfo := range sc.Nodes[info.Name].NumaInfo.NumaResMap {
 		klog.V(3).Infof("resource %s Allocatable %v on node[%s] into cache", resName, NumaResInfo, info.Name)
 	}
@@ -1155,6 +1168,7 @@ func (sc *SchedulerCache) deleteNumaInfo(info *nodeinfov1alpha1.Numatopology) {
 	if sc.Nodes[info.Name] != nil {
 		sc.Nodes[info.Name].NumaInfo = nil
 		sc.Nodes[info.Name].NumaChgFlag = schedulingapi.NumaInfoResetFlag
+		sc.Nodes[info.Name].Generation = schedulingapi.NextGeneration()
 		klog.V(3).Infof("delete numainfo in cache for node<%s>", info.Name)
 	}
 }
@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"sync"
+
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// nodeClone is a node cloned by the last session snapshot and the generation of the cached node it was cloned from.
+type nodeClone struct {
+	node       *schedulingapi.NodeInfo
+	generation int64
+}
+
+// jobClone is a job cloned by the last session snapshot and the generation of the cached job it was cloned from.
+type jobClone struct {
+	job        *schedulingapi.JobInfo
+	generation int64
+}
+
+// snapshotState keeps the nodes and jobs cloned by the last session snapshot. A clone is reused by the next
+// snapshot if neither the cached object nor the clone itself was changed since, which is told by the generation:
+// every change bumps the generation of the changed object to a new value of schedulingapi.NextGeneration.
+// A nil *snapshotState clones everything.
+type snapshotState struct {
+	nodes map[string]*nodeClone
+	jobs  map[schedulingapi.JobID]*jobClone
+
+	// nextNodes and nextJobs collect the clones of the snapshot in progress, so that the
+	// nodes and jobs deleted from the cache are dropped at the end of the snapshot.
+	nextNodes map[string]*nodeClone
+	jobsLock  sync.Mutex
+	nextJobs  map[schedulingapi.JobID]*jobClone
+}
+
+func newSnapshotState() *snapshotState {
+	return &snapshotState{
+		nodes: make(map[string]*nodeClone),
+		jobs:  make(map[schedulingapi.JobID]*jobClone),
+	}
+}
+
+func (s *snapshotState) begin() {
+	if s == nil {
+		return
+	}
+	s.nextNodes = make(map[string]*nodeClone, len(s.nodes))
+	s.nextJobs = make(map[schedulingapi.JobID]*jobClone, len(s.jobs))
+}
+
+func (s *snapshotState) end() {
+	if s == nil {
+		return
+	}
+	s.nodes, s.nextNodes = s.nextNodes, nil
+	s.jobs, s.nextJobs = s.nextJobs, nil
+}
+
+// cloneNode returns the clone of the last snapshot if the node did not change, otherwise a new clone.
+func (s *snapshotState) cloneNode(node *schedulingapi.NodeInfo) *schedulingapi.NodeInfo {
+	if s == nil {
+		return node.Clone()
+	}
+
+	last, found := s.nodes[node.Name]
+	if !found || last.generation != node.Generation || last.node.Generation != node.Generation {
+		last = &nodeClone{node: node.Clone(), generation: node.Generation}
+	}
+	s.nextNodes[node.Name] = last
+
+	return last.node
+}
+
+// cloneJob returns the clone of the last snapshot if the job did not change, otherwise a new clone.
+// It is safe to be called concurrently for different jobs.
+func (s *snapshotState) cloneJob(job *schedulingapi.JobInfo) *schedulingapi.JobInfo {
+	if s == nil {
+		return job.Clone()
+	}
+
+	s.jobsLock.Lock()
+	last, found := s.jobs[job.UID]
+	s.jobsLock.Unlock()
+
+	if !found || last.generation != job.Generation || last.job.Generation != job.Generation {
+		last = &jobClone{job: job.Clone(), generation: job.Generation}
+	} else {
+		// The fields below are updated by the cache or the last session without bumping
+		// the generation, refresh them the same way as JobInfo.Clone does.
+		last.job.Priority = job.Priority
+		last.job.JobFitErrors = job.JobFitErrors
+		last.job.NodesFitErrors = make(map[schedulingapi.TaskID]*schedulingapi.FitErrors)
+		last.job.PodGroup = job.PodGroup.Clone()
+	}
+
+	s.jobsLock.Lock()
+	s.nextJobs[job.UID] = last
+	s.jobsLock.Unlock()
+
+	return last.job
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"fmt"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+
+	"volcano.sh/apis/pkg/apis/scheduling"
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestSessionSnapshot(t *testing.T) {
+	sc := NewDefaultMockSchedulerCache("volcano")
+	sc.incrementalSnapshot = newSnapshotState()
+	sc.AddOrUpdateNode(util.BuildNode("n1", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil))
+	sc.AddOrUpdateNode(util.BuildNode("n2", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil))
+	sc.AddQueueV1beta1(util.BuildQueue("q1", 1, nil))
+	sc.AddPodGroupV1beta1(util.BuildPodGroup("pg1", "ns1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning))
+	sc.AddPodGroupV1beta1(util.BuildPodGroup("pg2", "ns1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue))
+	sc.AddPod(util.BuildPod("ns1", "p1", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil))
+	sc.AddPod(util.BuildPod("ns1", "p2", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg2", nil, nil))
+
+	job1, job2 := api.JobID("ns1/pg1"), api.JobID("ns1/pg2")
+
+	first := sc.SessionSnapshot()
+	second := sc.SessionSnapshot()
+	if first.Nodes["n1"] != second.Nodes["n1"] || first.Nodes["n2"] != second.Nodes["n2"] {
+		t.Errorf("expected unchanged nodes to be reused")
+	}
+	if first.Jobs[job1] != second.Jobs[job1] || first.Jobs[job2] != second.Jobs[job2] {
+		t.Errorf("expected unchanged jobs to be reused")
+	}
+	if full := sc.Snapshot(); full.Nodes["n1"] == second.Nodes["n1"] || full.Jobs[job1] == second.Jobs[job1] {
+		t.Errorf("expected the full snapshot not to share objects with the session snapshot")
+	}
+
+	// change n2 and pg2 in the cache
+	sc.AddPod(util.BuildPod("ns1", "p3", "n2", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg2", nil, nil))
+	third := sc.SessionSnapshot()
+	if third.Nodes["n1"] != second.Nodes["n1"] || third.Jobs[job1] != second.Jobs[job1] {
+		t.Errorf("expected unchanged node n1 and job pg1 to be reused")
+	}
+	if third.Nodes["n2"] == second.Nodes["n2"] || len(third.Nodes["n2"].Tasks) != 1 {
+		t.Errorf("expected changed node n2 to be cloned again with 1 task, got %d tasks", len(third.Nodes["n2"].Tasks))
+	}
+	if third.Jobs[job2] == second.Jobs[job2] || len(third.Jobs[job2].Tasks) != 2 {
+		t.Errorf("expected changed job pg2 to be cloned again with 2 tasks, got %d tasks", len(third.Jobs[job2].Tasks))
+	}
+
+	// change n1 and pg1 in the session, which must not leak into the next session
+	task := api.NewTaskInfo(util.BuildPod("ns1", "p4", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil))
+	if err := third.Nodes["n1"].AddTask(task); err != nil {
+		t.Fatalf("failed to add task to node: %v", err)
+	}
+	third.Jobs[job1].AddTaskInfo(task)
+	third.Jobs[job2].PodGroup.Status.Phase = scheduling.PodGroupUnknown
+	fourth := sc.SessionSnapshot()
+	if fourth.Nodes["n1"] == third.Nodes["n1"] || len(fourth.Nodes["n1"].Tasks) != 1 {
+		t.Errorf("expected node n1 changed by the session to be cloned again with 1 task, got %d tasks", len(fourth.Nodes["n1"].Tasks))
+	}
+	if fourth.Jobs[job1] == third.Jobs[job1] || len(fourth.Jobs[job1].Tasks) != 1 {
+		t.Errorf("expected job pg1 changed by the session to be cloned again with 1 task, got %d tasks", len(fourth.Jobs[job1].Tasks))
+	}
+	if fourth.Jobs[job2] != third.Jobs[job2] || fourth.Jobs[job2].PodGroup.Status.Phase != scheduling.PodGroupInqueue {
+		t.Errorf("expected job pg2 to be reused with the podgroup of the cache, got phase %s", fourth.Jobs[job2].PodGroup.Status.Phase)
+	}
+
+	// deleted objects are dropped from the state
+	sc.RemoveNode("n2")
+	sc.SessionSnapshot()
+	if _, found := sc.incrementalSnapshot.nodes["n2"]; found {
+		t.Errorf("expected deleted node n2 to be dropped from the snapshot state")
+	}
+}
+
+// buildBenchmarkCache builds a cache of 5k nodes and 50k pods, which belong to 5k podgroups.
+func buildBenchmarkCache(b *testing.B) *SchedulerCache {
+	sc := NewDefaultMockSchedulerCache("volcano")
+	sc.AddQueueV1beta1(util.BuildQueue("q1", 1, nil))
+	for i := 0; i < 5000; i++ {
+		sc.AddOrUpdateNode(util.BuildNode(fmt.Sprintf("n%d", i), api.BuildResourceList("64", "256Gi", []api.ScalarResource{{Name: "pods", Value: "110"}}...), nil))
+		sc.AddPodGroupV1beta1(util.BuildPodGroup(fmt.Sprintf("pg%d", i), "ns1", "q1", 10, nil, schedulingv1beta1.PodGroupRunning))
+	}
+	for i := 0; i < 50000; i++ {
+		sc.AddPod(util.BuildPod("ns1", fmt.Sprintf("p%d", i), fmt.Sprintf("n%d", i%5000), v1.PodRunning,
+			api.BuildResourceList("1", "1Gi"), fmt.Sprintf("pg%d", i/10), nil, nil))
+	}
+	if len(sc.Nodes) != 5000 || len(sc.Jobs) != 5000 {
+		b.Fatalf("expected 5000 nodes and jobs, got %d nodes and %d jobs", len(sc.Nodes), len(sc.Jobs))
+	}
+	return sc
+}
+
+// churn deletes and adds back 100 pods, so that about 2% of nodes and jobs change between snapshots.
+func churn(sc *SchedulerCache, round int) {
+	for i := 0; i < 100; i++ {
+		idx := (round*100 + i) * 487 % 50000
+		pod := util.BuildPod("ns1", fmt.Sprintf("p%d", idx), fmt.Sprintf("n%d", idx%5000), v1.PodRunning,
+			api.BuildResourceList("1", "1Gi"), fmt.Sprintf("pg%d", idx/10), nil, nil)
+		sc.DeletePod(pod)
+		sc.AddPod(pod)
+	}
+}
+
+func BenchmarkSnapshot(b *testing.B) {
+	sc := buildBenchmarkCache(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		churn(sc, i)
+		b.StartTimer()
+		sc.Snapshot()
+	}
+}
+
+func BenchmarkSessionSnapshot(b *testing.B) {
+	sc := buildBenchmarkCache(b)
+	sc.incrementalSnapshot = newSnapshotState()
+	sc.SessionSnapshot()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		churn(sc, i)
+		b.StartTimer()
+		sc.SessionSnapshot()
+	}
+}
@@ -37,6 +37,18 @@ type Cache interface {
 	// Snapshot deep copy overall cache information into snapshot
 	Snapshot() *api.ClusterInfo
 
+	// SessionSnapshot returns the snapshot of cache information for a new session, which
+	// may reuse the unchanged nodes and jobs of the previous session snapshot
+	SessionSnapshot() *api.ClusterInfo
+
+	// ScheduleTrigger returns the channel signaled when an event arrives
+	// which may make pending jobs schedulable
+	ScheduleTrigger() <-chan struct{}
+
+	// PluginState returns the state the plugin keeps across sessions, the sessions
+	// of the same cache share the state
+	PluginState(name string, newState func() interface{}) interface{}
+
 	// WaitForCacheSync waits for all cache synced
 	WaitForCacheSync(stopCh <-chan struct{})
 
@@ -0,0 +1,229 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	schedulingscheme "volcano.sh/apis/pkg/apis/scheduling/scheme"
+	vcv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	schedulingapi "volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// ClusterSnapshotVersion is the version of ClusterSnapshot format, it must be bumped
+// when an incompatible change is made to ClusterSnapshot.
+const ClusterSnapshotVersion = "v1"
+
+// defaultPriorityClassName is the name of the priority class rebuilt for the global default priority
+const defaultPriorityClassName = "volcano-snapshot-global-default"
+
+// ClusterSnapshot is the serializable form of a scheduler cache snapshot. Instead of the
+// derived api.ClusterInfo, it records the objects the snapshot was built from and the state
+// which can not be derived from them, so that the same api.ClusterInfo can be rebuilt by
+// replaying the objects into a scheduler cache.
+type ClusterSnapshot struct {
+	// Version is the format version of the snapshot, see ClusterSnapshotVersion
+	Version string `json:"version"`
+	// Timestamp is the time when the snapshot is taken
+	Timestamp metav1.Time `json:"timestamp"`
+
+	Nodes           []*v1.Node                    `json:"nodes,omitempty"`
+	Pods            []*v1.Pod                     `json:"pods,omitempty"`
+	PodGroups       []*vcv1beta1.PodGroup         `json:"podGroups,omitempty"`
+	Queues          []*vcv1beta1.Queue            `json:"queues,omitempty"`
+	PriorityClasses []*schedulingv1.PriorityClass `json:"priorityClasses,omitempty"`
+	ResourceQuotas  []*v1.ResourceQuota           `json:"resourceQuotas,omitempty"`
+
+	// NodeUsage is the node load collected from metrics source, key is node name
+	NodeUsage map[string]*schedulingapi.NodeUsage `json:"nodeUsage,omitempty"`
+	// CSINodesStatus is the status of csi drivers, key is csi node name
+	CSINodesStatus map[string]*schedulingapi.CSINodeStatusInfo `json:"csiNodesStatus,omitempty"`
+}
+
+// NewClusterSnapshot builds a ClusterSnapshot from the snapshot of scheduler cache.
+func NewClusterSnapshot(ci *schedulingapi.ClusterInfo) (*ClusterSnapshot, error) {
+	cs := &ClusterSnapshot{
+		Version:        ClusterSnapshotVersion,
+		Timestamp:      metav1.Now(),
+		NodeUsage:      map[string]*schedulingapi.NodeUsage{},
+		CSINodesStatus: map[string]*schedulingapi.CSINodeStatusInfo{},
+	}
+
+	pods := map[schedulingapi.TaskID]*v1.Pod{}
+	for _, node := range ci.Nodes {
+		if node.Node == nil {
+			continue
+		}
+		cs.Nodes = append(cs.Nodes, node.Node)
+		if node.ResourceUsage != nil {
+			cs.NodeUsage[node.Name] = node.ResourceUsage.DeepCopy()
+		}
+		// pods not scheduled by volcano only exist in nodes
+		for _, task := range node.Tasks {
+			if task.Pod != nil {
+				pods[task.UID] = task.Pod
+			}
+		}
+	}
+
+	for _, queue := range ci.Queues {
+		if queue.Queue == nil {
+			continue
+		}
+		q := &vcv1beta1.Queue{}
+		if err := schedulingscheme.Scheme.Convert(queue.Queue, q, nil); err != nil {
+			return nil, fmt.Errorf("failed to convert queue <%s>: %v", queue.Name, err)
+		}
+		cs.Queues = append(cs.Queues, q)
+	}
+
+	priorityClasses := map[string]*schedulingv1.PriorityClass{}
+	for _, job := range ci.Jobs {
+		for _, task := range job.Tasks {
+			if task.Pod != nil {
+				pods[task.UID] = task.Pod
+			}
+		}
+		if job.PodGroup == nil {
+			continue
+		}
+		pg := &vcv1beta1.PodGroup{}
+		if err := schedulingscheme.Scheme.Convert(&job.PodGroup.PodGroup, pg, nil); err != nil {
+			return nil, fmt.Errorf("failed to convert podgroup <%s/%s>: %v", job.Namespace, job.Name, err)
+		}
+		cs.PodGroups = append(cs.PodGroups, pg)
+
+		// priority classes are not kept in snapshot, rebuild them from the priority resolved for jobs
+		name := pg.Spec.PriorityClassName
+		if name == "" {
+			if job.Priority == 0 {
+				continue
+			}
+			name = defaultPriorityClassName
+		}
+		priorityClasses[name] = &schedulingv1.PriorityClass{
+			ObjectMeta:    metav1.ObjectMeta{Name: name},
+			Value:         job.Priority,
+			GlobalDefault: name == defaultPriorityClassName,
+		}
+	}
+	for _, pod := range pods {
+		cs.Pods = append(cs.Pods, pod)
+	}
+	for _, pc := range priorityClasses {
+		cs.PriorityClasses = append(cs.PriorityClasses, pc)
+	}
+
+	for _, ns := range ci.NamespaceInfo {
+		for name, status := range ns.QuotaStatus {
+			cs.ResourceQuotas = append(cs.ResourceQuotas, &v1.ResourceQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: string(ns.Name)},
+				Status:     status,
+			})
+		}
+	}
+
+	for name, status := range ci.CSINodesStatus {
+		cs.CSINodesStatus[name] = status.Clone()
+	}
+
+	cs.sort()
+	return cs, nil
+}
+
+// sort keeps the order of objects stable, so that the cache rebuilt from the snapshot is the same every time
+func (cs *ClusterSnapshot) sort() {
+	sort.Slice(cs.Nodes, func(i, j int) bool { return cs.Nodes[i].Name < cs.Nodes[j].Name })
+	sort.Slice(cs.Queues, func(i, j int) bool { return cs.Queues[i].Name < cs.Queues[j].Name })
+	sort.Slice(cs.PriorityClasses, func(i, j int) bool { return cs.PriorityClasses[i].Name < cs.PriorityClasses[j].Name })
+	sort.Slice(cs.Pods, func(i, j int) bool {
+		return cs.Pods[i].Namespace+"/"+cs.Pods[i].Name < cs.Pods[j].Namespace+"/"+cs.Pods[j].Name
+	})
+	sort.Slice(cs.PodGroups, func(i, j int) bool {
+		return cs.PodGroups[i].Namespace+"/"+cs.PodGroups[i].Name < cs.PodGroups[j].Namespace+"/"+cs.PodGroups[j].Name
+	})
+	sort.Slice(cs.ResourceQuotas, func(i, j int) bool {
+		return cs.ResourceQuotas[i].Namespace+"/"+cs.ResourceQuotas[i].Name < cs.ResourceQuotas[j].Namespace+"/"+cs.ResourceQuotas[j].Name
+	})
+}
+
+// Restore replays the objects of snapshot into a mocked scheduler cache, so that the cache can be
+// used to open sessions offline, e.g. in unit tests or a simulator.
+func (cs *ClusterSnapshot) Restore(sc *SchedulerCache) {
+	for _, pc := range cs.PriorityClasses {
+		sc.AddPriorityClass(pc)
+	}
+	for _, node := range cs.Nodes {
+		sc.AddOrUpdateNode(node)
+	}
+	for _, queue := range cs.Queues {
+		sc.AddQueueV1beta1(queue)
+	}
+	for _, pg := range cs.PodGroups {
+		sc.AddPodGroupV1beta1(pg)
+	}
+	for _, pod := range cs.Pods {
+		sc.AddPod(pod)
+	}
+	for _, rq := range cs.ResourceQuotas {
+		sc.AddResourceQuota(rq)
+	}
+
+	sc.Mutex.Lock()
+	defer sc.Mutex.Unlock()
+	for name, usage := range cs.NodeUsage {
+		if node, found := sc.Nodes[name]; found {
+			node.ResourceUsage = usage.DeepCopy()
+			node.Generation = schedulingapi.NextGeneration()
+		}
+	}
+	for name, status := range cs.CSINodesStatus {
+		sc.CSINodesStatus[name] = status.Clone()
+	}
+}
+
+// ClusterInfo rebuilds the api.ClusterInfo the snapshot was taken from.
+func (cs *ClusterSnapshot) ClusterInfo() *schedulingapi.ClusterInfo {
+	sc := NewDefaultMockSchedulerCache("volcano")
+	cs.Restore(sc)
+	return sc.Snapshot()
+}
+
+// LoadClusterSnapshot reads a ClusterSnapshot from the json file written by Dumper.
+func LoadClusterSnapshot(path string) (*ClusterSnapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot file %s: %v", path, err)
+	}
+
+	cs := &ClusterSnapshot{}
+	if err := json.Unmarshal(data, cs); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot file %s: %v", path, err)
+	}
+	if cs.Version != ClusterSnapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version <%s> in file %s, expect <%s>",
+			cs.Version, path, ClusterSnapshotVersion)
+	}
+	return cs, nil
+}
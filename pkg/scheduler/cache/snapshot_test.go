@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestClusterSnapshotRoundTrip(t *testing.T) {
+	sc := NewDefaultMockSchedulerCache("volcano")
+	sc.AddPriorityClass(util.BuildPriorityClass("high", 100))
+	sc.AddOrUpdateNode(util.BuildNode("n1", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil))
+	sc.AddQueueV1beta1(util.BuildQueue("q1", 1, nil))
+	sc.AddPodGroupV1beta1(util.BuildPodGroupWithPrio("pg1", "ns1", "q1", 2, nil, schedulingv1beta1.PodGroupRunning, "high"))
+	sc.AddPod(util.BuildPod("ns1", "p1", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil))
+	sc.AddPod(util.BuildPod("ns1", "p2", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil))
+	// pod of other scheduler without podgroup
+	sc.AddPod(util.BuildPod("ns2", "p3", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "", nil, nil))
+	sc.AddResourceQuota(&v1.ResourceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "rq1", Namespace: "ns1"},
+		Status:     v1.ResourceQuotaStatus{Hard: api.BuildResourceList("10", "10Gi")},
+	})
+	sc.CSINodesStatus["n1"] = &api.CSINodeStatusInfo{CSINodeName: "n1", DriverStatus: map[string]bool{"csi.driver": true}}
+	sc.Nodes["n1"].ResourceUsage = &api.NodeUsage{CPUUsageAvg: map[string]float64{"5m": 50}, MEMUsageAvg: map[string]float64{"5m": 20}}
+
+	origin := sc.Snapshot()
+	cs, err := NewClusterSnapshot(origin)
+	if err != nil {
+		t.Fatalf("failed to build cluster snapshot: %v", err)
+	}
+
+	data, err := json.Marshal(cs)
+	if err != nil {
+		t.Fatalf("failed to encode cluster snapshot: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("failed to write cluster snapshot: %v", err)
+	}
+
+	loaded, err := LoadClusterSnapshot(path)
+	if err != nil {
+		t.Fatalf("failed to load cluster snapshot: %v", err)
+	}
+	restored := loaded.ClusterInfo()
+
+	if len(restored.Nodes) != len(origin.Nodes) || len(restored.Jobs) != len(origin.Jobs) || len(restored.Queues) != len(origin.Queues) {
+		t.Fatalf("restored snapshot mismatch: nodes %d/%d, jobs %d/%d, queues %d/%d",
+			len(restored.Nodes), len(origin.Nodes), len(restored.Jobs), len(origin.Jobs), len(restored.Queues), len(origin.Queues))
+	}
+
+	for name, node := range origin.Nodes {
+		got := restored.Nodes[name]
+		if !equality.Semantic.DeepEqual(node.Used, got.Used) || !equality.Semantic.DeepEqual(node.Idle, got.Idle) || len(node.Tasks) != len(got.Tasks) {
+			t.Errorf("node %s mismatch: want used %v idle %v tasks %d, got used %v idle %v tasks %d",
+				name, node.Used, node.Idle, len(node.Tasks), got.Used, got.Idle, len(got.Tasks))
+		}
+		if !reflect.DeepEqual(node.ResourceUsage.CPUUsageAvg, got.ResourceUsage.CPUUsageAvg) {
+			t.Errorf("node %s usage mismatch: want %v, got %v", name, node.ResourceUsage, got.ResourceUsage)
+		}
+	}
+
+	for uid, job := range origin.Jobs {
+		got, found := restored.Jobs[uid]
+		if !found {
+			t.Fatalf("job %s is missing after restore", uid)
+		}
+		if job.Priority != got.Priority || job.MinAvailable != got.MinAvailable || len(job.Tasks) != len(got.Tasks) {
+			t.Errorf("job %s mismatch: want priority %d minAvailable %d tasks %d, got priority %d minAvailable %d tasks %d",
+				uid, job.Priority, job.MinAvailable, len(job.Tasks), got.Priority, got.MinAvailable, len(got.Tasks))
+		}
+		for status, tasks := range job.TaskStatusIndex {
+			if len(got.TaskStatusIndex[status]) != len(tasks) {
+				t.Errorf("job %s has %d tasks in status %v, want %d", uid, len(got.TaskStatusIndex[status]), status, len(tasks))
+			}
+		}
+	}
+
+	if !reflect.DeepEqual(origin.NamespaceInfo["ns1"].QuotaStatus, restored.NamespaceInfo["ns1"].QuotaStatus) {
+		t.Errorf("namespace info mismatch: want %v, got %v", origin.NamespaceInfo["ns1"], restored.NamespaceInfo["ns1"])
+	}
+	if !reflect.DeepEqual(origin.CSINodesStatus, restored.CSINodesStatus) {
+		t.Errorf("csi node status mismatch: want %v, got %v", origin.CSINodesStatus, restored.CSINodesStatus)
+	}
+}
+
+func TestLoadClusterSnapshotVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	if err := os.WriteFile(path, []byte(`{"version": "v0"}`), 0644); err != nil {
+		t.Fatalf("failed to write cluster snapshot: %v", err)
+	}
+	if _, err := LoadClusterSnapshot(path); err == nil {
+		t.Errorf("expect error when loading snapshot with unsupported version")
+	}
+}
@@ -3,4 +3,18 @@ package conf
 const (
 	// EnablePredicateErrCacheKey is the key whether predicate error cache is enabled
 	EnablePredicateErrCacheKey = "predicateErrorCacheEnable"
+	// ParallelismKey is the key of the number of workers predicating and scoring nodes in parallel
+	ParallelismKey = "parallelism"
+	// PercentageOfNodesToFindKey is the key of the percentage of nodes to find feasible and score,
+	// it overrides --percentage-nodes-to-find for the action if it's greater than 0
+	PercentageOfNodesToFindKey = "percentageOfNodesToFind"
+	// BackfillModeKey is the key of the backfill mode, which is one of besteffort, easy and conservative
+	BackfillModeKey = "backfillMode"
+	// PreemptionCostKey is the key of the comma separated criteria comparing the victims of the preemption on
+	// the nodes, the first criterion is compared first. The preemptor preempts on the first node in score order
+	// if it's not set.
+	PreemptionCostKey = "preemptionCost"
+	// GangEvictionModeKey is the key of how the preempt and reclaim actions evict the tasks of the victim gang jobs,
+	// which is one of none, wholeJob and avoid
+	GangEvictionModeKey = "gangEvictionMode"
 )
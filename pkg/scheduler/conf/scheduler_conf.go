@@ -28,6 +28,21 @@ type SchedulerConfiguration struct {
 	// Configurations is configuration for actions
 	Configurations       []Configuration   `yaml:"configurations"`
 	MetricsConfiguration map[string]string `yaml:"metrics"`
+	// Profiles defines the actions, tiers and configurations of the scheduler names,
+	// the scheduler names without a profile use the actions, tiers and configurations above
+	Profiles []Profile `yaml:"profiles"`
+}
+
+// Profile defines the configuration of the sessions scheduling the pods of one scheduler name.
+type Profile struct {
+	// SchedulerName is the scheduler name of the pods scheduled by the profile
+	SchedulerName string `yaml:"schedulerName"`
+	// Actions defines the actions list of the profile in order, the actions of scheduler are used if empty
+	Actions string `yaml:"actions"`
+	// Tiers defines plugins in different tiers, the tiers of scheduler are used if empty
+	Tiers []Tier `yaml:"tiers"`
+	// Configurations is configuration for actions, the configurations of scheduler are used if empty
+	Configurations []Configuration `yaml:"configurations"`
 }
 
 // Tier defines plugin tier
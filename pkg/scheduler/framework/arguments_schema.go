@@ -0,0 +1,169 @@
+/*
+Copyright 2026 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// ArgumentType is the type of the value of an argument of plugin or action
+type ArgumentType string
+
+const (
+	// IntArgument is an integer
+	IntArgument ArgumentType = "int"
+	// Float64Argument is a float or an integer
+	Float64Argument ArgumentType = "float64"
+	// BoolArgument is a boolean
+	BoolArgument ArgumentType = "bool"
+	// StringArgument is a string
+	StringArgument ArgumentType = "string"
+	// DurationArgument is a string parsed by time.ParseDuration, e.g. 5m
+	DurationArgument ArgumentType = "duration"
+	// MapArgument is a map whose entries are not validated
+	MapArgument ArgumentType = "map"
+	// ListArgument is a list whose items are not validated
+	ListArgument ArgumentType = "list"
+)
+
+// ArgumentsSchema declares the arguments of a plugin or action: argument name -> type.
+// The names ending with "*" match the arguments by prefix, e.g. binpack.resources.* matches
+// the weights of the resources like binpack.resources.nvidia.com/gpu.
+type ArgumentsSchema map[string]ArgumentType
+
+// Validate checks that all the arguments are declared in the schema with the right type.
+func (s ArgumentsSchema) Validate(arguments map[string]interface{}) error {
+	keys := make([]string, 0, len(arguments))
+	for key := range arguments {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var errs []error
+	for _, key := range keys {
+		argType, found := s.lookup(key)
+		if !found {
+			errs = append(errs, fmt.Errorf("unknown argument %s", key))
+			continue
+		}
+		if err := argType.validate(arguments[key]); err != nil {
+			errs = append(errs, fmt.Errorf("argument %s: %v", key, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func (s ArgumentsSchema) lookup(key string) (ArgumentType, bool) {
+	if argType, found := s[key]; found {
+		return argType, true
+	}
+	// the longest prefix wins
+	var match string
+	for name := range s {
+		prefix, ok := strings.CutSuffix(name, "*")
+		if ok && strings.HasPrefix(key, prefix) && len(prefix) >= len(match) {
+			match = prefix
+		}
+	}
+	argType, found := s[match+"*"]
+	return argType, found
+}
+
+func (t ArgumentType) validate(value interface{}) error {
+	valid := false
+	switch t {
+	case IntArgument:
+		_, valid = value.(int)
+	case Float64Argument:
+		switch value.(type) {
+		case int, float64:
+			valid = true
+		}
+	case BoolArgument:
+		_, valid = value.(bool)
+	case StringArgument:
+		_, valid = value.(string)
+	case DurationArgument:
+		str, ok := value.(string)
+		if !ok {
+			break
+		}
+		if _, err := time.ParseDuration(str); err != nil {
+			return err
+		}
+		valid = true
+	case MapArgument:
+		switch value.(type) {
+		case map[interface{}]interface{}, map[string]interface{}:
+			valid = true
+		}
+	case ListArgument:
+		_, valid = value.([]interface{})
+	default:
+		valid = true
+	}
+	if !valid {
+		return fmt.Errorf("%v is not %s", value, t)
+	}
+	return nil
+}
+
+var (
+	pluginArgumentsSchemas = map[string]ArgumentsSchema{}
+	actionArgumentsSchemas = map[string]ArgumentsSchema{}
+)
+
+// RegisterPluginArgumentsSchema registers the schema validating the arguments of the plugin in the scheduler
+// configuration, the arguments of the plugins without a schema are not validated.
+func RegisterPluginArgumentsSchema(name string, schema ArgumentsSchema) {
+	pluginMutex.Lock()
+	defer pluginMutex.Unlock()
+
+	pluginArgumentsSchemas[name] = schema
+}
+
+// GetPluginArgumentsSchema gets the schema of the arguments of the plugin by name
+func GetPluginArgumentsSchema(name string) (ArgumentsSchema, bool) {
+	pluginMutex.RLock()
+	defer pluginMutex.RUnlock()
+
+	schema, found := pluginArgumentsSchemas[name]
+	return schema, found
+}
+
+// RegisterActionArgumentsSchema registers the schema validating the arguments of the action in the configurations
+// of the scheduler configuration, the arguments of the actions without a schema are not validated.
+func RegisterActionArgumentsSchema(name string, schema ArgumentsSchema) {
+	pluginMutex.Lock()
+	defer pluginMutex.Unlock()
+
+	actionArgumentsSchemas[name] = schema
+}
+
+// GetActionArgumentsSchema gets the schema of the arguments of the action by name
+func GetActionArgumentsSchema(name string) (ArgumentsSchema, bool) {
+	pluginMutex.RLock()
+	defer pluginMutex.RUnlock()
+
+	schema, found := actionArgumentsSchemas[name]
+	return schema, found
+}
@@ -137,6 +137,55 @@ func TestArgumentsGetFloat64(t *testing.T) {
 	}
 }
 
+func TestArgumentsGetString(t *testing.T) {
+	key1 := "stringkey"
+
+	cases := []struct {
+		name        string
+		arg         Arguments
+		key         string
+		baseValue   string
+		expectValue string
+	}{
+		{
+			name: "key not exist",
+			arg: Arguments{
+				"anotherKey": "value",
+			},
+			key:         key1,
+			baseValue:   "base",
+			expectValue: "base",
+		},
+		{
+			name: "key exist",
+			arg: Arguments{
+				key1: "value",
+			},
+			key:         key1,
+			baseValue:   "base",
+			expectValue: "value",
+		},
+		{
+			name: "value of key invalid",
+			arg: Arguments{
+				key1: 15,
+			},
+			key:         key1,
+			baseValue:   "base",
+			expectValue: "base",
+		},
+	}
+
+	for index, c := range cases {
+		baseValue := c.baseValue
+		c.arg.GetString(nil, c.key)
+		c.arg.GetString(&baseValue, c.key)
+		if baseValue != c.expectValue {
+			t.Errorf("index %d, case %s, value should be %v, but not %v", index, c.name, c.expectValue, baseValue)
+		}
+	}
+}
+
 func TestGetArgOfActionFromConf(t *testing.T) {
 	cases := []struct {
 		name              string
@@ -187,3 +236,78 @@ func TestGetArgOfActionFromConf(t *testing.T) {
 		}
 	}
 }
+
+func TestArgumentsSchemaValidate(t *testing.T) {
+	schema := ArgumentsSchema{
+		"weight":             IntArgument,
+		"factor":             Float64Argument,
+		"enabled":            BoolArgument,
+		"period":             DurationArgument,
+		"resources":          StringArgument,
+		"resources.*":        IntArgument,
+		"resources.custom.*": StringArgument,
+		"thresholds":         MapArgument,
+		"names":              ListArgument,
+	}
+
+	cases := []struct {
+		name      string
+		arguments map[string]interface{}
+		expectErr string
+	}{
+		{
+			name: "valid arguments",
+			arguments: map[string]interface{}{
+				"weight":                   2,
+				"factor":                   1,
+				"enabled":                  true,
+				"period":                   "5m",
+				"resources":                "nvidia.com/gpu",
+				"resources.nvidia.com/gpu": 3,
+				"resources.custom.foo":     "bar",
+				"thresholds":               map[interface{}]interface{}{"cpu": 80},
+				"names":                    []interface{}{"a", "b"},
+			},
+		},
+		{
+			name:      "unknown argument",
+			arguments: map[string]interface{}{"weigth": 2},
+			expectErr: "unknown argument weigth",
+		},
+		{
+			name:      "wrong type",
+			arguments: map[string]interface{}{"weight": "2"},
+			expectErr: "argument weight: 2 is not int",
+		},
+		{
+			name:      "the longest prefix wins",
+			arguments: map[string]interface{}{"resources.custom.foo": 1},
+			expectErr: "argument resources.custom.foo: 1 is not string",
+		},
+		{
+			name:      "invalid duration",
+			arguments: map[string]interface{}{"period": "5 minutes"},
+			expectErr: `argument period: time: unknown unit " minutes" in duration "5 minutes"`,
+		},
+		{
+			name:      "all the errors are reported",
+			arguments: map[string]interface{}{"enabled": "yes", "names": "a"},
+			expectErr: "[argument enabled: yes is not bool, argument names: a is not list]",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := schema.Validate(c.arguments)
+			if c.expectErr == "" {
+				if err != nil {
+					t.Errorf("expected no error, but got %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != c.expectErr {
+				t.Errorf("expected error %q, but got %v", c.expectErr, err)
+			}
+		})
+	}
+}
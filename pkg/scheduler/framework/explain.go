@@ -0,0 +1,204 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+)
+
+// ResourceFitPredicate is the name under which the idle resource check done by allocate
+// before calling the predicate plugins is reported in an explanation.
+const ResourceFitPredicate = "resource-fit"
+
+// TaskExplanation tells why a pending task can not be placed.
+type TaskExplanation struct {
+	Name string `json:"name"`
+	// PrePredicateFailures are the pre-predicate rejections: plugin name -> reason
+	PrePredicateFailures map[string]string `json:"prePredicateFailures,omitempty"`
+	// PredicateFailures are the predicate rejections: node name -> plugin name -> reason
+	PredicateFailures map[string]map[string]string `json:"predicateFailures,omitempty"`
+	// FeasibleNodes are the nodes passing all the predicates
+	FeasibleNodes []string `json:"feasibleNodes,omitempty"`
+	// Allocatable are the verdicts of whether the queue can hold the task: plugin name -> verdict
+	Allocatable map[string]bool `json:"allocatable,omitempty"`
+}
+
+// JobExplanation tells why a job (podgroup) is pending, by evaluating each plugin separately
+// instead of stopping at the first rejection as a scheduling session does.
+type JobExplanation struct {
+	Namespace    string      `json:"namespace"`
+	Name         string      `json:"name"`
+	Queue        api.QueueID `json:"queue"`
+	Phase        string      `json:"phase"`
+	MinAvailable int32       `json:"minAvailable"`
+	// Invalid are the rejections of job validation: plugin name -> reason
+	Invalid map[string]string `json:"invalid,omitempty"`
+	// Overused are the verdicts of whether the queue of job is overused: plugin name -> verdict
+	Overused map[string]bool `json:"overused,omitempty"`
+	// Enqueueable are the votes of whether the job can be enqueued: plugin name -> vote,
+	// 1 means permit, 0 means abstain and -1 means reject
+	Enqueueable map[string]int `json:"enqueueable,omitempty"`
+	// Ready are the verdicts of whether the job is ready to be dispatched: plugin name -> verdict
+	Ready map[string]bool `json:"ready,omitempty"`
+	// FitErrors is the summary of fit errors recorded by the last scheduling session
+	FitErrors string `json:"fitErrors,omitempty"`
+	// Tasks are the explanations of pending tasks
+	Tasks []*TaskExplanation `json:"tasks,omitempty"`
+}
+
+// Explain opens a dry-run session scheduling the jobs accepted by the filter against the snapshot of cache
+// and explains why the job with the given podgroup namespace and name is pending. The plugins are opened in
+// a dry run, so nothing is written back to the cluster and the state kept across sessions is unchanged.
+func Explain(cache cache.Cache, tiers []conf.Tier, configurations []conf.Configuration, filter JobFilter, namespace, name string) (*JobExplanation, error) {
+	ssn := openFilteredSession(cache, tiers, configurations, filter, true)
+	defer CloseSession(ssn)
+
+	var job *api.JobInfo
+	for _, j := range ssn.Jobs {
+		if j.Namespace == namespace && j.Name == name {
+			job = j
+			break
+		}
+	}
+	if job == nil {
+		return nil, fmt.Errorf("job <%s/%s> not found in scheduler cache", namespace, name)
+	}
+
+	return ssn.explainJob(job), nil
+}
+
+func (ssn *Session) explainJob(job *api.JobInfo) *JobExplanation {
+	je := &JobExplanation{
+		Namespace:    job.Namespace,
+		Name:         job.Name,
+		Queue:        job.Queue,
+		MinAvailable: job.MinAvailable,
+		Invalid:      map[string]string{},
+		Overused:     map[string]bool{},
+		Enqueueable:  map[string]int{},
+		Ready:        map[string]bool{},
+		FitErrors:    job.FitError(),
+	}
+	if job.PodGroup != nil {
+		je.Phase = string(job.PodGroup.Status.Phase)
+	}
+	queue := ssn.Queues[job.Queue]
+
+	for _, tier := range ssn.Tiers {
+		for _, plugin := range tier.Plugins {
+			if fn, found := ssn.jobValidFns[plugin.Name]; found {
+				if vr := fn(job); vr != nil && !vr.Pass {
+					je.Invalid[plugin.Name] = fmt.Sprintf("%s: %s", vr.Reason, vr.Message)
+				}
+			}
+			if fn, found := ssn.overusedFns[plugin.Name]; found && isEnabled(plugin.EnabledOverused) && queue != nil {
+				je.Overused[plugin.Name] = fn(queue)
+			}
+			if fn, found := ssn.jobEnqueueableFns[plugin.Name]; found && isEnabled(plugin.EnabledJobEnqueued) {
+				je.Enqueueable[plugin.Name] = fn(job)
+			}
+			if fn, found := ssn.jobReadyFns[plugin.Name]; found && isEnabled(plugin.EnabledJobReady) {
+				je.Ready[plugin.Name] = fn(job)
+			}
+		}
+	}
+
+	for _, task := range job.TaskStatusIndex[api.Pending] {
+		je.Tasks = append(je.Tasks, ssn.explainTask(queue, task))
+	}
+	sort.Slice(je.Tasks, func(i, j int) bool { return je.Tasks[i].Name < je.Tasks[j].Name })
+
+	return je
+}
+
+func (ssn *Session) explainTask(queue *api.QueueInfo, task *api.TaskInfo) *TaskExplanation {
+	te := &TaskExplanation{
+		Name:                 task.Name,
+		PrePredicateFailures: map[string]string{},
+		PredicateFailures:    map[string]map[string]string{},
+		Allocatable:          map[string]bool{},
+	}
+
+	for _, tier := range ssn.Tiers {
+		for _, plugin := range tier.Plugins {
+			if fn, found := ssn.allocatableFns[plugin.Name]; found && isEnabled(plugin.EnabledAllocatable) && queue != nil {
+				te.Allocatable[plugin.Name] = fn(queue, task)
+			}
+			if !isEnabled(plugin.EnabledPredicate) {
+				continue
+			}
+			if fn, found := ssn.prePredicateFns[plugin.Name]; found {
+				if err := fn(task); err != nil {
+					te.PrePredicateFailures[plugin.Name] = explainReason(err)
+				}
+			}
+		}
+	}
+
+	for _, node := range ssn.NodeList {
+		failures := map[string]string{}
+		if ok, resources := task.InitResreq.LessEqualWithResourcesName(node.FutureIdle(), api.Zero); !ok {
+			failures[ResourceFitPredicate] = api.WrapInsufficientResourceReason(resources)
+		}
+		for _, tier := range ssn.Tiers {
+			for _, plugin := range tier.Plugins {
+				if !isEnabled(plugin.EnabledPredicate) {
+					continue
+				}
+				fn, found := ssn.predicateFns[plugin.Name]
+				if !found {
+					continue
+				}
+				if err := fn(task, node); err != nil && isPredicateFailure(err) {
+					failures[plugin.Name] = explainReason(err)
+				}
+			}
+		}
+		if len(failures) == 0 {
+			te.FeasibleNodes = append(te.FeasibleNodes, node.Name)
+			continue
+		}
+		te.PredicateFailures[node.Name] = failures
+	}
+	sort.Strings(te.FeasibleNodes)
+
+	return te
+}
+
+// isPredicateFailure tells whether the predicate error rejects the node as PredicateForAllocateAction does
+func isPredicateFailure(err error) bool {
+	fitErr, ok := err.(*api.FitError)
+	if !ok {
+		return true
+	}
+	return fitErr.Status.ContainsUnschedulable() || fitErr.Status.ContainsUnschedulableAndUnresolvable() ||
+		fitErr.Status.ContainsErrorSkipOrWait()
+}
+
+// explainReason returns the reasons of fit error without the task and node already known by caller
+func explainReason(err error) string {
+	if fitErr, ok := err.(*api.FitError); ok {
+		return strings.Join(fitErr.Reasons(), ", ")
+	}
+	return err.Error()
+}
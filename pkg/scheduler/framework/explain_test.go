@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+
+	schedulingv1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+type explainPlugin struct {
+	// dryRun records whether the plugin is opened in a dry-run session
+	dryRun *bool
+}
+
+func (ep *explainPlugin) Name() string { return "explain" }
+
+func (ep *explainPlugin) OnSessionOpen(ssn *Session) {
+	*ep.dryRun = ssn.DryRun()
+	ssn.AddPredicateFn(ep.Name(), func(task *api.TaskInfo, node *api.NodeInfo) error {
+		if node.Name == "n2" {
+			return api.NewFitError(task, node, "node n2 is reserved")
+		}
+		return nil
+	})
+	ssn.AddOverusedFn(ep.Name(), func(obj interface{}) bool { return false })
+	ssn.AddAllocatableFn(ep.Name(), func(queue *api.QueueInfo, candidate *api.TaskInfo) bool { return true })
+	ssn.AddJobEnqueueableFn(ep.Name(), func(obj interface{}) int { return -1 })
+	ssn.AddJobReadyFn(ep.Name(), func(obj interface{}) bool { return false })
+}
+
+func (ep *explainPlugin) OnSessionClose(ssn *Session) {}
+
+func TestExplain(t *testing.T) {
+	dryRun := false
+	RegisterPluginBuilder("explain", func(Arguments) Plugin { return &explainPlugin{dryRun: &dryRun} })
+	defer CleanupPluginBuilders()
+
+	scherCache := cache.NewDefaultMockSchedulerCache("test-scheduler")
+	scherCache.AddOrUpdateNode(util.BuildNode("n1", api.BuildResourceList("1", "4Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil))
+	scherCache.AddOrUpdateNode(util.BuildNode("n2", api.BuildResourceList("4", "4Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil))
+	scherCache.AddOrUpdateNode(util.BuildNode("n3", api.BuildResourceList("4", "4Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil))
+	scherCache.AddQueueV1beta1(util.BuildQueue("c1", 1, nil))
+	scherCache.AddPodGroupV1beta1(util.BuildPodGroup("pg1", "c1", "c1", 1, nil, schedulingv1.PodGroupPending))
+	scherCache.AddPod(util.BuildPod("c1", "p1", "", v1.PodPending, api.BuildResourceList("2", "1G"), "pg1", nil, nil))
+
+	trueValue := true
+	tiers := []conf.Tier{{Plugins: []conf.PluginOption{{
+		Name:               "explain",
+		EnabledPredicate:   &trueValue,
+		EnabledOverused:    &trueValue,
+		EnabledAllocatable: &trueValue,
+		EnabledJobEnqueued: &trueValue,
+		EnabledJobReady:    &trueValue,
+	}}}}
+
+	if _, err := Explain(scherCache, tiers, nil, nil, "c1", "pg2"); err == nil {
+		t.Errorf("expect error when explaining unknown job")
+	}
+
+	// the job rejected by the filter is not scheduled by the session
+	if _, err := Explain(scherCache, tiers, nil, func(*api.JobInfo) bool { return false }, "c1", "pg1"); err == nil {
+		t.Errorf("expect error when explaining filtered job")
+	}
+
+	explanation, err := Explain(scherCache, tiers, nil, nil, "c1", "pg1")
+	if err != nil {
+		t.Fatalf("failed to explain job: %v", err)
+	}
+	assert.True(t, dryRun, "the plugins are opened in a dry-run session")
+	assert.Equal(t, api.QueueID("c1"), explanation.Queue)
+	assert.Equal(t, string(schedulingv1.PodGroupPending), explanation.Phase)
+	assert.Equal(t, map[string]bool{"explain": false}, explanation.Overused)
+	assert.Equal(t, map[string]int{"explain": -1}, explanation.Enqueueable)
+	assert.Equal(t, map[string]bool{"explain": false}, explanation.Ready)
+	if !assert.Len(t, explanation.Tasks, 1) {
+		return
+	}
+	task := explanation.Tasks[0]
+	assert.Equal(t, "p1", task.Name)
+	assert.Equal(t, map[string]bool{"explain": true}, task.Allocatable)
+	assert.Equal(t, []string{"n3"}, task.FeasibleNodes)
+	assert.Equal(t, map[string]map[string]string{
+		"n1": {ResourceFitPredicate: "Insufficient cpu"},
+		"n2": {"explain": "node n2 is reserved"},
+	}, task.PredicateFailures)
+}
@@ -28,7 +28,18 @@ import (
 
 // OpenSession start the session
 func OpenSession(cache cache.Cache, tiers []conf.Tier, configurations []conf.Configuration) *Session {
-	ssn := openSession(cache)
+	return OpenFilteredSession(cache, tiers, configurations, nil)
+}
+
+// OpenFilteredSession starts the session scheduling the jobs accepted by the filter only, the tasks of the other jobs
+// still occupy the nodes of the session. All the jobs are scheduled if the filter is nil.
+func OpenFilteredSession(cache cache.Cache, tiers []conf.Tier, configurations []conf.Configuration, filter JobFilter) *Session {
+	return openFilteredSession(cache, tiers, configurations, filter, false)
+}
+
+func openFilteredSession(cache cache.Cache, tiers []conf.Tier, configurations []conf.Configuration, filter JobFilter, dryRun bool) *Session {
+	ssn := openSession(cache, filter)
+	ssn.dryRun = dryRun
 	ssn.Tiers = tiers
 	ssn.Configurations = configurations
 	ssn.NodeMap = GenerateNodeMapAndSlice(ssn.Nodes)
@@ -43,10 +54,14 @@ func OpenSession(cache cache.Cache, tiers []conf.Tier, configurations []conf.Con
 				ssn.plugins[plugin.Name()] = plugin
 				onSessionOpenStart := time.Now()
 				plugin.OnSessionOpen(ssn)
-				metrics.UpdatePluginDuration(plugin.Name(), metrics.OnSessionOpen, metrics.Duration(onSessionOpenStart))
+				if !ssn.dryRun {
+					metrics.UpdatePluginDuration(plugin.Name(), metrics.OnSessionOpen, metrics.Duration(onSessionOpenStart))
+				}
 			}
 		}
 	}
+	// reserve nodes for the starving jobs before any action runs
+	ssn.ReservedNodes()
 	return ssn
 }
 
@@ -55,7 +70,13 @@ func CloseSession(ssn *Session) {
 	for _, plugin := range ssn.plugins {
 		onSessionCloseStart := time.Now()
 		plugin.OnSessionClose(ssn)
-		metrics.UpdatePluginDuration(plugin.Name(), metrics.OnSessionClose, metrics.Duration(onSessionCloseStart))
+		if !ssn.dryRun {
+			metrics.UpdatePluginDuration(plugin.Name(), metrics.OnSessionClose, metrics.Duration(onSessionCloseStart))
+		}
+	}
+
+	if ssn.trace != nil {
+		ssn.trace.EndTime = time.Now()
 	}
 
 	closeSession(ssn)
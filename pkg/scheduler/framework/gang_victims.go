@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// GangEvictionMode is how the preempt and reclaim actions evict the tasks of the victim gang jobs.
+type GangEvictionMode string
+
+const (
+	// GangEvictionNone evicts the victim tasks one by one, even if the victim job drops below its minAvailable
+	GangEvictionNone GangEvictionMode = "none"
+	// GangEvictionWholeJob evicts all the running tasks of the victim job if evicting the victim task drops the job
+	// below its minAvailable, so that no pods of a broken gang are left running
+	GangEvictionWholeJob GangEvictionMode = "wholeJob"
+	// GangEvictionAvoid skips the victim task if evicting it drops the victim job below its minAvailable, so that
+	// other victims are chosen instead
+	GangEvictionAvoid GangEvictionMode = "avoid"
+)
+
+// ParseGangEvictionMode parses the gang eviction mode, the mode is none if the value is empty or invalid.
+func ParseGangEvictionMode(value string) GangEvictionMode {
+	switch mode := GangEvictionMode(value); mode {
+	case GangEvictionNone, GangEvictionWholeJob, GangEvictionAvoid:
+		return mode
+	case "":
+		return GangEvictionNone
+	default:
+		klog.Warningf("Invalid gang eviction mode %q, the valid modes are %s, %s and %s, use %s instead",
+			value, GangEvictionNone, GangEvictionWholeJob, GangEvictionAvoid, GangEvictionNone)
+		return GangEvictionNone
+	}
+}
+
+// GangVictims returns the tasks to evict together with the victim for the preemptor under the gang eviction mode, which
+// are the victim itself if its job stays at or above minAvailable after the eviction, and nil if the victim should be
+// skipped. The tasks evicted before through the session are not ready any more, and evicted is the number of tasks of
+// each job evicted before in a dry run without changing their status, so the victims are checked cumulatively.
+// The gang is not taken into account if the victim is in the job of the preemptor, as the job preempts its own tasks.
+// The other tasks of the gang must pass the filter of the action and the victimsFn, i.e. Preemptable or Reclaimable
+// of the session, otherwise the victim is skipped.
+func (ssn *Session) GangVictims(
+	preemptor, victim *api.TaskInfo,
+	mode GangEvictionMode,
+	evicted map[api.JobID]int32,
+	filter func(*api.TaskInfo) bool,
+	victimsFn func(*api.TaskInfo, []*api.TaskInfo) []*api.TaskInfo,
+) []*api.TaskInfo {
+	job, found := ssn.Jobs[victim.Job]
+	if mode == GangEvictionNone || !found || victim.Job == preemptor.Job ||
+		job.MinAvailable <= 1 || job.ReadyTaskNum()-evicted[victim.Job]-1 >= job.MinAvailable {
+		return []*api.TaskInfo{victim}
+	}
+
+	if mode == GangEvictionAvoid {
+		klog.V(4).Infof("Skip victim <%s/%s> because evicting it drops job <%s/%s> below minAvailable %d",
+			victim.Namespace, victim.Name, job.Namespace, job.Name, job.MinAvailable)
+		return nil
+	}
+
+	var siblings []*api.TaskInfo
+	for _, status := range []api.TaskStatus{api.Bound, api.Running} {
+		for _, task := range job.TaskStatusIndex[status] {
+			if task.UID == victim.UID {
+				continue
+			}
+			if filter != nil && !filter(task) {
+				klog.V(4).Infof("Skip victim <%s/%s> because task <%s/%s> of its gang job is not a candidate victim",
+					victim.Namespace, victim.Name, task.Namespace, task.Name)
+				return nil
+			}
+			siblings = append(siblings, task.Clone())
+		}
+	}
+	if len(siblings) > 0 && len(victimsFn(preemptor, siblings)) != len(siblings) {
+		klog.V(4).Infof("Skip victim <%s/%s> because the plugins do not allow evicting all the tasks of its gang job",
+			victim.Namespace, victim.Name)
+		return nil
+	}
+	victims := append([]*api.TaskInfo{victim}, siblings...)
+	klog.V(4).Infof("Evict all %d running tasks of job <%s/%s> together with victim <%s/%s>",
+		len(victims), job.Namespace, job.Name, victim.Namespace, victim.Name)
+	return victims
+}
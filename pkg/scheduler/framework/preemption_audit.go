@@ -0,0 +1,78 @@
+/*
+Copyright 2026 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/metrics"
+)
+
+// preemptionEventReasons are the reasons of the events recorded on the preemptor and the victim PodGroups by action.
+var preemptionEventReasons = map[string]struct{ preemptor, victim string }{
+	"preempt": {preemptor: "Preempt", victim: "Preempted"},
+	"reclaim": {preemptor: "Reclaim", victim: "Reclaimed"},
+}
+
+// RecordPreemption records the per queue and namespace metrics of the victims evicted for the preemptor by the action,
+// and an event on the PodGroups of both the preemptor and each victim job naming the other side.
+func (ssn *Session) RecordPreemption(action string, preemptor *api.TaskInfo, victims []*api.TaskInfo) {
+	preemptorJob, found := ssn.Jobs[preemptor.Job]
+	if !found || len(victims) == 0 {
+		return
+	}
+	reasons, found := preemptionEventReasons[action]
+	if !found {
+		reasons.preemptor, reasons.victim = action, action
+	}
+
+	metrics.RegisterQueuePreemption(action, string(preemptorJob.Queue), preemptor.Namespace)
+
+	var victimJobs []api.JobID
+	victimTasks := make(map[api.JobID][]*api.TaskInfo)
+	for _, victim := range victims {
+		victimJob, found := ssn.Jobs[victim.Job]
+		if !found {
+			continue
+		}
+		metrics.RegisterQueuePreemptionVictim(action, string(preemptorJob.Queue), preemptor.Namespace,
+			string(victimJob.Queue), victim.Namespace, victim.Resreq.MilliCPU, victim.Resreq.Memory, victim.Resreq.ScalarResources)
+		if _, found := victimTasks[victim.Job]; !found {
+			victimJobs = append(victimJobs, victim.Job)
+		}
+		victimTasks[victim.Job] = append(victimTasks[victim.Job], victim)
+	}
+
+	for _, jobID := range victimJobs {
+		victimJob := ssn.Jobs[jobID]
+		freed := api.EmptyResource()
+		for _, victim := range victimTasks[jobID] {
+			freed.Add(victim.Resreq)
+		}
+		ssn.RecordPodGroupEvent(preemptorJob.PodGroup, v1.EventTypeNormal, reasons.preemptor,
+			fmt.Sprintf("Task %s/%s of queue %s evicted %d tasks of job %s/%s in queue %s by %s, freed <%v>",
+				preemptor.Namespace, preemptor.Name, preemptorJob.Queue, len(victimTasks[jobID]),
+				victimJob.Namespace, victimJob.Name, victimJob.Queue, action, freed))
+		ssn.RecordPodGroupEvent(victimJob.PodGroup, v1.EventTypeWarning, reasons.victim,
+			fmt.Sprintf("%d tasks of queue %s evicted by %s for task %s/%s of job %s/%s in queue %s, freed <%v>",
+				len(victimTasks[jobID]), victimJob.Queue, action, preemptor.Namespace, preemptor.Name,
+				preemptorJob.Namespace, preemptorJob.Name, preemptorJob.Queue, freed))
+	}
+}
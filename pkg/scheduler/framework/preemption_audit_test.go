@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	schedulingv1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestRecordPreemption(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	scherCache := cache.NewCustomMockSchedulerCache("test-scheduler", nil, util.NewFakeEvictor(10), nil, nil, nil, recorder)
+	scherCache.AddOrUpdateNode(util.BuildNode("n1", api.BuildResourceList("2", "4Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil))
+	scherCache.AddQueueV1beta1(util.BuildQueue("q1", 1, nil))
+	scherCache.AddQueueV1beta1(util.BuildQueue("q2", 1, nil))
+	scherCache.AddPodGroupV1beta1(util.BuildPodGroup("pg1", "ns1", "q1", 1, nil, schedulingv1.PodGroupInqueue))
+	scherCache.AddPodGroupV1beta1(util.BuildPodGroup("pg2", "ns2", "q2", 1, nil, schedulingv1.PodGroupRunning))
+	scherCache.AddPod(util.BuildPod("ns1", "preemptor", "", v1.PodPending, api.BuildResourceList("2", "2G"), "pg1", nil, nil))
+	scherCache.AddPod(util.BuildPod("ns2", "victim1", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg2", nil, nil))
+	scherCache.AddPod(util.BuildPod("ns2", "victim2", "n1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg2", nil, nil))
+
+	ssn := OpenSession(scherCache, nil, nil)
+	defer CloseSession(ssn)
+
+	var preemptor *api.TaskInfo
+	var victims []*api.TaskInfo
+	for _, job := range ssn.Jobs {
+		for _, task := range job.Tasks {
+			if task.Name == "preemptor" {
+				preemptor = task
+			} else {
+				victims = append(victims, task)
+			}
+		}
+	}
+
+	evict := func(stmt *Statement) {
+		for _, victim := range victims {
+			if err := stmt.Evict(victim, "preempt"); err != nil {
+				t.Fatalf("failed to evict task %s: %v", victim.Name, err)
+			}
+		}
+		stmt.RecordPreemption("preempt", preemptor, victims)
+	}
+
+	// nothing is recorded for the discarded evictions
+	stmt := NewStatement(ssn)
+	evict(stmt)
+	stmt.Discard()
+	assert.Empty(t, recorder.Events)
+
+	stmt = NewStatement(ssn)
+	evict(stmt)
+	stmt.Commit()
+	// the cache records an Evict event on the victim PodGroup for each evicted task too
+	var events []string
+	for len(recorder.Events) > 0 {
+		events = append(events, <-recorder.Events)
+	}
+	assert.Len(t, events, 4)
+	assert.Contains(t, events, "Normal Preempt Task ns1/preemptor of queue q1 evicted 2 tasks of job ns2/pg2 in queue q2 by preempt, "+
+		"freed <cpu 2000.00, memory 2000000000.00, pods 2.00>")
+	assert.Contains(t, events, "Warning Preempted 2 tasks of queue q2 evicted by preempt for task ns1/preemptor of job ns1/pg1 in queue q1, "+
+		"freed <cpu 2000.00, memory 2000000000.00, pods 2.00>")
+}
@@ -107,9 +107,19 @@ type Session struct {
 	reservedNodesFns  map[string]api.ReservedNodesFn
 	victimTasksFns    map[string][]api.VictimTasksFn
 	jobStarvingFns    map[string]api.ValidateFn
+
+	// trace records the scheduling decisions of the session, it is nil if trace is not enabled
+	trace *SessionTrace
+	// dryRun means the session changes nothing out of itself, see DryRun
+	dryRun bool
+	// filteredJobs are the jobs not scheduled by the session, they are only counted in the allocated resources of queues
+	filteredJobs []*api.JobInfo
 }
 
-func openSession(cache cache.Cache) *Session {
+// JobFilter decides whether the job is scheduled by the session.
+type JobFilter func(job *api.JobInfo) bool
+
+func openSession(cache cache.Cache, filter JobFilter) *Session {
 	ssn := &Session{
 		UID:             uuid.NewUUID(),
 		kubeClient:      cache.Client(),
@@ -158,9 +168,15 @@ func openSession(cache cache.Cache) *Session {
 		jobStarvingFns:      map[string]api.ValidateFn{},
 	}
 
-	snapshot := cache.Snapshot()
+	snapshot := cache.SessionSnapshot()
 
 	ssn.Jobs = snapshot.Jobs
+	for uid, job := range ssn.Jobs {
+		if filter != nil && !filter(job) {
+			ssn.filteredJobs = append(ssn.filteredJobs, job)
+			delete(ssn.Jobs, uid)
+		}
+	}
 	for _, job := range ssn.Jobs {
 		if job.PodGroup != nil {
 			ssn.podGroupStatus[job.UID] = *job.PodGroup.Status.DeepCopy()
@@ -191,7 +207,7 @@ func updateQueueStatus(ssn *Session) {
 	for queueID := range ssn.Queues {
 		allocatedResources[queueID] = &api.Resource{}
 	}
-	for _, job := range ssn.Jobs {
+	for _, job := range ssn.AllJobs() {
 		for status, tasks := range job.TaskStatusIndex {
 			if api.AllocatedStatus(status) {
 				for _, task := range tasks {
@@ -282,10 +298,12 @@ func updateRootQueueResources(ssn *Session, allocated v1.ResourceList) {
 }
 
 func closeSession(ssn *Session) {
-	ju := newJobUpdater(ssn)
-	ju.UpdateAll()
+	if !ssn.dryRun {
+		ju := newJobUpdater(ssn)
+		ju.UpdateAll()
 
-	updateQueueStatus(ssn)
+		updateQueueStatus(ssn)
+	}
 
 	ssn.Jobs = nil
 	ssn.Nodes = nil
@@ -454,6 +472,7 @@ func (ssn *Session) Pipeline(task *api.TaskInfo, hostname string) error {
 			hostname, ssn.UID)
 		return fmt.Errorf("failed to find node %s", hostname)
 	}
+	ssn.traceEvent(task, hostname, "pipelined to node %s", hostname)
 
 	for _, eh := range ssn.eventHandlers {
 		if eh.AllocateFunc != nil {
@@ -515,6 +534,7 @@ func (ssn *Session) Allocate(task *api.TaskInfo, nodeInfo *api.NodeInfo) (err er
 			hostname, ssn.UID)
 		return fmt.Errorf("failed to find node %s", hostname)
 	}
+	ssn.traceEvent(task, hostname, "allocated to node %s", hostname)
 
 	// Callbacks
 	for _, eh := range ssn.eventHandlers {
@@ -590,6 +610,7 @@ func (ssn *Session) Evict(reclaimee *api.TaskInfo, reason string) error {
 			return err
 		}
 	}
+	ssn.traceEvent(reclaimee, "", "evicted from node %s for %s", reclaimee.NodeName, reason)
 
 	for _, eh := range ssn.eventHandlers {
 		if eh.DeallocateFunc != nil {
@@ -642,6 +663,28 @@ func (ssn *Session) UpdateSchedulerNumaInfo(AllocatedSets map[string]api.ResNuma
 	ssn.cache.UpdateSchedulerNumaInfo(AllocatedSets)
 }
 
+// AllJobs returns the jobs of the session together with the jobs not scheduled by the session
+func (ssn *Session) AllJobs() []*api.JobInfo {
+	jobs := make([]*api.JobInfo, 0, len(ssn.Jobs)+len(ssn.filteredJobs))
+	for _, job := range ssn.Jobs {
+		jobs = append(jobs, job)
+	}
+	return append(jobs, ssn.filteredJobs...)
+}
+
+// DryRun tells whether the session is a dry run, e.g. explaining a job. The status of jobs and queues are not
+// written back when a dry-run session is closed, and the plugins should neither change the state kept across
+// sessions nor write to the cluster or report events and metrics.
+func (ssn *Session) DryRun() bool {
+	return ssn.dryRun
+}
+
+// PluginState returns the state the plugin keeps across the sessions of the same scheduler cache,
+// the state is created by newState in the first session.
+func (ssn *Session) PluginState(name string, newState func() interface{}) interface{} {
+	return ssn.cache.PluginState(name, newState)
+}
+
 // KubeClient returns the kubernetes client
 func (ssn Session) KubeClient() kubernetes.Interface {
 	return ssn.kubeClient
@@ -676,6 +719,20 @@ func (ssn Session) RecordPodGroupEvent(podGroup *api.PodGroup, eventType, reason
 	ssn.recorder.Eventf(pg, eventType, reason, msg)
 }
 
+// RecordQueueEvent records queue events
+func (ssn Session) RecordQueueEvent(queue *api.QueueInfo, eventType, reason, msg string) {
+	if queue == nil || queue.Queue == nil {
+		return
+	}
+
+	q := &vcv1beta1.Queue{}
+	if err := schedulingscheme.Scheme.Convert(queue.Queue, q, nil); err != nil {
+		klog.Errorf("Error while converting Queue to v1beta1.Queue with error: %v", err)
+		return
+	}
+	ssn.recorder.Eventf(q, eventType, reason, msg)
+}
+
 // String return nodes and jobs information in the session
 func (ssn Session) String() string {
 	msg := fmt.Sprintf("Session %v: \n", ssn.UID)
@@ -658,6 +658,7 @@ func (ssn *Session) PredicateFn(task *api.TaskInfo, node *api.NodeInfo) error {
 			}
 			err := pfn(task, node)
 			if err != nil {
+				ssn.tracePredicateFailure(task, node, plugin.Name, err)
 				return err
 			}
 		}
@@ -723,6 +724,7 @@ func (ssn *Session) NodeOrderFn(task *api.TaskInfo, node *api.NodeInfo) (float64
 				return 0, err
 			}
 			priorityScore += score
+			ssn.traceNodeScore(task, node.Name, plugin.Name, score)
 		}
 	}
 	return priorityScore, nil
@@ -746,6 +748,7 @@ func (ssn *Session) BatchNodeOrderFn(task *api.TaskInfo, nodes []*api.NodeInfo)
 			}
 			for nodeName, score := range score {
 				priorityScore[nodeName] += score
+				ssn.traceNodeScore(task, nodeName, plugin.Name, score)
 			}
 		}
 	}
@@ -771,6 +774,7 @@ func (ssn *Session) NodeOrderMapFn(task *api.TaskInfo, node *api.NodeInfo) (map[
 					return nodeScoreMap, priorityScore, err
 				}
 				priorityScore += score
+				ssn.traceNodeScore(task, node.Name, plugin.Name, score)
 			}
 			if pfn, found := ssn.nodeMapFns[plugin.Name]; found {
 				score, err := pfn(task, node)
@@ -801,6 +805,7 @@ func (ssn *Session) NodeOrderReduceFn(task *api.TaskInfo, pluginNodeScoreMap map
 			}
 			for _, hp := range pluginNodeScoreMap[plugin.Name] {
 				nodeScoreMap[hp.Name] += float64(hp.Score)
+				ssn.traceNodeScore(task, hp.Name, plugin.Name, float64(hp.Score))
 			}
 		}
 	}
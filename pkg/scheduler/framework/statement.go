@@ -37,16 +37,38 @@ const (
 	Allocate
 )
 
+// String returns the name of operation
+func (op Operation) String() string {
+	switch op {
+	case Evict:
+		return "evict"
+	case Pipeline:
+		return "pipeline"
+	case Allocate:
+		return "allocate"
+	default:
+		return fmt.Sprintf("operation(%d)", op)
+	}
+}
+
 type operation struct {
 	name   Operation
 	task   *api.TaskInfo
 	reason string
 }
 
+// preemption is the victims evicted by the action for the preemptor
+type preemption struct {
+	action    string
+	preemptor *api.TaskInfo
+	victims   []*api.TaskInfo
+}
+
 // Statement structure
 type Statement struct {
-	operations []operation
-	ssn        *Session
+	operations  []operation
+	preemptions []preemption
+	ssn         *Session
 }
 
 // NewStatement returns new statement object
@@ -92,6 +114,7 @@ func (s *Statement) Evict(reclaimee *api.TaskInfo, reason string) error {
 		task:   reclaimee,
 		reason: reason,
 	})
+	s.ssn.traceEvent(reclaimee, "", "evicted from node %s for %s", reclaimee.NodeName, reason)
 
 	return nil
 }
@@ -198,6 +221,7 @@ func (s *Statement) Pipeline(task *api.TaskInfo, hostname string, evictionOccurr
 			name: Pipeline,
 			task: task,
 		})
+		s.ssn.traceEvent(task, hostname, "pipelined to node %s", hostname)
 	}
 
 	return nil
@@ -323,6 +347,7 @@ func (s *Statement) Allocate(task *api.TaskInfo, nodeInfo *api.NodeInfo) (err er
 			name: Allocate,
 			task: task,
 		})
+		s.ssn.traceEvent(task, hostname, "allocated to node %s", hostname)
 	}
 
 	return nil
@@ -390,12 +415,23 @@ func (s *Statement) unallocate(task *api.TaskInfo) error {
 	return nil
 }
 
+// RecordPreemption records the victims evicted in the statement for the preemptor by the action,
+// which are reported by the session once the statement is committed.
+func (s *Statement) RecordPreemption(action string, preemptor *api.TaskInfo, victims []*api.TaskInfo) {
+	if len(victims) == 0 {
+		return
+	}
+	s.preemptions = append(s.preemptions, preemption{action: action, preemptor: preemptor, victims: victims})
+}
+
 // Discard operation for evict, pipeline and allocate
 func (s *Statement) Discard() {
 	klog.V(3).Info("Discarding operations ...")
+	s.preemptions = nil
 	for i := len(s.operations) - 1; i >= 0; i-- {
 		op := s.operations[i]
 		op.task.GenerateLastTxContext()
+		s.ssn.traceEvent(op.task, "", "%s on node %s discarded", op.name, op.task.NodeName)
 		switch op.name {
 		case Evict:
 			err := s.unevict(op.task)
@@ -419,12 +455,14 @@ func (s *Statement) Discard() {
 // Commit operation for evict and pipeline
 func (s *Statement) Commit() {
 	klog.V(3).Info("Committing operations ...")
+	failedEvictions := make(map[api.TaskID]bool)
 	for _, op := range s.operations {
 		op.task.ClearLastTxContext()
 		switch op.name {
 		case Evict:
 			err := s.evict(op.task, op.reason)
 			if err != nil {
+				failedEvictions[op.task.UID] = true
 				klog.Errorf("Failed to evict task: %s", err.Error())
 			}
 		case Pipeline:
@@ -439,4 +477,15 @@ func (s *Statement) Commit() {
 			}
 		}
 	}
+
+	for _, p := range s.preemptions {
+		var victims []*api.TaskInfo
+		for _, victim := range p.victims {
+			if !failedEvictions[victim.UID] {
+				victims = append(victims, victim)
+			}
+		}
+		s.ssn.RecordPreemption(p.action, p.preemptor, victims)
+	}
+	s.preemptions = nil
 }
@@ -0,0 +1,210 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// TaskTrace records the scheduling decisions made for a task in a session.
+type TaskTrace struct {
+	Namespace string      `json:"namespace"`
+	Name      string      `json:"name"`
+	Job       api.JobID   `json:"job"`
+	Queue     api.QueueID `json:"queue"`
+	// PredicateFailures are the predicate rejections: node name -> plugin name -> reason
+	PredicateFailures map[string]map[string]string `json:"predicateFailures,omitempty"`
+	// NodeScores are the node order scores: node name -> plugin name -> score
+	NodeScores map[string]map[string]float64 `json:"nodeScores,omitempty"`
+	// Node is the node which the task is allocated or pipelined to at last
+	Node string `json:"node,omitempty"`
+	// Events are the operations applied to the task in order, e.g. allocate, pipeline, evict and discard
+	Events []string `json:"events,omitempty"`
+}
+
+// SessionTrace records the scheduling decisions made in a session.
+type SessionTrace struct {
+	sync.Mutex
+
+	UID       types.UID `json:"uid"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+	// QueueOrder, JobOrder and TaskOrder are the plugins whose order functions are applied in the session
+	QueueOrder []string `json:"queueOrder,omitempty"`
+	JobOrder   []string `json:"jobOrder,omitempty"`
+	TaskOrder  []string `json:"taskOrder,omitempty"`
+	// Tasks are the traces of tasks in the order they were first considered
+	Tasks []*TaskTrace `json:"tasks"`
+
+	tasks map[api.TaskID]*TaskTrace
+}
+
+// EnableTrace makes the session record a scheduling trace for each task it considers.
+// It should be called after the session is opened, so that the order plugins are known.
+func (ssn *Session) EnableTrace() {
+	trace := &SessionTrace{
+		UID:       ssn.UID,
+		StartTime: time.Now(),
+		tasks:     map[api.TaskID]*TaskTrace{},
+	}
+	for _, tier := range ssn.Tiers {
+		for _, plugin := range tier.Plugins {
+			if _, found := ssn.queueOrderFns[plugin.Name]; found && isEnabled(plugin.EnabledQueueOrder) {
+				trace.QueueOrder = append(trace.QueueOrder, plugin.Name)
+			}
+			if _, found := ssn.jobOrderFns[plugin.Name]; found && isEnabled(plugin.EnabledJobOrder) {
+				trace.JobOrder = append(trace.JobOrder, plugin.Name)
+			}
+			if _, found := ssn.taskOrderFns[plugin.Name]; found && isEnabled(plugin.EnabledTaskOrder) {
+				trace.TaskOrder = append(trace.TaskOrder, plugin.Name)
+			}
+		}
+	}
+	ssn.trace = trace
+}
+
+// Trace returns the trace recorded by the session, it is nil if trace is not enabled.
+func (ssn *Session) Trace() *SessionTrace {
+	return ssn.trace
+}
+
+// taskTrace returns the trace of task, caller should hold the lock of trace
+func (ssn *Session) taskTrace(task *api.TaskInfo) *TaskTrace {
+	tt, found := ssn.trace.tasks[task.UID]
+	if !found {
+		tt = &TaskTrace{
+			Namespace: task.Namespace,
+			Name:      task.Name,
+			Job:       task.Job,
+		}
+		if job, found := ssn.Jobs[task.Job]; found {
+			tt.Queue = job.Queue
+		}
+		ssn.trace.tasks[task.UID] = tt
+		ssn.trace.Tasks = append(ssn.trace.Tasks, tt)
+	}
+	return tt
+}
+
+func (ssn *Session) tracePredicateFailure(task *api.TaskInfo, node *api.NodeInfo, plugin string, err error) {
+	if ssn.trace == nil {
+		return
+	}
+	ssn.trace.Lock()
+	defer ssn.trace.Unlock()
+
+	tt := ssn.taskTrace(task)
+	if tt.PredicateFailures == nil {
+		tt.PredicateFailures = map[string]map[string]string{}
+	}
+	if tt.PredicateFailures[node.Name] == nil {
+		tt.PredicateFailures[node.Name] = map[string]string{}
+	}
+	tt.PredicateFailures[node.Name][plugin] = err.Error()
+}
+
+func (ssn *Session) traceNodeScore(task *api.TaskInfo, node string, plugin string, score float64) {
+	if ssn.trace == nil {
+		return
+	}
+	ssn.trace.Lock()
+	defer ssn.trace.Unlock()
+
+	tt := ssn.taskTrace(task)
+	if tt.NodeScores == nil {
+		tt.NodeScores = map[string]map[string]float64{}
+	}
+	if tt.NodeScores[node] == nil {
+		tt.NodeScores[node] = map[string]float64{}
+	}
+	tt.NodeScores[node][plugin] = score
+}
+
+func (ssn *Session) traceEvent(task *api.TaskInfo, node string, format string, args ...interface{}) {
+	if ssn.trace == nil {
+		return
+	}
+	ssn.trace.Lock()
+	defer ssn.trace.Unlock()
+
+	tt := ssn.taskTrace(task)
+	if node != "" {
+		tt.Node = node
+	}
+	tt.Events = append(tt.Events, fmt.Sprintf(format, args...))
+}
+
+// TraceBuffer keeps the traces of the latest sessions in a ring buffer.
+type TraceBuffer struct {
+	sync.RWMutex
+	traces []*SessionTrace
+	next   int
+	full   bool
+}
+
+// NewTraceBuffer returns a TraceBuffer keeping at most size session traces.
+func NewTraceBuffer(size int) *TraceBuffer {
+	if size <= 0 {
+		size = 1
+	}
+	return &TraceBuffer{
+		traces: make([]*SessionTrace, size),
+	}
+}
+
+// Add puts the trace into buffer, the oldest trace is dropped if the buffer is full.
+func (tb *TraceBuffer) Add(trace *SessionTrace) {
+	if trace == nil {
+		return
+	}
+	tb.Lock()
+	defer tb.Unlock()
+
+	tb.traces[tb.next] = trace
+	tb.next = (tb.next + 1) % len(tb.traces)
+	if tb.next == 0 {
+		tb.full = true
+	}
+}
+
+// List returns the traces in buffer from the oldest to the latest.
+func (tb *TraceBuffer) List() []*SessionTrace {
+	tb.RLock()
+	defer tb.RUnlock()
+
+	if !tb.full {
+		return append([]*SessionTrace{}, tb.traces[:tb.next]...)
+	}
+	return append(append([]*SessionTrace{}, tb.traces[tb.next:]...), tb.traces[:tb.next]...)
+}
+
+// ServeHTTP writes the traces in buffer as json.
+func (tb *TraceBuffer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(tb.List()); err != nil {
+		klog.Errorf("Failed to encode scheduling traces: %v", err)
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	schedulingv1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestSessionTrace(t *testing.T) {
+	scherCache := cache.NewDefaultMockSchedulerCache("test-scheduler")
+	scherCache.AddOrUpdateNode(util.BuildNode("n1", api.BuildResourceList("2", "4Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil))
+	scherCache.AddOrUpdateNode(util.BuildNode("n2", api.BuildResourceList("2", "4Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil))
+	scherCache.AddQueueV1beta1(util.BuildQueue("c1", 1, nil))
+	scherCache.AddPodGroupV1beta1(util.BuildPodGroup("pg1", "c1", "c1", 1, nil, schedulingv1.PodGroupInqueue))
+	scherCache.AddPod(util.BuildPod("c1", "p1", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg1", nil, nil))
+
+	trueValue := true
+	tiers := []conf.Tier{{Plugins: []conf.PluginOption{{
+		Name:             "fake",
+		EnabledPredicate: &trueValue,
+		EnabledNodeOrder: &trueValue,
+	}}}}
+	ssn := OpenSession(scherCache, tiers, nil)
+	defer CloseSession(ssn)
+
+	ssn.AddPredicateFn("fake", func(task *api.TaskInfo, node *api.NodeInfo) error {
+		if node.Name == "n2" {
+			return fmt.Errorf("node n2 is not allowed")
+		}
+		return nil
+	})
+	ssn.AddNodeOrderFn("fake", func(task *api.TaskInfo, node *api.NodeInfo) (float64, error) {
+		return 10, nil
+	})
+
+	var task *api.TaskInfo
+	for _, job := range ssn.Jobs {
+		for _, t := range job.Tasks {
+			task = t
+		}
+	}
+
+	// nothing is recorded before trace is enabled
+	ssn.PredicateFn(task, ssn.Nodes["n2"])
+	assert.Nil(t, ssn.Trace())
+
+	ssn.EnableTrace()
+	for _, node := range []string{"n1", "n2"} {
+		if err := ssn.PredicateFn(task, ssn.Nodes[node]); err == nil {
+			ssn.NodeOrderFn(task, ssn.Nodes[node])
+		}
+	}
+	stmt := NewStatement(ssn)
+	if err := stmt.Allocate(task, ssn.Nodes["n1"]); err != nil {
+		t.Fatalf("failed to allocate task: %v", err)
+	}
+	stmt.Discard()
+
+	trace := ssn.Trace()
+	if !assert.Len(t, trace.Tasks, 1) {
+		return
+	}
+	tt := trace.Tasks[0]
+	assert.Equal(t, "p1", tt.Name)
+	assert.Equal(t, api.QueueID("c1"), tt.Queue)
+	assert.Equal(t, map[string]map[string]string{"n2": {"fake": "node n2 is not allowed"}}, tt.PredicateFailures)
+	assert.Equal(t, map[string]map[string]float64{"n1": {"fake": 10}}, tt.NodeScores)
+	assert.Equal(t, "n1", tt.Node)
+	assert.Equal(t, []string{"allocated to node n1", "allocate on node n1 discarded"}, tt.Events)
+}
+
+func TestTraceBuffer(t *testing.T) {
+	tests := []struct {
+		name   string
+		size   int
+		traces int
+		want   []types.UID
+	}{
+		{
+			name:   "buffer is not full",
+			size:   3,
+			traces: 2,
+			want:   []types.UID{"0", "1"},
+		},
+		{
+			name:   "oldest traces are dropped when buffer is full",
+			size:   3,
+			traces: 5,
+			want:   []types.UID{"2", "3", "4"},
+		},
+		{
+			name:   "invalid size keeps the latest trace",
+			size:   0,
+			traces: 2,
+			want:   []types.UID{"1"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tb := NewTraceBuffer(test.size)
+			for i := 0; i < test.traces; i++ {
+				tb.Add(&SessionTrace{UID: types.UID(fmt.Sprint(i))})
+			}
+			tb.Add(nil)
+
+			got := []types.UID{}
+			for _, trace := range tb.List() {
+				got = append(got, trace.UID)
+			}
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
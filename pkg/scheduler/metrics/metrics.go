@@ -146,6 +146,22 @@ var (
 			Help:      "Number of jobs could not be scheduled",
 		},
 	)
+
+	schedulerConfLoads = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "scheduler_conf_loads_total",
+			Help:      "Number of loads of the scheduler configuration, result is Success or Invalid",
+		}, []string{"result"},
+	)
+
+	schedulerConfValid = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "scheduler_conf_valid",
+			Help:      "Whether the latest loaded scheduler configuration is valid, the previous configuration is kept if not",
+		},
+	)
 )
 
 // UpdatePluginDuration updates latency for every plugin
@@ -209,6 +225,17 @@ func UpdateUnscheduleJobCount(jobCount int) {
 	unscheduleJobCount.Set(float64(jobCount))
 }
 
+// RegisterSchedulerConfLoad records the load of the scheduler configuration and whether it is valid
+func RegisterSchedulerConfLoad(valid bool) {
+	if valid {
+		schedulerConfLoads.WithLabelValues("Success").Inc()
+		schedulerConfValid.Set(1)
+		return
+	}
+	schedulerConfLoads.WithLabelValues("Invalid").Inc()
+	schedulerConfValid.Set(0)
+}
+
 // DurationInMicroseconds gets the time in microseconds.
 func DurationInMicroseconds(duration time.Duration) float64 {
 	return float64(duration.Nanoseconds()) / float64(time.Microsecond.Nanoseconds())
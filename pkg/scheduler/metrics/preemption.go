@@ -0,0 +1,95 @@
+/*
+Copyright 2026 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto" // auto-registry collectors in default registry
+	v1 "k8s.io/api/core/v1"
+)
+
+var preemptionVictimLabels = []string{"action", "preemptor_queue", "preemptor_namespace", "victim_queue", "victim_namespace"}
+
+var (
+	queuePreemptions = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "queue_preemptions_total",
+			Help:      "Total preemptions evicting victims for the preemptors of one queue and namespace",
+		}, []string{"action", "preemptor_queue", "preemptor_namespace"},
+	)
+
+	queuePreemptionVictims = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "queue_preemption_victims_total",
+			Help:      "Total victims evicted from one queue and namespace for the preemptors of another",
+		}, preemptionVictimLabels,
+	)
+
+	queuePreemptionFreedMilliCPU = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "queue_preemption_freed_milli_cpu_total",
+			Help:      "Total CPU freed by the victims evicted from one queue and namespace for the preemptors of another",
+		}, preemptionVictimLabels,
+	)
+
+	queuePreemptionFreedMemory = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "queue_preemption_freed_memory_bytes_total",
+			Help:      "Total memory freed by the victims evicted from one queue and namespace for the preemptors of another",
+		}, preemptionVictimLabels,
+	)
+
+	queuePreemptionFreedScalarResource = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "queue_preemption_freed_scalar_resources_total",
+			Help:      "Total scalar resources freed by the victims evicted from one queue and namespace for the preemptors of another",
+		}, append(append([]string{}, preemptionVictimLabels...), "resource"),
+	)
+)
+
+// RegisterQueuePreemption records a preemption evicting victims for a preemptor of the queue and namespace by the action
+func RegisterQueuePreemption(action, preemptorQueue, preemptorNamespace string) {
+	queuePreemptions.WithLabelValues(action, preemptorQueue, preemptorNamespace).Inc()
+}
+
+// RegisterQueuePreemptionVictim records a victim of the queue and namespace evicted for a preemptor of the queue and namespace by the action
+func RegisterQueuePreemptionVictim(action, preemptorQueue, preemptorNamespace, victimQueue, victimNamespace string,
+	milliCPU, memory float64, scalarResources map[v1.ResourceName]float64) {
+	queuePreemptionVictims.WithLabelValues(action, preemptorQueue, preemptorNamespace, victimQueue, victimNamespace).Inc()
+	queuePreemptionFreedMilliCPU.WithLabelValues(action, preemptorQueue, preemptorNamespace, victimQueue, victimNamespace).Add(milliCPU)
+	queuePreemptionFreedMemory.WithLabelValues(action, preemptorQueue, preemptorNamespace, victimQueue, victimNamespace).Add(memory)
+	for name, value := range scalarResources {
+		queuePreemptionFreedScalarResource.WithLabelValues(action, preemptorQueue, preemptorNamespace, victimQueue, victimNamespace, string(name)).Add(value)
+	}
+}
+
+// deleteQueuePreemptionMetrics deletes the preemption metrics of the queue either as the preemptor or the victim
+func deleteQueuePreemptionMetrics(queueName string) {
+	for _, label := range []string{"preemptor_queue", "victim_queue"} {
+		partialLabelMap := map[string]string{label: queueName}
+		queuePreemptionVictims.DeletePartialMatch(partialLabelMap)
+		queuePreemptionFreedMilliCPU.DeletePartialMatch(partialLabelMap)
+		queuePreemptionFreedMemory.DeletePartialMatch(partialLabelMap)
+		queuePreemptionFreedScalarResource.DeletePartialMatch(partialLabelMap)
+	}
+	queuePreemptions.DeletePartialMatch(map[string]string{"preemptor_queue": queueName})
+}
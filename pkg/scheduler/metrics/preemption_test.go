@@ -0,0 +1,45 @@
+/*
+Copyright 2026 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestQueuePreemptionMetric(t *testing.T) {
+	RegisterQueuePreemption("reclaim", "preemptor-queue", "ns1")
+	RegisterQueuePreemptionVictim("reclaim", "preemptor-queue", "ns1", "victim-queue", "ns2", 1000, 1024,
+		map[v1.ResourceName]float64{"nvidia.com/gpu": 1000})
+	RegisterQueuePreemptionVictim("reclaim", "preemptor-queue", "ns1", "victim-queue", "ns2", 500, 512, nil)
+
+	assert.Equal(t, 1., testutil.ToFloat64(queuePreemptions.WithLabelValues("reclaim", "preemptor-queue", "ns1")))
+	assert.Equal(t, 2., testutil.ToFloat64(queuePreemptionVictims.WithLabelValues("reclaim", "preemptor-queue", "ns1", "victim-queue", "ns2")))
+	assert.Equal(t, 1500., testutil.ToFloat64(queuePreemptionFreedMilliCPU.WithLabelValues("reclaim", "preemptor-queue", "ns1", "victim-queue", "ns2")))
+	assert.Equal(t, 1536., testutil.ToFloat64(queuePreemptionFreedMemory.WithLabelValues("reclaim", "preemptor-queue", "ns1", "victim-queue", "ns2")))
+	assert.Equal(t, 1000., testutil.ToFloat64(queuePreemptionFreedScalarResource.WithLabelValues("reclaim", "preemptor-queue", "ns1", "victim-queue", "ns2", "nvidia.com/gpu")))
+
+	// the metrics are deleted with either side of the preemption
+	DeleteQueueMetrics("victim-queue")
+	assert.Equal(t, 1, testutil.CollectAndCount(queuePreemptions))
+	assert.Equal(t, 0, testutil.CollectAndCount(queuePreemptionVictims))
+	DeleteQueueMetrics("preemptor-queue")
+	assert.Equal(t, 0, testutil.CollectAndCount(queuePreemptions))
+}
@@ -166,6 +166,86 @@ var (
 			Help:      "Capacity scalar resources for one queue",
 		}, []string{"queue_name", "resource"},
 	)
+
+	queueBorrowedMilliCPU = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "queue_borrowed_milli_cpu",
+			Help:      "CPU count allocated beyond the deserved for one queue",
+		}, []string{"queue_name"},
+	)
+
+	queueBorrowedMemory = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "queue_borrowed_memory_bytes",
+			Help:      "Memory allocated beyond the deserved for one queue",
+		}, []string{"queue_name"},
+	)
+
+	queueBorrowedScalarResource = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "queue_borrowed_scalar_resources",
+			Help:      "Scalar resources allocated beyond the deserved for one queue",
+		}, []string{"queue_name", "resource"},
+	)
+
+	queueQuotaWindow = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "queue_scheduled_quota_window",
+			Help:      "The active scheduled quota window of one queue, the value is 1 if it's active",
+		}, []string{"queue_name", "window"},
+	)
+
+	queueFairShareUsage = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "queue_fair_share_usage",
+			Help:      "The decayed usage of one queue normalized by the cluster resources and the half-life",
+		}, []string{"queue_name"},
+	)
+
+	queueReservedJobs = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "queue_reserved_jobs",
+			Help:      "Number of jobs with reserved nodes for one queue",
+		}, []string{"queue_name"},
+	)
+
+	queueReservedNodes = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "queue_reserved_nodes",
+			Help:      "Number of nodes reserved for the jobs of one queue",
+		}, []string{"queue_name"},
+	)
+
+	queueReservedIdleMilliCPU = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "queue_reserved_idle_milli_cpu",
+			Help:      "Idle CPU count on the nodes reserved for the jobs of one queue",
+		}, []string{"queue_name"},
+	)
+
+	queueReservedIdleMemory = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "queue_reserved_idle_memory_bytes",
+			Help:      "Idle memory on the nodes reserved for the jobs of one queue",
+		}, []string{"queue_name"},
+	)
+
+	queueReservationExpired = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: VolcanoSubSystemName,
+			Name:      "queue_reservation_expired_total",
+			Help:      "Number of reservations of one queue released because of the TTL",
+		}, []string{"queue_name"},
+	)
 )
 
 // UpdateQueueAllocated records allocated resources for one queue
@@ -232,6 +312,41 @@ func UpdateQueueRealCapacity(queueName string, milliCPU, memory float64, scalarR
 	}
 }
 
+// UpdateQueueBorrowed records the resources allocated beyond the deserved for one queue
+func UpdateQueueBorrowed(queueName string, milliCPU, memory float64, scalarResources map[v1.ResourceName]float64) {
+	queueBorrowedMilliCPU.WithLabelValues(queueName).Set(milliCPU)
+	queueBorrowedMemory.WithLabelValues(queueName).Set(memory)
+	for resource, value := range scalarResources {
+		queueBorrowedScalarResource.WithLabelValues(queueName, string(resource)).Set(value)
+	}
+}
+
+// UpdateQueueQuotaWindow records the active scheduled quota window for one queue, window is empty if none is active
+func UpdateQueueQuotaWindow(queueName, window string) {
+	queueQuotaWindow.DeletePartialMatch(map[string]string{"queue_name": queueName})
+	if window != "" {
+		queueQuotaWindow.WithLabelValues(queueName, window).Set(1)
+	}
+}
+
+// UpdateQueueFairShareUsage records the normalized decayed usage for one queue
+func UpdateQueueFairShareUsage(queueName string, usage float64) {
+	queueFairShareUsage.WithLabelValues(queueName).Set(usage)
+}
+
+// UpdateQueueReservation records the reserved jobs, the reserved nodes and the idle resources on them for one queue
+func UpdateQueueReservation(queueName string, jobs, nodes int, idleMilliCPU, idleMemory float64) {
+	queueReservedJobs.WithLabelValues(queueName).Set(float64(jobs))
+	queueReservedNodes.WithLabelValues(queueName).Set(float64(nodes))
+	queueReservedIdleMilliCPU.WithLabelValues(queueName).Set(idleMilliCPU)
+	queueReservedIdleMemory.WithLabelValues(queueName).Set(idleMemory)
+}
+
+// RegisterQueueReservationExpired records a reservation of one queue released because of the TTL
+func RegisterQueueReservationExpired(queueName string) {
+	queueReservationExpired.WithLabelValues(queueName).Inc()
+}
+
 // DeleteQueueMetrics delete all metrics related to the queue
 func DeleteQueueMetrics(queueName string) {
 	queueAllocatedMilliCPU.DeleteLabelValues(queueName)
@@ -247,10 +362,21 @@ func DeleteQueueMetrics(queueName string) {
 	queueCapacityMemory.DeleteLabelValues(queueName)
 	queueRealCapacityMilliCPU.DeleteLabelValues(queueName)
 	queueRealCapacityMemory.DeleteLabelValues(queueName)
+	queueBorrowedMilliCPU.DeleteLabelValues(queueName)
+	queueBorrowedMemory.DeleteLabelValues(queueName)
+	queueFairShareUsage.DeleteLabelValues(queueName)
+	queueReservedJobs.DeleteLabelValues(queueName)
+	queueReservedNodes.DeleteLabelValues(queueName)
+	queueReservedIdleMilliCPU.DeleteLabelValues(queueName)
+	queueReservedIdleMemory.DeleteLabelValues(queueName)
+	queueReservationExpired.DeleteLabelValues(queueName)
 	partialLabelMap := map[string]string{"queue_name": queueName}
 	queueAllocatedScalarResource.DeletePartialMatch(partialLabelMap)
 	queueRequestScalarResource.DeletePartialMatch(partialLabelMap)
 	queueDeservedScalarResource.DeletePartialMatch(partialLabelMap)
 	queueCapacityScalarResource.DeletePartialMatch(partialLabelMap)
 	queueRealCapacityScalarResource.DeletePartialMatch(partialLabelMap)
+	queueBorrowedScalarResource.DeletePartialMatch(partialLabelMap)
+	queueQuotaWindow.DeletePartialMatch(partialLabelMap)
+	deleteQueuePreemptionMetrics(queueName)
 }
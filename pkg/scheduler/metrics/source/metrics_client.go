@@ -19,8 +19,12 @@ package source
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
+	listersv1 "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/klog/v2"
 )
@@ -30,30 +34,86 @@ const (
 	Metrics_Type_Prometheus_Adaptor = "prometheus_adaptor"
 	Metrics_Tpye_Prometheus         = "prometheus"
 	Metrics_Type_Elasticsearch      = "elasticsearch"
+	Metrics_Type_Metrics_Server     = "metrics_server"
+	Metrics_Type_JSON               = "json"
 )
 
+// NodeMetrics is the load of a node collected from the metrics source. CPU and Memory are the usage in percent,
+// Load is the 1 minute load average, the network and disk fields are the throughput in bytes per second.
 type NodeMetrics struct {
-	MetricsTime time.Time
-	CPU         float64
-	Memory      float64
+	MetricsTime     time.Time
+	CPU             float64
+	Memory          float64
+	Load            float64
+	NetworkReceive  float64
+	NetworkTransmit float64
+	DiskRead        float64
+	DiskWrite       float64
 }
 
 type MetricsClient interface {
 	NodesMetricsAvg(ctx context.Context, nodeMetricsMap map[string]*NodeMetrics) error
 }
 
-func NewMetricsClient(restConfig *rest.Config, metricsConf map[string]string) (MetricsClient, error) {
-	klog.V(3).Infof("New metrics client begin, metricsConf is %v", metricsConf)
-	metricsType := metricsConf["type"]
-	if metricsType == Metrics_Type_Elasticsearch {
+// MetricsClientBuilder builds the metrics client of a metrics source from the metrics configuration of the scheduler.
+// The node lister is backed by the informer cache of the scheduler.
+type MetricsClientBuilder func(restConfig *rest.Config, nodeLister listersv1.NodeLister, metricsConf map[string]string) (MetricsClient, error)
+
+var metricsClientMutex sync.RWMutex
+
+// metricsClientBuilders is the registry of the metrics sources, keyed by the metrics type.
+var metricsClientBuilders = map[string]MetricsClientBuilder{}
+
+func init() {
+	RegisterMetricsClient(Metrics_Type_Elasticsearch, func(_ *rest.Config, _ listersv1.NodeLister, metricsConf map[string]string) (MetricsClient, error) {
 		return NewElasticsearchMetricsClient(metricsConf)
-	} else if metricsType == Metrics_Tpye_Prometheus {
+	})
+	RegisterMetricsClient(Metrics_Tpye_Prometheus, func(_ *rest.Config, _ listersv1.NodeLister, metricsConf map[string]string) (MetricsClient, error) {
 		return NewPrometheusMetricsClient(metricsConf)
-	} else if metricsType == Metrics_Type_Prometheus_Adaptor {
+	})
+	RegisterMetricsClient(Metrics_Type_Prometheus_Adaptor, func(restConfig *rest.Config, _ listersv1.NodeLister, _ map[string]string) (MetricsClient, error) {
 		return NewCustomMetricsClient(restConfig)
-	} else {
+	})
+	RegisterMetricsClient(Metrics_Type_Metrics_Server, func(restConfig *rest.Config, nodeLister listersv1.NodeLister, _ map[string]string) (MetricsClient, error) {
+		return NewMetricsServerClient(restConfig, nodeLister)
+	})
+	RegisterMetricsClient(Metrics_Type_JSON, func(_ *rest.Config, _ listersv1.NodeLister, metricsConf map[string]string) (MetricsClient, error) {
+		return NewJSONMetricsClient(metricsConf)
+	})
+}
+
+// RegisterMetricsClient registers the builder of the metrics client of a metrics type, the builder registered
+// later replaces the former one of the same type.
+func RegisterMetricsClient(metricsType string, builder MetricsClientBuilder) {
+	metricsClientMutex.Lock()
+	defer metricsClientMutex.Unlock()
+
+	metricsClientBuilders[metricsType] = builder
+}
+
+// GetMetricsTypes returns the registered metrics types in order.
+func GetMetricsTypes() []string {
+	metricsClientMutex.RLock()
+	defer metricsClientMutex.RUnlock()
+
+	types := make([]string, 0, len(metricsClientBuilders))
+	for metricsType := range metricsClientBuilders {
+		types = append(types, metricsType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func NewMetricsClient(restConfig *rest.Config, nodeLister listersv1.NodeLister, metricsConf map[string]string) (MetricsClient, error) {
+	klog.V(3).Infof("New metrics client begin, metricsConf is %v", metricsConf)
+	metricsType := metricsConf["type"]
+
+	metricsClientMutex.RLock()
+	builder, found := metricsClientBuilders[metricsType]
+	metricsClientMutex.RUnlock()
+	if !found {
 		return nil, fmt.Errorf("data cannot be collected from the %s monitoring system. "+
-			"The supported monitoring systems are %s, %s, and %s",
-			metricsType, Metrics_Type_Elasticsearch, Metrics_Tpye_Prometheus, Metrics_Type_Prometheus_Adaptor)
+			"The supported monitoring systems are %s", metricsType, strings.Join(GetMetricsTypes(), ", "))
 	}
+	return builder(restConfig, nodeLister, metricsConf)
 }
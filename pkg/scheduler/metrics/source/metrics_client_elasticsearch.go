@@ -35,8 +35,30 @@ const (
 	esCPUUsageField = "host.cpu.usage"
 	// esMemUsageField is the field name of mem usage in the document
 	esMemUsageField = "system.memory.actual.used.pct"
+	// esLoadField is the field name of 1 minute load average in the document
+	esLoadField = "system.load.1"
+	// esNetworkIngressField, esNetworkEgressField, esDiskReadField and esDiskWriteField are the field names of
+	// the bytes received, transmitted, read and written in the period of the metricset in the document
+	esNetworkIngressField = "host.network.ingress.bytes"
+	esNetworkEgressField  = "host.network.egress.bytes"
+	esDiskReadField       = "host.disk.read.bytes"
+	esDiskWriteField      = "host.disk.write.bytes"
+	// esPeriodField is the field name of the period of the metricset in milliseconds in the document
+	esPeriodField = "metricset.period"
 )
 
+// esAggregations are the average aggregations of the query of node metrics: aggregation name -> field name
+var esAggregations = map[string]string{
+	"cpu":             esCPUUsageField,
+	"mem":             esMemUsageField,
+	"load":            esLoadField,
+	"networkIngress":  esNetworkIngressField,
+	"networkEgress":   esNetworkEgressField,
+	"diskRead":        esDiskReadField,
+	"diskWrite":       esDiskWriteField,
+	"metricsetPeriod": esPeriodField,
+}
+
 type ElasticsearchMetricsClient struct {
 	address           string
 	indexName         string
@@ -94,6 +116,14 @@ func (e *ElasticsearchMetricsClient) NodesMetricsAvg(ctx context.Context, nodeMe
 
 func (e *ElasticsearchMetricsClient) NodeMetricsAvg(ctx context.Context, nodeName string) (*NodeMetrics, error) {
 	nodeMetrics := &NodeMetrics{}
+	aggs := make(map[string]interface{}, len(esAggregations))
+	for name, field := range esAggregations {
+		aggs[name] = map[string]interface{}{
+			"avg": map[string]interface{}{
+				"field": field,
+			},
+		}
+	}
 	var buf bytes.Buffer
 	query := map[string]interface{}{
 		"size": 0,
@@ -116,18 +146,7 @@ func (e *ElasticsearchMetricsClient) NodeMetricsAvg(ctx context.Context, nodeNam
 				},
 			},
 		},
-		"aggs": map[string]interface{}{
-			"cpu": map[string]interface{}{
-				"avg": map[string]interface{}{
-					"field": esCPUUsageField,
-				},
-			},
-			"mem": map[string]interface{}{
-				"avg": map[string]interface{}{
-					"field": esMemUsageField,
-				},
-			},
-		},
+		"aggs": aggs,
 	}
 	if err := json.NewEncoder(&buf).Encode(query); err != nil {
 		return nil, err
@@ -141,22 +160,32 @@ func (e *ElasticsearchMetricsClient) NodeMetricsAvg(ctx context.Context, nodeNam
 		return nil, err
 	}
 	defer res.Body.Close()
+	// the value of an aggregation is null if no document has the field
 	var r struct {
-		Aggregations struct {
-			CPU struct {
-				Value float64 `json:"value"`
-			}
-			Mem struct {
-				Value float64 `json:"value"`
-			}
+		Aggregations map[string]struct {
+			Value *float64 `json:"value"`
 		} `json:"aggregations"`
 	}
 	if err := json.NewDecoder(res.Body).Decode(&r); err != nil {
 		return nil, err
 	}
+	value := func(name string) float64 {
+		if v := r.Aggregations[name].Value; v != nil {
+			return *v
+		}
+		return 0
+	}
 	// The data obtained from Elasticsearch is in decimals and needs to be multiplied by 100.
-	nodeMetrics.CPU = r.Aggregations.CPU.Value * 100
-	nodeMetrics.Memory = r.Aggregations.Mem.Value * 100
+	nodeMetrics.CPU = value("cpu") * 100
+	nodeMetrics.Memory = value("mem") * 100
+	nodeMetrics.Load = value("load")
+	// The bytes are counted in the period of the metricset and need to be divided by the period in seconds.
+	if period := value("metricsetPeriod") / 1000; period > 0 {
+		nodeMetrics.NetworkReceive = value("networkIngress") / period
+		nodeMetrics.NetworkTransmit = value("networkEgress") / period
+		nodeMetrics.DiskRead = value("diskRead") / period
+		nodeMetrics.DiskWrite = value("diskWrite") / period
+	}
 	nodeMetrics.MetricsTime = time.Now()
 	return nodeMetrics, nil
 }
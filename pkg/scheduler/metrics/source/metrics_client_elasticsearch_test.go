@@ -16,7 +16,15 @@
 
 package source
 
-import "testing"
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
 
 func TestElasticsearchMetricsClientDefaultIndexName(t *testing.T) {
 	client, err := NewElasticsearchMetricsClient(map[string]string{"address": "http://localhost:9200"})
@@ -37,3 +45,29 @@ func TestElasticsearchMetricsClientCustomIndexName(t *testing.T) {
 		t.Errorf("Custom index name should be custom-index")
 	}
 }
+
+func TestElasticsearchMetricsClientNodeMetricsAvg(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		// the disk write throughput is not reported
+		fmt.Fprint(w, `{"aggregations": {"cpu": {"value": 0.305}, "mem": {"value": 0.42}, "load": {"value": 1.5},
+			"networkIngress": {"value": 10240}, "networkEgress": {"value": 20480}, "diskRead": {"value": 40960},
+			"diskWrite": {"value": null}, "metricsetPeriod": {"value": 10000}}}`)
+	}))
+	defer server.Close()
+
+	client, err := NewElasticsearchMetricsClient(map[string]string{"address": server.URL})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	nodeMetrics, err := client.NodeMetricsAvg(context.TODO(), "n1")
+	if err != nil {
+		t.Fatalf("Failed to get node metrics: %v", err)
+	}
+	nodeMetrics.MetricsTime = time.Time{}
+	expected := &NodeMetrics{CPU: 30.5, Memory: 42, Load: 1.5, NetworkReceive: 1024, NetworkTransmit: 2048, DiskRead: 4096}
+	if !reflect.DeepEqual(nodeMetrics, expected) {
+		t.Errorf("Expected node metrics %+v, got %+v", expected, nodeMetrics)
+	}
+}
@@ -0,0 +1,141 @@
+/*
+ Copyright 2026 The Volcano Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// JSONMetrics is the document read by the json metrics source, e.g.
+//
+//	{
+//	  "timestamp": "2026-01-01T00:00:00Z",
+//	  "nodes": {
+//	    "node1": {"cpu": 30.5, "memory": 42, "load": 1.2, "networkReceive": 1048576, "diskWrite": 2097152}
+//	  }
+//	}
+//
+// The timestamp of a node overrides the timestamp of the document, and the time of the read is used if neither is set.
+type JSONMetrics struct {
+	Timestamp *time.Time                  `json:"timestamp,omitempty"`
+	Nodes     map[string]*JSONNodeMetrics `json:"nodes"`
+}
+
+// JSONNodeMetrics is the metrics of a node in the json document.
+type JSONNodeMetrics struct {
+	Timestamp       *time.Time `json:"timestamp,omitempty"`
+	CPU             float64    `json:"cpu"`
+	Memory          float64    `json:"memory"`
+	Load            float64    `json:"load"`
+	NetworkReceive  float64    `json:"networkReceive"`
+	NetworkTransmit float64    `json:"networkTransmit"`
+	DiskRead        float64    `json:"diskRead"`
+	DiskWrite       float64    `json:"diskWrite"`
+}
+
+// JSONMetricsClient reads the node metrics from a json document in a local file or served over http(s),
+// which is useful for the tests and the clusters without a monitoring system.
+type JSONMetricsClient struct {
+	address string
+	client  *http.Client
+}
+
+func NewJSONMetricsClient(conf map[string]string) (*JSONMetricsClient, error) {
+	address := conf["address"]
+	if len(address) == 0 {
+		return nil, errors.New("metrics address is empty")
+	}
+	insecureSkipVerify := conf["tls.insecureSkipVerify"] == "true"
+	return &JSONMetricsClient{
+		address: address,
+		client: &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					InsecureSkipVerify: insecureSkipVerify,
+				},
+			},
+		},
+	}, nil
+}
+
+func (j *JSONMetricsClient) NodesMetricsAvg(ctx context.Context, nodeMetricsMap map[string]*NodeMetrics) error {
+	klog.V(4).Infof("Get node metrics from json document: %s", j.address)
+	data, err := j.read(ctx)
+	if err != nil {
+		klog.Errorf("Failed to read the json metrics from %s, error is: %v.", j.address, err)
+		return err
+	}
+	metrics := &JSONMetrics{}
+	if err := json.Unmarshal(data, metrics); err != nil {
+		return fmt.Errorf("failed to parse the json metrics from %s: %v", j.address, err)
+	}
+
+	now := time.Now()
+	for nodeName, value := range metrics.Nodes {
+		nodeMetrics, ok := nodeMetricsMap[nodeName]
+		if !ok || value == nil {
+			klog.V(4).Infof("The node %s information is obtained through the json document, but the volcano cache does not contain the node information.", nodeName)
+			continue
+		}
+		nodeMetrics.MetricsTime = now
+		if value.Timestamp != nil {
+			nodeMetrics.MetricsTime = *value.Timestamp
+		} else if metrics.Timestamp != nil {
+			nodeMetrics.MetricsTime = *metrics.Timestamp
+		}
+		nodeMetrics.CPU = value.CPU
+		nodeMetrics.Memory = value.Memory
+		nodeMetrics.Load = value.Load
+		nodeMetrics.NetworkReceive = value.NetworkReceive
+		nodeMetrics.NetworkTransmit = value.NetworkTransmit
+		nodeMetrics.DiskRead = value.DiskRead
+		nodeMetrics.DiskWrite = value.DiskWrite
+	}
+	return nil
+}
+
+// read returns the json document from the http(s) url or the local file of the address.
+func (j *JSONMetricsClient) read(ctx context.Context) ([]byte, error) {
+	if !strings.HasPrefix(j.address, "http://") && !strings.HasPrefix(j.address, "https://") {
+		return os.ReadFile(strings.TrimPrefix(j.address, "file://"))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, j.address, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := j.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
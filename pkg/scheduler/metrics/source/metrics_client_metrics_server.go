@@ -0,0 +1,89 @@
+/*
+ Copyright 2026 The Volcano Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+	metricsclientset "k8s.io/metrics/pkg/client/clientset/versioned"
+)
+
+// MetricsServerClient collects the node usage from the metrics.k8s.io API served by the metrics server. The metrics
+// server only reports the current cpu and memory usage, which is taken as the average usage in percent of the
+// allocatable resources of the node. The allocatable resources are read from the node informer cache of the scheduler.
+type MetricsServerClient struct {
+	nodeLister    listersv1.NodeLister
+	metricsClient metricsclientset.Interface
+}
+
+func NewMetricsServerClient(cfg *rest.Config, nodeLister listersv1.NodeLister) (*MetricsServerClient, error) {
+	klog.V(3).Infof("Create metrics.k8s.io api client")
+	if nodeLister == nil {
+		return nil, errors.New("node lister is nil")
+	}
+	metricsClient, err := metricsclientset.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricsServerClient{nodeLister: nodeLister, metricsClient: metricsClient}, nil
+}
+
+func (ms *MetricsServerClient) NodesMetricsAvg(ctx context.Context, nodeMetricsMap map[string]*NodeMetrics) error {
+	klog.V(5).Infof("Get node metrics from metrics.k8s.io api")
+
+	nodeMetricsList, err := ms.metricsClient.MetricsV1beta1().NodeMetricses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		klog.Errorf("Failed to list the node metrics, error is: %v.", err)
+		return err
+	}
+	nodes, err := ms.nodeLister.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("Failed to list the nodes, error is: %v.", err)
+		return err
+	}
+	allocatable := make(map[string]*NodeMetrics, len(nodes))
+	for _, node := range nodes {
+		allocatable[node.Name] = &NodeMetrics{
+			CPU:    float64(node.Status.Allocatable.Cpu().MilliValue()),
+			Memory: float64(node.Status.Allocatable.Memory().Value()),
+		}
+	}
+
+	for _, item := range nodeMetricsList.Items {
+		nodeMetrics, ok := nodeMetricsMap[item.Name]
+		if !ok {
+			klog.Warningf("The node %s information is obtained through the metrics.k8s.io API, but the volcano cache does not contain the node information.", item.Name)
+			continue
+		}
+		total, ok := allocatable[item.Name]
+		if !ok || total.CPU == 0 || total.Memory == 0 {
+			klog.Warningf("The allocatable resources of node %s are unknown, its metrics are ignored.", item.Name)
+			continue
+		}
+		nodeMetrics.MetricsTime = item.Timestamp.Time
+		nodeMetrics.CPU = float64(item.Usage.Cpu().MilliValue()) / total.CPU * 100
+		nodeMetrics.Memory = float64(item.Usage.Memory().Value()) / total.Memory * 100
+		klog.V(5).Infof("The updated usage information of node %s is %v.", item.Name, nodeMetrics)
+	}
+	return nil
+}
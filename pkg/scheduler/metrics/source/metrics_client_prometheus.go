@@ -22,7 +22,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"strconv"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -46,17 +47,34 @@ func NewPrometheusMetricsClient(conf map[string]string) (*PrometheusMetricsClien
 }
 
 func (p *PrometheusMetricsClient) NodesMetricsAvg(ctx context.Context, nodeMetricsMap map[string]*NodeMetrics) error {
+	if len(nodeMetricsMap) == 0 {
+		return nil
+	}
+	nodeNames := make([]string, 0, len(nodeMetricsMap))
 	for nodeName := range nodeMetricsMap {
-		nodeMetrics, err := p.NodeMetricsAvg(ctx, nodeName)
-		if err != nil {
-			return err
-		}
+		nodeNames = append(nodeNames, nodeName)
+	}
+	nodesMetrics, err := p.queryNodesMetrics(ctx, nodeNames)
+	if err != nil {
+		return err
+	}
+	for nodeName, nodeMetrics := range nodesMetrics {
 		nodeMetricsMap[nodeName] = nodeMetrics
 	}
 	return nil
 }
 
 func (p *PrometheusMetricsClient) NodeMetricsAvg(ctx context.Context, nodeName string) (*NodeMetrics, error) {
+	nodesMetrics, err := p.queryNodesMetrics(ctx, []string{nodeName})
+	if err != nil {
+		return nil, err
+	}
+	return nodesMetrics[nodeName], nil
+}
+
+// queryNodesMetrics issues one query per metric covering all the given nodes, the samples of the result vectors
+// are assigned to the nodes by their instance label.
+func (p *PrometheusMetricsClient) queryNodesMetrics(ctx context.Context, nodeNames []string) (map[string]*NodeMetrics, error) {
 	klog.V(4).Infof("Get node metrics from Prometheus: %s", p.address)
 	var client api.Client
 	var err error
@@ -74,39 +92,77 @@ func (p *PrometheusMetricsClient) NodeMetricsAvg(ctx context.Context, nodeName s
 		return nil, err
 	}
 	v1api := prometheusv1.NewAPI(client)
-	nodeMetrics := &NodeMetrics{}
-	cpuQueryStr := fmt.Sprintf("avg_over_time((100 - (avg by (instance) (irate(node_cpu_seconds_total{mode=\"idle\",instance=\"%s\"}[5m])) * 100))[%s:30s])", nodeName, NODE_METRICS_PERIOD)
-	memQueryStr := fmt.Sprintf("100*avg_over_time(((1-node_memory_MemAvailable_bytes{instance=\"%s\"}/node_memory_MemTotal_bytes{instance=\"%s\"}))[%s:30s])", nodeName, nodeName, NODE_METRICS_PERIOD)
 
-	for _, metric := range []string{cpuQueryStr, memQueryStr} {
-		res, warnings, err := v1api.Query(ctx, metric, time.Now())
+	sort.Strings(nodeNames)
+	quotedNames := make([]string, 0, len(nodeNames))
+	for _, nodeName := range nodeNames {
+		quotedNames = append(quotedNames, regexp.QuoteMeta(nodeName))
+	}
+	// the instance matcher is a regular expression, the backslashes of the quoted names are escaped for PromQL
+	instances := strings.ReplaceAll(strings.Join(quotedNames, "|"), `\`, `\\`)
+
+	nodesMetrics := make(map[string]*NodeMetrics, len(nodeNames))
+	now := time.Now()
+	for _, nodeName := range nodeNames {
+		nodesMetrics[nodeName] = &NodeMetrics{MetricsTime: now}
+	}
+
+	queries := []struct {
+		query string
+		set   func(nodeMetrics *NodeMetrics, value float64)
+	}{
+		{
+			query: fmt.Sprintf("avg_over_time((100 - (avg by (instance) (irate(node_cpu_seconds_total{mode=\"idle\",instance=~\"%s\"}[5m])) * 100))[%s:30s])", instances, NODE_METRICS_PERIOD),
+			set:   func(nodeMetrics *NodeMetrics, value float64) { nodeMetrics.CPU = value },
+		},
+		{
+			query: fmt.Sprintf("100*avg_over_time(((1-node_memory_MemAvailable_bytes{instance=~\"%s\"}/node_memory_MemTotal_bytes{instance=~\"%s\"}))[%s:30s])", instances, instances, NODE_METRICS_PERIOD),
+			set:   func(nodeMetrics *NodeMetrics, value float64) { nodeMetrics.Memory = value },
+		},
+		{
+			query: fmt.Sprintf("avg_over_time(node_load1{instance=~\"%s\"}[%s])", instances, NODE_METRICS_PERIOD),
+			set:   func(nodeMetrics *NodeMetrics, value float64) { nodeMetrics.Load = value },
+		},
+		{
+			query: fmt.Sprintf("sum by (instance) (rate(node_network_receive_bytes_total{instance=~\"%s\",device!=\"lo\"}[%s]))", instances, NODE_METRICS_PERIOD),
+			set:   func(nodeMetrics *NodeMetrics, value float64) { nodeMetrics.NetworkReceive = value },
+		},
+		{
+			query: fmt.Sprintf("sum by (instance) (rate(node_network_transmit_bytes_total{instance=~\"%s\",device!=\"lo\"}[%s]))", instances, NODE_METRICS_PERIOD),
+			set:   func(nodeMetrics *NodeMetrics, value float64) { nodeMetrics.NetworkTransmit = value },
+		},
+		{
+			query: fmt.Sprintf("sum by (instance) (rate(node_disk_read_bytes_total{instance=~\"%s\"}[%s]))", instances, NODE_METRICS_PERIOD),
+			set:   func(nodeMetrics *NodeMetrics, value float64) { nodeMetrics.DiskRead = value },
+		},
+		{
+			query: fmt.Sprintf("sum by (instance) (rate(node_disk_written_bytes_total{instance=~\"%s\"}[%s]))", instances, NODE_METRICS_PERIOD),
+			set:   func(nodeMetrics *NodeMetrics, value float64) { nodeMetrics.DiskWrite = value },
+		},
+	}
+
+	for _, metric := range queries {
+		res, warnings, err := v1api.Query(ctx, metric.query, now)
 		if err != nil {
 			klog.Errorf("Error querying Prometheus: %v", err)
+			continue
 		}
 		if len(warnings) > 0 {
 			klog.V(3).Infof("Warning querying Prometheus: %v", warnings)
 		}
-		if res == nil || res.String() == "" {
-			klog.Warningf("Warning querying Prometheus: no data found for %s", metric)
-			continue
-		}
 		// plugin.usage only need type pmodel.ValVector in Prometheus.rulues
-		if res.Type() != pmodel.ValVector {
+		vector, ok := res.(pmodel.Vector)
+		if !ok || len(vector) == 0 {
+			klog.Warningf("Warning querying Prometheus: no data found for %s", metric.query)
 			continue
 		}
-		// only method res.String() can get data, dataType []pmodel.ValVector, eg: "{k1:v1, ...} => #[value] @#[timespace]\n {k2:v2, ...} => ..."
-		firstRowValVector := strings.Split(res.String(), "\n")[0]
-		rowValues := strings.Split(strings.TrimSpace(firstRowValVector), "=>")
-		value := strings.Split(strings.TrimSpace(rowValues[1]), " ")
-		switch metric {
-		case cpuQueryStr:
-			cpuUsage, _ := strconv.ParseFloat(value[0], 64)
-			nodeMetrics.CPU = cpuUsage
-		case memQueryStr:
-			memUsage, _ := strconv.ParseFloat(value[0], 64)
-			nodeMetrics.Memory = memUsage
+		for _, sample := range vector {
+			nodeMetrics, found := nodesMetrics[string(sample.Metric["instance"])]
+			if !found {
+				continue
+			}
+			metric.set(nodeMetrics, float64(sample.Value))
 		}
 	}
-	nodeMetrics.MetricsTime = time.Now()
-	return nodeMetrics, nil
+	return nodesMetrics, nil
 }
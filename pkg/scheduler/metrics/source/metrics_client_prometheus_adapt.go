@@ -34,8 +34,26 @@ const (
 	CustomNodeCPUUsageAvg = "node_cpu_usage_avg"
 	// CustomNodeMemUsageAvg record name of mem average usage defined in prometheus adapt rules
 	CustomNodeMemUsageAvg = "node_memory_usage_avg"
+	// CustomNodeLoadAvg record name of 1 minute load average defined in prometheus adapt rules
+	CustomNodeLoadAvg = "node_load_avg"
+	// CustomNodeNetworkReceiveRate record name of network receive throughput in bytes per second defined in prometheus adapt rules
+	CustomNodeNetworkReceiveRate = "node_network_receive_bytes_rate"
+	// CustomNodeNetworkTransmitRate record name of network transmit throughput in bytes per second defined in prometheus adapt rules
+	CustomNodeNetworkTransmitRate = "node_network_transmit_bytes_rate"
+	// CustomNodeDiskReadRate record name of disk read throughput in bytes per second defined in prometheus adapt rules
+	CustomNodeDiskReadRate = "node_disk_read_bytes_rate"
+	// CustomNodeDiskWriteRate record name of disk write throughput in bytes per second defined in prometheus adapt rules
+	CustomNodeDiskWriteRate = "node_disk_written_bytes_rate"
 )
 
+// customNodeMetrics are the metrics of the nodes which must be defined in prometheus adapt rules.
+var customNodeMetrics = []string{CustomNodeCPUUsageAvg, CustomNodeMemUsageAvg}
+
+// customNodeExtendedMetrics are the optional metrics of the nodes, they're only collected if defined in prometheus
+// adapt rules.
+var customNodeExtendedMetrics = []string{CustomNodeLoadAvg, CustomNodeNetworkReceiveRate, CustomNodeNetworkTransmitRate,
+	CustomNodeDiskReadRate, CustomNodeDiskWriteRate}
+
 type KMetricsClient struct {
 	customMetricsCli customclient.CustomMetricsClient
 }
@@ -68,8 +86,13 @@ func (km *KMetricsClient) NodesMetricsAvg(ctx context.Context, nodeMetricsMap ma
 		Kind:  "Node",
 	}
 
-	for _, metricName := range []string{CustomNodeCPUUsageAvg, CustomNodeMemUsageAvg} {
+	metricNames := append(append([]string{}, customNodeMetrics...), customNodeExtendedMetrics...)
+	for i, metricName := range metricNames {
 		metricsValue, err := km.customMetricsCli.RootScopedMetrics().GetForObjects(groupKind, labels.NewSelector(), metricName, labels.NewSelector())
+		if err != nil && i >= len(customNodeMetrics) {
+			klog.V(4).Infof("Failed to query the optional indicator %s, error is: %v.", metricName, err)
+			continue
+		}
 		if err != nil {
 			klog.Errorf("Failed to query the indicator %s, error is: %v.", metricName, err)
 			return err
@@ -88,6 +111,16 @@ func (km *KMetricsClient) NodesMetricsAvg(ctx context.Context, nodeMetricsMap ma
 			case CustomNodeMemUsageAvg:
 				nodeMetricsMap[nodeName].MetricsTime = metricValue.Timestamp.Time
 				nodeMetricsMap[nodeName].Memory = metricValue.Value.AsApproximateFloat64() * 100
+			case CustomNodeLoadAvg:
+				nodeMetricsMap[nodeName].Load = metricValue.Value.AsApproximateFloat64()
+			case CustomNodeNetworkReceiveRate:
+				nodeMetricsMap[nodeName].NetworkReceive = metricValue.Value.AsApproximateFloat64()
+			case CustomNodeNetworkTransmitRate:
+				nodeMetricsMap[nodeName].NetworkTransmit = metricValue.Value.AsApproximateFloat64()
+			case CustomNodeDiskReadRate:
+				nodeMetricsMap[nodeName].DiskRead = metricValue.Value.AsApproximateFloat64()
+			case CustomNodeDiskWriteRate:
+				nodeMetricsMap[nodeName].DiskWrite = metricValue.Value.AsApproximateFloat64()
 			default:
 				klog.Errorf("Node supports %s and %s metrics, and %s indicates abnormal metrics.", CustomNodeCPUUsageAvg, CustomNodeMemUsageAvg, metricName)
 			}
@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ktesting "k8s.io/client-go/testing"
+	"k8s.io/metrics/pkg/apis/custom_metrics/v1beta2"
+	customfake "k8s.io/metrics/pkg/client/custom_metrics/fake"
+)
+
+func TestCustomMetricsClientNodesMetricsAvg(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	// the disk metrics are not defined in prometheus adapt rules
+	values := map[string]string{
+		CustomNodeCPUUsageAvg:         "305m",
+		CustomNodeMemUsageAvg:         "420m",
+		CustomNodeLoadAvg:             "1500m",
+		CustomNodeNetworkReceiveRate:  "1Ki",
+		CustomNodeNetworkTransmitRate: "2Ki",
+	}
+	customClient := &customfake.FakeCustomMetricsClient{}
+	customClient.AddReactor("get", "*", func(action ktesting.Action) (bool, runtime.Object, error) {
+		metricName := action.(customfake.GetForAction).GetMetricName()
+		value, found := values[metricName]
+		if !found {
+			return true, nil, fmt.Errorf("the server could not find the metric %s for nodes", metricName)
+		}
+		return true, &v1beta2.MetricValueList{Items: []v1beta2.MetricValue{{
+			DescribedObject: v1.ObjectReference{Kind: "Node", Name: "n1"},
+			Timestamp:       metav1.NewTime(now),
+			Value:           resource.MustParse(value),
+		}}}, nil
+	})
+
+	client := &KMetricsClient{customMetricsCli: customClient}
+	nodeMetricsMap := map[string]*NodeMetrics{"n1": {}}
+	if err := client.NodesMetricsAvg(context.TODO(), nodeMetricsMap); err != nil {
+		t.Fatalf("Failed to get node metrics: %v", err)
+	}
+	expected := &NodeMetrics{MetricsTime: now, CPU: 30.5, Memory: 42, Load: 1.5, NetworkReceive: 1024, NetworkTransmit: 2048}
+	if !reflect.DeepEqual(nodeMetricsMap["n1"], expected) {
+		t.Errorf("Expected node metrics %+v, got %+v", expected, nodeMetricsMap["n1"])
+	}
+
+	// the cpu and memory metrics must be defined
+	delete(values, CustomNodeMemUsageAvg)
+	if err := client.NodesMetricsAvg(context.TODO(), nodeMetricsMap); err == nil {
+		t.Errorf("Expected an error when the memory metric is not defined")
+	}
+}
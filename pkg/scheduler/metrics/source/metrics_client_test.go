@@ -0,0 +1,272 @@
+/*
+ Copyright 2026 The Volcano Authors.
+
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package source
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	listersv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/rest"
+	ktesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+const jsonMetrics = `{
+  "timestamp": "2026-01-01T00:00:00Z",
+  "nodes": {
+    "n1": {"cpu": 30.5, "memory": 42, "load": 1.5, "networkReceive": 1024, "networkTransmit": 2048, "diskRead": 4096, "diskWrite": 8192},
+    "n2": {"timestamp": "2026-01-01T00:01:00Z", "cpu": 60},
+    "unknown": {"cpu": 90}
+  }
+}`
+
+func TestNewMetricsClientRegistry(t *testing.T) {
+	if _, err := NewMetricsClient(nil, nil, map[string]string{"type": "unknown"}); err == nil {
+		t.Errorf("Expected an error for the unknown metrics type")
+	}
+
+	called := false
+	RegisterMetricsClient("fake", func(_ *rest.Config, _ listersv1.NodeLister, _ map[string]string) (MetricsClient, error) {
+		called = true
+		return &JSONMetricsClient{}, nil
+	})
+	defer func() {
+		metricsClientMutex.Lock()
+		delete(metricsClientBuilders, "fake")
+		metricsClientMutex.Unlock()
+	}()
+	if _, err := NewMetricsClient(nil, nil, map[string]string{"type": "fake"}); err != nil || !called {
+		t.Errorf("Expected the registered builder to be called, err: %v", err)
+	}
+
+	for _, metricsType := range []string{Metrics_Type_Elasticsearch, Metrics_Tpye_Prometheus, Metrics_Type_Prometheus_Adaptor, Metrics_Type_Metrics_Server, Metrics_Type_JSON} {
+		metricsClientMutex.RLock()
+		_, found := metricsClientBuilders[metricsType]
+		metricsClientMutex.RUnlock()
+		if !found {
+			t.Errorf("Metrics type %s is not registered", metricsType)
+		}
+	}
+}
+
+func checkJSONMetrics(t *testing.T, nodeMetricsMap map[string]*NodeMetrics) {
+	expected := map[string]*NodeMetrics{
+		"n1": {
+			MetricsTime: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			CPU:         30.5, Memory: 42, Load: 1.5,
+			NetworkReceive: 1024, NetworkTransmit: 2048, DiskRead: 4096, DiskWrite: 8192,
+		},
+		"n2": {
+			MetricsTime: time.Date(2026, 1, 1, 0, 1, 0, 0, time.UTC),
+			CPU:         60,
+		},
+		"n3": {},
+	}
+	if len(nodeMetricsMap) != len(expected) {
+		t.Fatalf("Expected %d nodes, got %d", len(expected), len(nodeMetricsMap))
+	}
+	for name, want := range expected {
+		got := nodeMetricsMap[name]
+		if !got.MetricsTime.Equal(want.MetricsTime) {
+			t.Errorf("Node %s: expected metrics time %v, got %v", name, want.MetricsTime, got.MetricsTime)
+		}
+		got.MetricsTime = want.MetricsTime
+		if *got != *want {
+			t.Errorf("Node %s: expected metrics %+v, got %+v", name, want, got)
+		}
+	}
+}
+
+func newNodeMetricsMap(names ...string) map[string]*NodeMetrics {
+	nodeMetricsMap := make(map[string]*NodeMetrics, len(names))
+	for _, name := range names {
+		nodeMetricsMap[name] = &NodeMetrics{}
+	}
+	return nodeMetricsMap
+}
+
+func TestJSONMetricsClientFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	if err := os.WriteFile(path, []byte(jsonMetrics), 0600); err != nil {
+		t.Fatalf("Failed to write the metrics file: %v", err)
+	}
+
+	for _, address := range []string{path, "file://" + path} {
+		client, err := NewMetricsClient(nil, nil, map[string]string{"type": Metrics_Type_JSON, "address": address})
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		nodeMetricsMap := newNodeMetricsMap("n1", "n2", "n3")
+		if err := client.NodesMetricsAvg(context.TODO(), nodeMetricsMap); err != nil {
+			t.Fatalf("Failed to get the node metrics: %v", err)
+		}
+		checkJSONMetrics(t, nodeMetricsMap)
+	}
+
+	client, _ := NewJSONMetricsClient(map[string]string{"address": filepath.Join(t.TempDir(), "missing.json")})
+	if err := client.NodesMetricsAvg(context.TODO(), newNodeMetricsMap("n1")); err == nil {
+		t.Errorf("Expected an error for the missing file")
+	}
+	if _, err := NewJSONMetricsClient(map[string]string{}); err == nil {
+		t.Errorf("Expected an error for the empty address")
+	}
+}
+
+func TestJSONMetricsClientHTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metrics" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(jsonMetrics))
+	}))
+	defer server.Close()
+
+	client, err := NewJSONMetricsClient(map[string]string{"address": server.URL + "/metrics"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	nodeMetricsMap := newNodeMetricsMap("n1", "n2", "n3")
+	if err := client.NodesMetricsAvg(context.TODO(), nodeMetricsMap); err != nil {
+		t.Fatalf("Failed to get the node metrics: %v", err)
+	}
+	checkJSONMetrics(t, nodeMetricsMap)
+
+	client, _ = NewJSONMetricsClient(map[string]string{"address": server.URL + "/missing"})
+	if err := client.NodesMetricsAvg(context.TODO(), newNodeMetricsMap("n1")); err == nil {
+		t.Errorf("Expected an error for the unexpected status code")
+	}
+}
+
+func TestMetricsServerClient(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "n1"},
+		Status: v1.NodeStatus{Allocatable: v1.ResourceList{
+			v1.ResourceCPU:    resource.MustParse("4"),
+			v1.ResourceMemory: resource.MustParse("8Gi"),
+		}},
+	}
+	metricsClient := metricsfake.NewSimpleClientset()
+	metricsClient.PrependReactor("list", "nodes", func(_ ktesting.Action) (bool, runtime.Object, error) {
+		return true, &metricsv1beta1.NodeMetricsList{Items: []metricsv1beta1.NodeMetrics{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "n1"},
+				Timestamp:  metav1.NewTime(now),
+				Usage: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse("1"),
+					v1.ResourceMemory: resource.MustParse("2Gi"),
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "n2"},
+				Timestamp:  metav1.NewTime(now),
+				Usage:      v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")},
+			},
+		}}, nil
+	})
+	nodeIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	nodeIndexer.Add(node)
+	client := &MetricsServerClient{
+		nodeLister:    listersv1.NewNodeLister(nodeIndexer),
+		metricsClient: metricsClient,
+	}
+
+	nodeMetricsMap := newNodeMetricsMap("n1", "n2")
+	if err := client.NodesMetricsAvg(context.TODO(), nodeMetricsMap); err != nil {
+		t.Fatalf("Failed to get the node metrics: %v", err)
+	}
+	if got := nodeMetricsMap["n1"]; got.CPU != 25 || got.Memory != 25 || !got.MetricsTime.Equal(now) {
+		t.Errorf("Node n1: expected cpu 25, memory 25 at %v, got %+v", now, got)
+	}
+	if got := nodeMetricsMap["n2"]; *got != (NodeMetrics{}) {
+		t.Errorf("Node n2 without allocatable resources is expected to be ignored, got %+v", got)
+	}
+}
+
+func TestPrometheusMetricsClient(t *testing.T) {
+	values := map[string]string{
+		"node_cpu_seconds_total":            "12.5",
+		"node_memory_MemAvailable_bytes":    "40",
+		"node_load1":                        "1.5",
+		"node_network_receive_bytes_total":  "1024",
+		"node_network_transmit_bytes_total": "2048",
+		"node_disk_read_bytes_total":        "4096",
+		"node_disk_written_bytes_total":     "8192",
+	}
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		query := r.Form.Get("query")
+		queries = append(queries, query)
+		if !strings.Contains(query, `instance=~"n1|n2\\.example"`) {
+			t.Errorf("Expected the query to cover all the nodes, got %s", query)
+		}
+		value := ""
+		for metric, v := range values {
+			if strings.Contains(query, metric+"{") {
+				value = v
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":[`+
+			`{"metric":{"instance":"n1"},"value":[1700000000,"%s"]},`+
+			`{"metric":{"instance":"n3"},"value":[1700000000,"1"]}]}}`, value)
+	}))
+	defer server.Close()
+
+	client, err := NewMetricsClient(nil, nil, map[string]string{"type": Metrics_Tpye_Prometheus, "address": server.URL})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	nodeMetricsMap := newNodeMetricsMap("n1", "n2.example")
+	if err := client.NodesMetricsAvg(context.TODO(), nodeMetricsMap); err != nil {
+		t.Fatalf("Failed to get the node metrics: %v", err)
+	}
+	if len(queries) != len(values) {
+		t.Errorf("Expected one query per metric (%d), got %d", len(values), len(queries))
+	}
+	got := *nodeMetricsMap["n1"]
+	got.MetricsTime = time.Time{}
+	expected := NodeMetrics{CPU: 12.5, Memory: 40, Load: 1.5, NetworkReceive: 1024, NetworkTransmit: 2048, DiskRead: 4096, DiskWrite: 8192}
+	if got != expected {
+		t.Errorf("Node n1: expected metrics %+v, got %+v", expected, got)
+	}
+	if got := nodeMetricsMap["n2.example"]; got.CPU != 0 || got.Memory != 0 || got.MetricsTime.IsZero() {
+		t.Errorf("Node n2.example without samples is expected to have empty metrics, got %+v", got)
+	}
+	if _, found := nodeMetricsMap["n3"]; found {
+		t.Errorf("Node n3 unknown to the scheduler is not expected in the metrics")
+	}
+}
@@ -49,6 +49,15 @@ const (
 	resourceFmt = "%s[%d]"
 )
 
+// ArgumentsSchema is the schema of the arguments of binpack plugin
+var ArgumentsSchema = framework.ArgumentsSchema{
+	BinpackWeight:                framework.IntArgument,
+	BinpackCPU:                   framework.IntArgument,
+	BinpackMemory:                framework.IntArgument,
+	BinpackResources:             framework.StringArgument,
+	BinpackResourcesPrefix + "*": framework.IntArgument,
+}
+
 type priorityWeight struct {
 	BinPackingWeight    int
 	BinPackingCPU       int
@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacity
+
+import (
+	"encoding/json"
+	"math"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/api/helpers"
+	"volcano.sh/volcano/pkg/scheduler/metrics"
+)
+
+const (
+	// BorrowingLimitAnnotationKey is the annotation of queue with the max resources the queue may use beyond
+	// its deserved, e.g. {"cpu": "4", "memory": "8Gi"}. The resources not in the limit may be borrowed without limit.
+	BorrowingLimitAnnotationKey = "volcano.sh/borrowing-limit"
+	// LendingLimitAnnotationKey is the annotation of queue with the max resources of its idle deserved the queue
+	// lends to other queues, e.g. {"cpu": "2"}. The resources not in the limit are lent entirely.
+	LendingLimitAnnotationKey = "volcano.sh/lending-limit"
+)
+
+// parseLimit parses the borrowing or lending limit of the queue, the invalid limit is ignored.
+func parseLimit(queue *api.QueueInfo, key string) v1.ResourceList {
+	value, found := queue.Queue.Annotations[key]
+	if !found {
+		return nil
+	}
+	limit := v1.ResourceList{}
+	if err := json.Unmarshal([]byte(value), &limit); err != nil {
+		klog.Errorf("Failed to parse annotation %s of queue <%s>, ignore it: %v", key, queue.Name, err)
+		return nil
+	}
+	return limit
+}
+
+// reservedResource returns the resources reserved for the queue, which are not lent to other queues even if idle:
+// the guarantee, and the part of the deserved beyond the lending limit.
+func reservedResource(queue *api.QueueInfo) *api.Resource {
+	guarantee := api.EmptyResource()
+	if len(queue.Queue.Spec.Guarantee.Resource) != 0 {
+		guarantee = api.NewResource(queue.Queue.Spec.Guarantee.Resource)
+	}
+	lendingLimit := parseLimit(queue, LendingLimitAnnotationKey)
+	if lendingLimit == nil {
+		return guarantee
+	}
+
+	deserved := api.NewResource(queue.Queue.Spec.Deserved)
+	limit := api.NewResource(lendingLimit)
+	unlent := api.EmptyResource()
+	for name := range lendingLimit {
+		setResource(unlent, name, math.Max(deserved.Get(name)-limit.Get(name), 0))
+	}
+	return helpers.Max(guarantee, unlent)
+}
+
+// borrowingCapability returns the deserved plus the borrowing limit of the queue, which caps its realCapability.
+// The resources not in the borrowing limit are not capped.
+func borrowingCapability(deserved *api.Resource, borrowingLimit v1.ResourceList) *api.Resource {
+	capability := &api.Resource{MilliCPU: math.MaxFloat64, Memory: math.MaxFloat64}
+	limit := api.NewResource(borrowingLimit)
+	for name := range borrowingLimit {
+		setResource(capability, name, deserved.Get(name)+limit.Get(name))
+	}
+	return capability
+}
+
+func setResource(r *api.Resource, name v1.ResourceName, value float64) {
+	switch name {
+	case v1.ResourceCPU:
+		r.MilliCPU = value
+	case v1.ResourceMemory:
+		r.Memory = value
+	default:
+		r.SetScalar(name, value)
+	}
+}
+
+// setLimits sets the borrowing limit and the reserved resources of the queue, it must be called before
+// the deserved of the queue is adjusted by its request.
+func (attr *queueAttr) setLimits(queue *api.QueueInfo) {
+	attr.borrowingLimit = parseLimit(queue, BorrowingLimitAnnotationKey)
+	attr.reserved = reservedResource(queue)
+}
+
+// limitBorrowing caps the realCapability of the queue by its deserved plus its borrowing limit.
+func (attr *queueAttr) limitBorrowing() {
+	if attr.borrowingLimit == nil || attr.realCapability == nil {
+		return
+	}
+	attr.realCapability.MinDimensionResource(borrowingCapability(attr.deserved, attr.borrowingLimit), api.Infinity)
+}
+
+// updateBorrowed records the resources allocated beyond the deserved of the queue,
+// the resources not in the deserved are considered infinity and never borrowed.
+func (cp *capacityPlugin) updateBorrowed(attr *queueAttr) {
+	borrowed, _ := attr.allocated.Diff(attr.deserved, api.Infinity)
+	metrics.UpdateQueueBorrowed(attr.name, borrowed.MilliCPU, borrowed.Memory, borrowed.ScalarResources)
+}
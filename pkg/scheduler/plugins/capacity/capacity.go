@@ -19,6 +19,7 @@ package capacity
 import (
 	"fmt"
 	"math"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
@@ -41,6 +42,8 @@ type capacityPlugin struct {
 	rootQueue      string
 	totalResource  *api.Resource
 	totalGuarantee *api.Resource
+	// totalReserved is the sum of the resources reserved for the queues, which are not lent to other queues
+	totalReserved *api.Resource
 
 	queueOpts map[api.QueueID]*queueAttr
 	// Arguments given for the plugin
@@ -65,6 +68,11 @@ type queueAttr struct {
 	// realCapability represents the resource limit of the queue, LessEqual capability
 	realCapability *api.Resource
 	guarantee      *api.Resource
+	// reserved represents the resources not lent to other queues even if idle, it's the guarantee plus
+	// the part of deserved beyond the lending limit
+	reserved *api.Resource
+	// borrowingLimit represents the max resources the queue may use beyond its deserved, nil means no limit
+	borrowingLimit v1.ResourceList
 }
 
 // New return capacityPlugin action
@@ -72,6 +80,7 @@ func New(arguments framework.Arguments) framework.Plugin {
 	return &capacityPlugin{
 		totalResource:   api.EmptyResource(),
 		totalGuarantee:  api.EmptyResource(),
+		totalReserved:   api.EmptyResource(),
 		queueOpts:       map[api.QueueID]*queueAttr{},
 		pluginArguments: arguments,
 	}
@@ -96,6 +105,7 @@ func (cp *capacityPlugin) HierarchyEnabled(ssn *framework.Session) bool {
 
 func (cp *capacityPlugin) OnSessionOpen(ssn *framework.Session) {
 	// Prepare scheduling data for this session.
+	graceQueues := util.ApplyScheduledQuotas(ssn, time.Now())
 	cp.totalResource.Add(ssn.TotalResource)
 
 	klog.V(4).Infof("The total resource is <%v>", cp.totalResource)
@@ -119,6 +129,11 @@ func (cp *capacityPlugin) OnSessionOpen(ssn *framework.Session) {
 		for _, reclaimee := range reclaimees {
 			job := ssn.Jobs[reclaimee.Job]
 			attr := cp.queueOpts[job.Queue]
+			if graceQueues[job.Queue] {
+				klog.V(4).Infof("Queue <%s> is in the grace period of its scheduled quota window, skip reclaimee <%s/%s>",
+					attr.name, reclaimee.Namespace, reclaimee.Name)
+				continue
+			}
 
 			if _, found := allocations[job.Queue]; !found {
 				allocations[job.Queue] = attr.allocated.Clone()
@@ -128,7 +143,8 @@ func (cp *capacityPlugin) OnSessionOpen(ssn *framework.Session) {
 			exceptReclaimee := allocated.Clone().Sub(reclaimee.Resreq)
 			// When scalar resource not specified in deserved such as "pods", we should skip it and consider it as infinity,
 			// so the following first condition will be true and the current queue will not be reclaimed.
-			if allocated.LessEqual(attr.deserved, api.Infinity) || !attr.guarantee.LessEqual(exceptReclaimee, api.Zero) {
+			// The reserved resources, including the deserved not lent to other queues, are never reclaimed.
+			if allocated.LessEqual(attr.deserved, api.Infinity) || !attr.reserved.LessEqual(exceptReclaimee, api.Zero) {
 				continue
 			}
 			allocated.Sub(reclaimee.Resreq)
@@ -227,10 +243,12 @@ func (cp *capacityPlugin) OnSessionOpen(ssn *framework.Session) {
 			metrics.UpdateQueueAllocated(attr.name, attr.allocated.MilliCPU, attr.allocated.Memory, attr.allocated.ScalarResources)
 
 			cp.updateShare(attr)
+			cp.updateBorrowed(attr)
 			if hierarchyEnabled {
 				for _, ancestorID := range attr.ancestors {
 					ancestorAttr := cp.queueOpts[ancestorID]
 					ancestorAttr.allocated.Add(event.Task.Resreq)
+					cp.updateBorrowed(ancestorAttr)
 				}
 			}
 
@@ -244,10 +262,12 @@ func (cp *capacityPlugin) OnSessionOpen(ssn *framework.Session) {
 			metrics.UpdateQueueAllocated(attr.name, attr.allocated.MilliCPU, attr.allocated.Memory, attr.allocated.ScalarResources)
 
 			cp.updateShare(attr)
+			cp.updateBorrowed(attr)
 			if hierarchyEnabled {
 				for _, ancestorID := range attr.ancestors {
 					ancestorAttr := cp.queueOpts[ancestorID]
 					ancestorAttr.allocated.Sub(event.Task.Resreq)
+					cp.updateBorrowed(ancestorAttr)
 				}
 			}
 
@@ -260,20 +280,22 @@ func (cp *capacityPlugin) OnSessionOpen(ssn *framework.Session) {
 func (cp *capacityPlugin) OnSessionClose(ssn *framework.Session) {
 	cp.totalResource = nil
 	cp.totalGuarantee = nil
+	cp.totalReserved = nil
 	cp.queueOpts = nil
 }
 
 func (cp *capacityPlugin) buildQueueAttrs(ssn *framework.Session) {
 	for _, queue := range ssn.Queues {
+		cp.totalReserved.Add(reservedResource(queue))
 		if len(queue.Queue.Spec.Guarantee.Resource) == 0 {
 			continue
 		}
 		guarantee := api.NewResource(queue.Queue.Spec.Guarantee.Resource)
 		cp.totalGuarantee.Add(guarantee)
 	}
-	klog.V(4).Infof("The total guarantee resource is <%v>", cp.totalGuarantee)
-	// Build attributes for Queues.
-	for _, job := range ssn.Jobs {
+	klog.V(4).Infof("The total guarantee resource is <%v>, the total reserved resource is <%v>", cp.totalGuarantee, cp.totalReserved)
+	// Build attributes for Queues, the jobs of the other scheduler profiles share the queues.
+	for _, job := range ssn.AllJobs() {
 		klog.V(4).Infof("Considering Job <%s/%s>.", job.Namespace, job.Name)
 		if _, found := cp.queueOpts[job.Queue]; !found {
 			queue := ssn.Queues[job.Queue]
@@ -300,13 +322,15 @@ func (cp *capacityPlugin) buildQueueAttrs(ssn *framework.Session) {
 			if len(queue.Queue.Spec.Guarantee.Resource) != 0 {
 				attr.guarantee = api.NewResource(queue.Queue.Spec.Guarantee.Resource)
 			}
-			realCapability := api.ExceededPart(cp.totalResource, cp.totalGuarantee).Add(attr.guarantee)
+			attr.setLimits(queue)
+			realCapability := api.ExceededPart(cp.totalResource, cp.totalReserved).Add(attr.reserved)
 			if attr.capability == nil {
 				attr.realCapability = realCapability
 			} else {
 				realCapability.MinDimensionResource(attr.capability, api.Infinity)
 				attr.realCapability = realCapability
 			}
+			attr.limitBorrowing()
 			cp.queueOpts[job.Queue] = attr
 			klog.V(4).Infof("Added Queue <%s> attributes.", job.Queue)
 		}
@@ -369,6 +393,7 @@ func (cp *capacityPlugin) buildQueueAttrs(ssn *framework.Session) {
 				metrics.UpdateQueueCapacity(attr.name, attr.capability.MilliCPU, attr.capability.Memory, attr.capability.ScalarResources)
 			}
 			metrics.UpdateQueueRealCapacity(attr.name, attr.realCapability.MilliCPU, attr.realCapability.Memory, attr.realCapability.ScalarResources)
+			cp.updateBorrowed(attr)
 			continue
 		}
 		deservedCPU, deservedMem, scalarResources := 0.0, 0.0, map[v1.ResourceName]float64{}
@@ -381,16 +406,16 @@ func (cp *capacityPlugin) buildQueueAttrs(ssn *framework.Session) {
 		metrics.UpdateQueueDeserved(queueInfo.Name, deservedCPU, deservedMem, scalarResources)
 		metrics.UpdateQueueAllocated(queueInfo.Name, 0, 0, map[v1.ResourceName]float64{})
 		metrics.UpdateQueueRequest(queueInfo.Name, 0, 0, map[v1.ResourceName]float64{})
-		guarantee := api.EmptyResource()
-		if len(queue.Queue.Spec.Guarantee.Resource) != 0 {
-			guarantee = api.NewResource(queue.Queue.Spec.Guarantee.Resource)
-		}
-		realCapacity := api.ExceededPart(cp.totalResource, cp.totalGuarantee).Add(guarantee)
+		metrics.UpdateQueueBorrowed(queueInfo.Name, 0, 0, map[v1.ResourceName]float64{})
+		realCapacity := api.ExceededPart(cp.totalResource, cp.totalReserved).Add(reservedResource(queue))
 		if len(queue.Queue.Spec.Capability) > 0 {
 			capacity := api.NewResource(queue.Queue.Spec.Capability)
 			realCapacity.MinDimensionResource(capacity, api.Infinity)
 			metrics.UpdateQueueCapacity(queueInfo.Name, capacity.MilliCPU, capacity.Memory, capacity.ScalarResources)
 		}
+		if borrowingLimit := parseLimit(queue, BorrowingLimitAnnotationKey); borrowingLimit != nil {
+			realCapacity.MinDimensionResource(borrowingCapability(api.NewResource(queue.Queue.Spec.Deserved), borrowingLimit), api.Infinity)
+		}
 		metrics.UpdateQueueRealCapacity(queueInfo.Name, realCapacity.MilliCPU, realCapacity.Memory, realCapacity.ScalarResources)
 	}
 
@@ -435,7 +460,8 @@ func (cp *capacityPlugin) buildHierarchicalQueueAttrs(ssn *framework.Session) bo
 		}
 	}
 
-	for _, job := range ssn.Jobs {
+	// the jobs of the other scheduler profiles share the queues
+	for _, job := range ssn.AllJobs() {
 		klog.V(4).Infof("Considering Job <%s/%s>.", job.Namespace, job.Name)
 		attr := cp.queueOpts[job.Queue]
 		if len(attr.children) > 0 {
@@ -517,6 +543,7 @@ func (cp *capacityPlugin) buildHierarchicalQueueAttrs(ssn *framework.Session) bo
 		metrics.UpdateQueueDeserved(attr.name, attr.deserved.MilliCPU, attr.deserved.Memory, attr.deserved.ScalarResources)
 		metrics.UpdateQueueAllocated(attr.name, attr.allocated.MilliCPU, attr.allocated.Memory, attr.allocated.ScalarResources)
 		metrics.UpdateQueueRequest(attr.name, attr.request.MilliCPU, attr.request.Memory, attr.request.ScalarResources)
+		cp.updateBorrowed(attr)
 	}
 
 	ssn.AddQueueOrderFn(cp.Name(), func(l, r interface{}) int {
@@ -613,6 +640,7 @@ func (cp *capacityPlugin) newQueueAttr(queue *api.QueueInfo) *queueAttr {
 	if len(queue.Queue.Spec.Guarantee.Resource) != 0 {
 		attr.guarantee = api.NewResource(queue.Queue.Spec.Guarantee.Resource)
 	}
+	attr.setLimits(queue)
 
 	return attr
 }
@@ -647,10 +675,12 @@ func (cp *capacityPlugin) updateAncestors(queue *api.QueueInfo, ssn *framework.S
 
 func (cp *capacityPlugin) checkHierarchicalQueue(attr *queueAttr) error {
 	totalGuarantee := api.EmptyResource()
+	totalReserved := api.EmptyResource()
 	totalDeserved := api.EmptyResource()
 	for _, childAttr := range attr.children {
 		totalDeserved.Add(childAttr.deserved)
 		totalGuarantee.Add(childAttr.guarantee)
+		totalReserved.Add(childAttr.reserved)
 		// if the user does not set CPU or memory in capability, we set the value to be the same as parent(we do not consider the situation where the user sets CPU or memory<=0)
 		if childAttr.capability.MilliCPU <= 0 {
 			childAttr.capability.MilliCPU = attr.capability.MilliCPU
@@ -670,13 +700,14 @@ func (cp *capacityPlugin) checkHierarchicalQueue(attr *queueAttr) error {
 	}
 
 	for _, childAttr := range attr.children {
-		realCapability := api.ExceededPart(attr.realCapability, totalGuarantee).Add(childAttr.guarantee)
+		realCapability := api.ExceededPart(attr.realCapability, totalReserved).Add(childAttr.reserved)
 		if childAttr.capability == nil {
 			childAttr.realCapability = realCapability
 		} else {
 			realCapability.MinDimensionResource(childAttr.capability, api.Infinity)
 			childAttr.realCapability = realCapability
 		}
+		childAttr.limitBorrowing()
 		oldDeserved := childAttr.deserved.Clone()
 		childAttr.deserved.MinDimensionResource(childAttr.realCapability, api.Infinity)
 		childAttr.deserved.MinDimensionResource(childAttr.request, api.Zero)
@@ -17,8 +17,10 @@ limitations under the License.
 package capacity
 
 import (
+	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -34,6 +36,7 @@ import (
 	"volcano.sh/volcano/pkg/scheduler/framework"
 	"volcano.sh/volcano/pkg/scheduler/plugins/gang"
 	"volcano.sh/volcano/pkg/scheduler/plugins/predicates"
+	pluginutil "volcano.sh/volcano/pkg/scheduler/plugins/util"
 	"volcano.sh/volcano/pkg/scheduler/uthelper"
 	"volcano.sh/volcano/pkg/scheduler/util"
 )
@@ -564,3 +567,278 @@ func buildQueueWithParents(name string, parent string, deserved corev1.ResourceL
 	queue.Spec.Parent = parent
 	return queue
 }
+
+func TestBorrowingAndLendingLimits(t *testing.T) {
+	plugins := map[string]framework.PluginBuilder{PluginName: New, predicates.PluginName: predicates.New}
+	trueValue := true
+	actions := []framework.Action{enqueue.New(), allocate.New()}
+
+	// nodes
+	n1 := util.BuildNode("n1", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), map[string]string{})
+	n2 := util.BuildNode("n2", api.BuildResourceList("8", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), map[string]string{})
+
+	// resources for test case 0
+	p1 := util.BuildPod("ns1", "p1", "n1", corev1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg1", make(map[string]string), make(map[string]string))
+	p2 := util.BuildPod("ns1", "p2", "", corev1.PodPending, api.BuildResourceList("1", "1Gi"), "pg2", make(map[string]string), make(map[string]string))
+	p3 := util.BuildPod("ns1", "p3", "", corev1.PodPending, api.BuildResourceList("1", "1Gi"), "pg3", make(map[string]string), make(map[string]string))
+	pg1 := util.BuildPodGroup("pg1", "ns1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning)
+	pg2 := util.BuildPodGroup("pg2", "ns1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue)
+	pg3 := util.BuildPodGroup("pg3", "ns1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue)
+	queue1 := buildQueueWithLimits(util.BuildQueueWithResourcesQuantity("q1", api.BuildResourceList("1", "2Gi"), nil), `{"cpu": "1"}`, "")
+
+	// resources for test case 1
+	p4 := util.BuildPod("ns1", "p4", "", corev1.PodPending, api.BuildResourceList("1", "1Gi"), "pg4", make(map[string]string), make(map[string]string))
+	p5 := util.BuildPod("ns1", "p5", "", corev1.PodPending, api.BuildResourceList("1", "1Gi"), "pg5", make(map[string]string), make(map[string]string))
+	p6 := util.BuildPod("ns1", "p6", "", corev1.PodPending, api.BuildResourceList("1", "1Gi"), "pg6", make(map[string]string), make(map[string]string))
+	p7 := util.BuildPod("ns1", "p7", "", corev1.PodPending, api.BuildResourceList("1", "1Gi"), "pg7", make(map[string]string), make(map[string]string))
+	pg4 := util.BuildPodGroup("pg4", "ns1", "q3", 1, nil, schedulingv1beta1.PodGroupInqueue)
+	pg5 := util.BuildPodGroup("pg5", "ns1", "q3", 1, nil, schedulingv1beta1.PodGroupInqueue)
+	pg6 := util.BuildPodGroup("pg6", "ns1", "q3", 1, nil, schedulingv1beta1.PodGroupInqueue)
+	pg7 := util.BuildPodGroup("pg7", "ns1", "q3", 1, nil, schedulingv1beta1.PodGroupInqueue)
+	// q2 is idle and lends at most 1 cpu of its deserved 2 cpu to other queues
+	queue2 := buildQueueWithLimits(util.BuildQueueWithResourcesQuantity("q2", api.BuildResourceList("2", "4Gi"), nil), "", `{"cpu": "1"}`)
+	queue3 := util.BuildQueueWithResourcesQuantity("q3", nil, nil)
+
+	// resources for test case 2
+	p8 := util.BuildPod("ns1", "p8", "n1", corev1.PodRunning, api.BuildResourceList("2", "1Gi"), "pg8", make(map[string]string), make(map[string]string))
+	pg8 := util.BuildPodGroupWithMinResources("pg8", "ns1", "q4", 1, nil, api.BuildResourceList("2", "1Gi"), schedulingv1beta1.PodGroupRunning)
+	pg9 := util.BuildPodGroupWithMinResources("pg9", "ns1", "q4", 1, nil, api.BuildResourceList("1", "1Gi"), schedulingv1beta1.PodGroupPending)
+	queue4 := buildQueueWithLimits(util.BuildQueueWithResourcesQuantity("q4", api.BuildResourceList("1", "1Gi"), nil), `{"cpu": "1"}`, "")
+
+	// resources for test case 3
+	p10 := util.BuildPod("ns1", "p10", "", corev1.PodPending, api.BuildResourceList("1", "1Gi"), "pg10", make(map[string]string), make(map[string]string))
+	p11 := util.BuildPod("ns1", "p11", "", corev1.PodPending, api.BuildResourceList("1", "1Gi"), "pg11", make(map[string]string), make(map[string]string))
+	p12 := util.BuildPod("ns1", "p12", "", corev1.PodPending, api.BuildResourceList("1", "1Gi"), "pg12", make(map[string]string), make(map[string]string))
+	pg10 := util.BuildPodGroup("pg10", "ns1", "q51", 1, nil, schedulingv1beta1.PodGroupInqueue)
+	pg11 := util.BuildPodGroup("pg11", "ns1", "q51", 1, nil, schedulingv1beta1.PodGroupInqueue)
+	pg12 := util.BuildPodGroup("pg12", "ns1", "q51", 1, nil, schedulingv1beta1.PodGroupInqueue)
+	root := buildQueueWithParents("root", "", nil, nil)
+	queue5 := buildQueueWithParents("q5", "root", api.BuildResourceList("4", "4Gi"), api.BuildResourceList("8", "8Gi"))
+	queue51 := buildQueueWithLimits(buildQueueWithParents("q51", "q5", api.BuildResourceList("1", "1Gi"), nil), `{"cpu": "1"}`, "")
+
+	tests := []uthelper.TestCommonStruct{
+		{
+			Name:           "case0: queue can not borrow beyond its borrowing limit",
+			Plugins:        plugins,
+			Pods:           []*corev1.Pod{p1, p2, p3},
+			Nodes:          []*corev1.Node{n1},
+			PodGroups:      []*schedulingv1beta1.PodGroup{pg1, pg2, pg3},
+			Queues:         []*schedulingv1beta1.Queue{queue1},
+			ExpectBindMap:  map[string]string{"ns1/p2": "n1"},
+			ExpectBindsNum: 1,
+		},
+		{
+			Name:           "case1: queue can not use the deserved of an idle queue beyond its lending limit",
+			Plugins:        plugins,
+			Pods:           []*corev1.Pod{p4, p5, p6, p7},
+			Nodes:          []*corev1.Node{n1},
+			PodGroups:      []*schedulingv1beta1.PodGroup{pg4, pg5, pg6, pg7},
+			Queues:         []*schedulingv1beta1.Queue{queue2, queue3},
+			ExpectBindMap:  map[string]string{"ns1/p4": "n1", "ns1/p5": "n1", "ns1/p6": "n1"},
+			ExpectBindsNum: 3,
+		},
+		{
+			Name:           "case2: job can not be enqueued beyond the borrowing limit of its queue",
+			Plugins:        plugins,
+			Pods:           []*corev1.Pod{p8},
+			Nodes:          []*corev1.Node{n1},
+			PodGroups:      []*schedulingv1beta1.PodGroup{pg8, pg9},
+			Queues:         []*schedulingv1beta1.Queue{queue4},
+			ExpectStatus:   map[api.JobID]scheduling.PodGroupPhase{"ns1/pg9": scheduling.PodGroupPending},
+			ExpectBindsNum: 0,
+		},
+	}
+	hierarchicalTests := []uthelper.TestCommonStruct{
+		{
+			Name:           "case3: leaf queue can not borrow beyond its borrowing limit in hierarchy",
+			Plugins:        plugins,
+			Pods:           []*corev1.Pod{p10, p11, p12},
+			Nodes:          []*corev1.Node{n2},
+			PodGroups:      []*schedulingv1beta1.PodGroup{pg10, pg11, pg12},
+			Queues:         []*schedulingv1beta1.Queue{root, queue5, queue51},
+			ExpectBindMap:  map[string]string{"ns1/p10": "n2", "ns1/p11": "n2"},
+			ExpectBindsNum: 2,
+		},
+	}
+
+	tiers := func(hierarchy bool) []conf.Tier {
+		return []conf.Tier{
+			{
+				Plugins: []conf.PluginOption{
+					{
+						Name:               PluginName,
+						EnabledAllocatable: &trueValue,
+						EnabledJobEnqueued: &trueValue,
+						EnabledQueueOrder:  &trueValue,
+						EnabledHierarchy:   &hierarchy,
+					},
+					{
+						Name:             predicates.PluginName,
+						EnabledPredicate: &trueValue,
+					},
+				},
+			},
+		}
+	}
+	for i, test := range append(tests, hierarchicalTests...) {
+		t.Run(test.Name, func(t *testing.T) {
+			test.RegisterSession(tiers(i >= len(tests)), nil)
+			defer test.Close()
+			test.Run(actions)
+			if err := test.CheckAll(i); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func buildQueueWithLimits(queue *schedulingv1beta1.Queue, borrowingLimit, lendingLimit string) *schedulingv1beta1.Queue {
+	queue.Annotations = map[string]string{}
+	if borrowingLimit != "" {
+		queue.Annotations[BorrowingLimitAnnotationKey] = borrowingLimit
+	}
+	if lendingLimit != "" {
+		queue.Annotations[LendingLimitAnnotationKey] = lendingLimit
+	}
+	return queue
+}
+
+func TestScheduledQuotas(t *testing.T) {
+	plugins := map[string]framework.PluginBuilder{PluginName: New, predicates.PluginName: predicates.New}
+	trueValue := true
+	actions := []framework.Action{allocate.New()}
+
+	n1 := util.BuildNode("n1", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), map[string]string{})
+	p1 := util.BuildPod("ns1", "p1", "", corev1.PodPending, api.BuildResourceList("1", "1Gi"), "pg1", make(map[string]string), make(map[string]string))
+	p2 := util.BuildPod("ns1", "p2", "", corev1.PodPending, api.BuildResourceList("1", "1Gi"), "pg2", make(map[string]string), make(map[string]string))
+	p3 := util.BuildPod("ns1", "p3", "", corev1.PodPending, api.BuildResourceList("1", "1Gi"), "pg3", make(map[string]string), make(map[string]string))
+	pg1 := util.BuildPodGroup("pg1", "ns1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue)
+	pg2 := util.BuildPodGroup("pg2", "ns1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue)
+	pg3 := util.BuildPodGroup("pg3", "ns1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue)
+
+	queue1 := util.BuildQueueWithResourcesQuantity("q1", api.BuildResourceList("1", "1Gi"), api.BuildResourceList("1", "4Gi"))
+	// the window lasting the whole day is always active
+	queue1.Annotations = map[string]string{pluginutil.ScheduledQuotasAnnotationKey: `[{"name": "all-day", "window": "00:00-00:00", "capability": {"cpu": "2"}}]`}
+
+	p4 := util.BuildPod("ns1", "p4", "", corev1.PodPending, api.BuildResourceList("1", "1Gi"), "pg4", make(map[string]string), make(map[string]string))
+	p5 := util.BuildPod("ns1", "p5", "", corev1.PodPending, api.BuildResourceList("1", "1Gi"), "pg5", make(map[string]string), make(map[string]string))
+	pg4 := util.BuildPodGroup("pg4", "ns1", "q2", 1, nil, schedulingv1beta1.PodGroupInqueue)
+	pg5 := util.BuildPodGroup("pg5", "ns1", "q2", 1, nil, schedulingv1beta1.PodGroupInqueue)
+	queue2 := util.BuildQueueWithResourcesQuantity("q2", api.BuildResourceList("1", "1Gi"), api.BuildResourceList("1", "4Gi"))
+	// the window starting on the day after tomorrow is not active
+	queue2.Annotations = map[string]string{pluginutil.ScheduledQuotasAnnotationKey: fmt.Sprintf(
+		`[{"name": "later", "window": "00:00-00:00", "weekdays": [%q], "capability": {"cpu": "2"}}]`, time.Now().AddDate(0, 0, 2).Weekday())}
+
+	tests := []uthelper.TestCommonStruct{
+		{
+			Name:           "case0: the capability of queue is overridden in the active quota window",
+			Plugins:        plugins,
+			Pods:           []*corev1.Pod{p1, p2, p3},
+			Nodes:          []*corev1.Node{n1},
+			PodGroups:      []*schedulingv1beta1.PodGroup{pg1, pg2, pg3},
+			Queues:         []*schedulingv1beta1.Queue{queue1},
+			ExpectBindMap:  map[string]string{"ns1/p1": "n1", "ns1/p2": "n1"},
+			ExpectBindsNum: 2,
+		},
+		{
+			Name:           "case1: the capability of queue is not overridden out of the quota window",
+			Plugins:        plugins,
+			Pods:           []*corev1.Pod{p4, p5},
+			Nodes:          []*corev1.Node{n1},
+			PodGroups:      []*schedulingv1beta1.PodGroup{pg4, pg5},
+			Queues:         []*schedulingv1beta1.Queue{queue2},
+			ExpectBindMap:  map[string]string{"ns1/p4": "n1"},
+			ExpectBindsNum: 1,
+		},
+	}
+
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:               PluginName,
+					EnabledAllocatable: &trueValue,
+					EnabledQueueOrder:  &trueValue,
+				},
+				{
+					Name:             predicates.PluginName,
+					EnabledPredicate: &trueValue,
+				},
+			},
+		},
+	}
+	for i, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			test.RegisterSession(tiers, nil)
+			defer test.Close()
+			test.Run(actions)
+			if err := test.CheckAll(i); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestQueueSharedByProfiles(t *testing.T) {
+	plugins := map[string]framework.PluginBuilder{PluginName: New, predicates.PluginName: predicates.New}
+	trueValue := true
+	actions := []framework.Action{allocate.New()}
+
+	n1 := util.BuildNode("n1", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), map[string]string{})
+	// p1 is scheduled by the other profile, but it still uses the capability of the queue
+	p1 := util.BuildPod("ns1", "p1", "n1", corev1.PodRunning, api.BuildResourceList("2", "2Gi"), "pg1", make(map[string]string), make(map[string]string))
+	p2 := util.BuildPod("ns1", "p2", "", corev1.PodPending, api.BuildResourceList("1", "1Gi"), "pg2", make(map[string]string), make(map[string]string))
+	pg1 := util.BuildPodGroup("pg1", "ns1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning)
+	pg2 := util.BuildPodGroup("pg2", "ns1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue)
+	queue1 := util.BuildQueueWithResourcesQuantity("q1", api.BuildResourceList("2", "2Gi"), api.BuildResourceList("2", "4Gi"))
+
+	tests := []uthelper.TestCommonStruct{
+		{
+			Name:           "case0: the queue is full with the jobs of the session",
+			Plugins:        plugins,
+			Pods:           []*corev1.Pod{p1, p2},
+			Nodes:          []*corev1.Node{n1},
+			PodGroups:      []*schedulingv1beta1.PodGroup{pg1, pg2},
+			Queues:         []*schedulingv1beta1.Queue{queue1},
+			ExpectBindMap:  map[string]string{},
+			ExpectBindsNum: 0,
+		},
+		{
+			Name:           "case1: the queue is full with the jobs of the other profile",
+			Plugins:        plugins,
+			Pods:           []*corev1.Pod{p1, p2},
+			Nodes:          []*corev1.Node{n1},
+			PodGroups:      []*schedulingv1beta1.PodGroup{pg1, pg2},
+			Queues:         []*schedulingv1beta1.Queue{queue1},
+			JobFilter:      func(job *api.JobInfo) bool { return job.Name != "pg1" },
+			ExpectBindMap:  map[string]string{},
+			ExpectBindsNum: 0,
+		},
+	}
+
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:               PluginName,
+					EnabledAllocatable: &trueValue,
+					EnabledQueueOrder:  &trueValue,
+				},
+				{
+					Name:             predicates.PluginName,
+					EnabledPredicate: &trueValue,
+				},
+			},
+		},
+	}
+	for i, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			test.RegisterSession(tiers, nil)
+			defer test.Close()
+			test.Run(actions)
+			if err := test.CheckAll(i); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
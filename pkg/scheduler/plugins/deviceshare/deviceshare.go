@@ -46,6 +46,16 @@ const (
 	ScheduleWeight         = "deviceshare.ScheduleWeight"
 )
 
+// ArgumentsSchema is the schema of the arguments of deviceshare plugin
+var ArgumentsSchema = framework.ArgumentsSchema{
+	GPUSharingPredicate:    framework.BoolArgument,
+	NodeLockEnable:         framework.BoolArgument,
+	GPUNumberPredicate:     framework.BoolArgument,
+	VGPUEnable:             framework.BoolArgument,
+	SchedulePolicyArgument: framework.StringArgument,
+	ScheduleWeight:         framework.IntArgument,
+}
+
 type deviceSharePlugin struct {
 	// Arguments given for the plugin
 	pluginArguments framework.Arguments
@@ -112,6 +112,8 @@ type drfPlugin struct {
 
 	// hierarchical tree root
 	hierarchicalRoot *hierarchicalNode
+	// queueHierarchies are the paths of the queues in the hierarchical tree
+	queueHierarchies map[api.QueueID]queueHierarchy
 
 	// Arguments given for the plugin
 	pluginArguments framework.Arguments
@@ -131,7 +133,8 @@ func New(arguments framework.Arguments) framework.Plugin {
 			weight:    1,
 			children:  map[string]*hierarchicalNode{},
 		},
-		pluginArguments: arguments,
+		queueHierarchies: map[api.QueueID]queueHierarchy{},
+		pluginArguments:  arguments,
 	}
 }
 
@@ -154,9 +157,9 @@ func (drf *drfPlugin) HierarchyEnabled(ssn *framework.Session) bool {
 
 func (drf *drfPlugin) compareQueues(root *hierarchicalNode, lqueue *api.QueueInfo, rqueue *api.QueueInfo) float64 {
 	lnode := root
-	lpaths := strings.Split(lqueue.Hierarchy, "/")
+	lpaths := strings.Split(drf.queueHierarchies[lqueue.UID].path, "/")
 	rnode := root
-	rpaths := strings.Split(rqueue.Hierarchy, "/")
+	rpaths := strings.Split(drf.queueHierarchies[rqueue.UID].path, "/")
 	for i, depth := 0, min(len(lpaths), len(rpaths)); i < depth; i++ {
 		// Saturated nodes have minimum priority,
 		// so that demanding nodes will be popped first.
@@ -185,6 +188,9 @@ func (drf *drfPlugin) OnSessionOpen(ssn *framework.Session) {
 	klog.V(4).Infof("Total Allocatable %s", drf.totalResource)
 
 	hierarchyEnabled := drf.HierarchyEnabled(ssn)
+	if hierarchyEnabled {
+		drf.buildQueueHierarchies(ssn)
+	}
 
 	for _, job := range ssn.Jobs {
 		attr := &drfAttr{
@@ -207,7 +213,7 @@ func (drf *drfPlugin) OnSessionOpen(ssn *framework.Session) {
 		if hierarchyEnabled {
 			queue := ssn.Queues[job.Queue]
 			drf.totalAllocated.Add(attr.allocated)
-			drf.UpdateHierarchicalShare(drf.hierarchicalRoot, drf.totalAllocated, job, attr, queue.Hierarchy, queue.Weights)
+			drf.UpdateHierarchicalShare(drf.hierarchicalRoot, drf.totalAllocated, job, attr, drf.queueHierarchies[queue.UID])
 		}
 	}
 
@@ -276,7 +282,7 @@ func (drf *drfPlugin) OnSessionOpen(ssn *framework.Session) {
 			lattr.allocated.Add(reclaimer.Resreq)
 			totalAllocated.Add(reclaimer.Resreq)
 			drf.updateShare(lattr)
-			drf.UpdateHierarchicalShare(root, totalAllocated, ljob, lattr, lqueue.Hierarchy, lqueue.Weights)
+			drf.UpdateHierarchicalShare(root, totalAllocated, ljob, lattr, drf.queueHierarchies[lqueue.UID])
 
 			for _, preemptee := range reclaimees {
 				rjob := ssn.Jobs[preemptee.Job]
@@ -291,7 +297,7 @@ func (drf *drfPlugin) OnSessionOpen(ssn *framework.Session) {
 				}
 				rattr.allocated.Sub(preemptee.Resreq)
 				drf.updateShare(rattr)
-				drf.UpdateHierarchicalShare(root, totalAllocated, rjob, rattr, rqueue.Hierarchy, rqueue.Weights)
+				drf.UpdateHierarchicalShare(root, totalAllocated, rjob, rattr, drf.queueHierarchies[rqueue.UID])
 
 				// compare hdrf of queues
 				ret := drf.compareQueues(root, lqueue, rqueue)
@@ -300,7 +306,7 @@ func (drf *drfPlugin) OnSessionOpen(ssn *framework.Session) {
 				totalAllocated.Add(preemptee.Resreq)
 				rattr.allocated.Add(preemptee.Resreq)
 				drf.updateShare(rattr)
-				drf.UpdateHierarchicalShare(root, totalAllocated, rjob, rattr, rqueue.Hierarchy, rqueue.Weights)
+				drf.UpdateHierarchicalShare(root, totalAllocated, rjob, rattr, drf.queueHierarchies[rqueue.UID])
 
 				if ret < 0 {
 					victims = append(victims, preemptee)
@@ -352,7 +358,7 @@ func (drf *drfPlugin) OnSessionOpen(ssn *framework.Session) {
 				queue := ssn.Queues[job.Queue]
 
 				drf.totalAllocated.Add(event.Task.Resreq)
-				drf.UpdateHierarchicalShare(drf.hierarchicalRoot, drf.totalAllocated, job, attr, queue.Hierarchy, queue.Weights)
+				drf.UpdateHierarchicalShare(drf.hierarchicalRoot, drf.totalAllocated, job, attr, drf.queueHierarchies[queue.UID])
 			}
 
 			klog.V(4).Infof("DRF AllocateFunc: task <%v/%v>, resreq <%v>,  share <%v>, namespace share <%v>",
@@ -370,7 +376,7 @@ func (drf *drfPlugin) OnSessionOpen(ssn *framework.Session) {
 			if hierarchyEnabled {
 				queue := ssn.Queues[job.Queue]
 				drf.totalAllocated.Sub(event.Task.Resreq)
-				drf.UpdateHierarchicalShare(drf.hierarchicalRoot, drf.totalAllocated, job, attr, queue.Hierarchy, queue.Weights)
+				drf.UpdateHierarchicalShare(drf.hierarchicalRoot, drf.totalAllocated, job, attr, drf.queueHierarchies[queue.UID])
 			}
 
 			klog.V(4).Infof("DRF EvictFunc: task <%v/%v>, resreq <%v>,  share <%v>, namespace share <%v>",
@@ -472,7 +478,7 @@ func (drf *drfPlugin) updateHierarchicalShare(node *hierarchicalNode,
 	}
 }
 
-func (drf *drfPlugin) UpdateHierarchicalShare(root *hierarchicalNode, totalAllocated *api.Resource, job *api.JobInfo, attr *drfAttr, hierarchy, hierarchicalWeights string) {
+func (drf *drfPlugin) UpdateHierarchicalShare(root *hierarchicalNode, totalAllocated *api.Resource, job *api.JobInfo, attr *drfAttr, hierarchy queueHierarchy) {
 	// filter out demanding resources
 	demandingResources := map[v1.ResourceName]bool{}
 	for _, rn := range drf.totalResource.ResourceNames() {
@@ -480,7 +486,7 @@ func (drf *drfPlugin) UpdateHierarchicalShare(root *hierarchicalNode, totalAlloc
 			demandingResources[rn] = true
 		}
 	}
-	drf.buildHierarchy(root, job, attr, hierarchy, hierarchicalWeights)
+	drf.buildHierarchy(root, job, attr, hierarchy.path, hierarchy.weights)
 	drf.updateHierarchicalShare(root, demandingResources)
 }
 
@@ -512,4 +518,5 @@ func (drf *drfPlugin) OnSessionClose(session *framework.Session) {
 	drf.totalResource = api.EmptyResource()
 	drf.totalAllocated = api.EmptyResource()
 	drf.jobAttrs = map[api.JobID]*drfAttr{}
+	drf.queueHierarchies = map[api.QueueID]queueHierarchy{}
 }
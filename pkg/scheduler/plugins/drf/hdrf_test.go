@@ -7,6 +7,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 
+	"volcano.sh/apis/pkg/apis/scheduling"
 	schedulingv1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 	"volcano.sh/volcano/cmd/scheduler/app/options"
 	"volcano.sh/volcano/pkg/scheduler/actions/allocate"
@@ -18,6 +19,10 @@ import (
 	"volcano.sh/volcano/pkg/scheduler/util"
 )
 
+func init() {
+	options.Default()
+}
+
 func makePods(num int, cpu, mem, podGroupName string) []*v1.Pod {
 	pods := []*v1.Pod{}
 	for i := 0; i < num; i++ {
@@ -38,8 +43,6 @@ func mergePods(pods ...[]*v1.Pod) []*v1.Pod {
 }
 
 func TestHDRF(t *testing.T) {
-	options.Default()
-
 	plugins := map[string]framework.PluginBuilder{PluginName: New, proportion.PluginName: proportion.New}
 	tests := []struct {
 		uthelper.TestCommonStruct
@@ -197,3 +200,135 @@ func TestHDRF(t *testing.T) {
 		})
 	}
 }
+
+func buildQueueWithParent(name, parent string, weight int32, annotations map[string]string) *schedulingv1.Queue {
+	queue := util.BuildQueueWithAnnos(name, weight, nil, annotations)
+	queue.Spec.Parent = parent
+	return queue
+}
+
+func TestBuildQueueHierarchy(t *testing.T) {
+	queues := []*schedulingv1.Queue{
+		buildQueueWithParent("root", "", 1, nil),
+		buildQueueWithParent("eng", "root", 2, nil),
+		buildQueueWithParent("dev", "eng", 3, nil),
+		buildQueueWithParent("legacy", "root", 1, map[string]string{
+			schedulingv1.KubeHierarchyAnnotationKey:       "root/sci",
+			schedulingv1.KubeHierarchyWeightAnnotationKey: "1/5",
+		}),
+		buildQueueWithParent("orphan", "missing", 4, nil),
+		buildQueueWithParent("loop-a", "loop-b", 1, nil),
+		buildQueueWithParent("loop-b", "loop-a", 1, nil),
+	}
+	ssn := &framework.Session{Queues: map[api.QueueID]*api.QueueInfo{}}
+	for _, queue := range queues {
+		queueInfo := api.NewQueueInfo(&scheduling.Queue{
+			ObjectMeta: queue.ObjectMeta,
+			Spec:       scheduling.QueueSpec{Weight: queue.Spec.Weight, Parent: queue.Spec.Parent},
+		})
+		ssn.Queues[queueInfo.UID] = queueInfo
+	}
+
+	expected := map[api.QueueID]queueHierarchy{
+		"root":   {path: "root", weights: "1"},
+		"eng":    {path: "root/eng", weights: "1/2"},
+		"dev":    {path: "root/eng/dev", weights: "1/2/3"},
+		"legacy": {path: "root/sci", weights: "1/5"},
+		"orphan": {path: "root/orphan", weights: "1/4"},
+		"loop-a": {path: "root/loop-a", weights: "1/1"},
+		"loop-b": {path: "root/loop-b", weights: "1/1"},
+	}
+	for queueID, want := range expected {
+		if got := buildQueueHierarchy(ssn, ssn.Queues[queueID]); got != want {
+			t.Errorf("queue %s: expected hierarchy %+v, got %+v", queueID, want, got)
+		}
+	}
+}
+
+func TestHDRFWithQueueParents(t *testing.T) {
+	plugins := map[string]framework.PluginBuilder{PluginName: New}
+	podGroups := []*schedulingv1.PodGroup{
+		util.BuildPodGroup("pg1", "default", "sci", 0, nil, schedulingv1.PodGroupInqueue),
+		util.BuildPodGroup("pg21", "default", "dev", 0, nil, schedulingv1.PodGroupInqueue),
+		util.BuildPodGroup("pg22", "default", "prod", 0, nil, schedulingv1.PodGroupInqueue),
+	}
+	nodes := []*v1.Node{util.BuildNode("n", api.BuildResourceList("10", "10G", []api.ScalarResource{{Name: "pods", Value: "50"}}...), make(map[string]string))}
+	// the dominant shares of sci and eng are balanced, and eng is divided between dev and prod
+	expected := map[string]*api.Resource{
+		"pg1":  {MilliCPU: 4000, Memory: 4000000000, ScalarResources: map[v1.ResourceName]float64{"pods": 4}},
+		"pg21": {MilliCPU: 6000, Memory: 0, ScalarResources: map[v1.ResourceName]float64{"pods": 6}},
+		"pg22": {MilliCPU: 0, Memory: 6000000000, ScalarResources: map[v1.ResourceName]float64{"pods": 6}},
+	}
+
+	tests := []uthelper.TestCommonStruct{
+		{
+			Name:      "the queue tree of spec.parent drives the hierarchy",
+			Plugins:   plugins,
+			PodGroups: podGroups,
+			Pods: mergePods(
+				makePods(10, "1", "1G", "pg1"),
+				makePods(10, "1", "0G", "pg21"),
+				makePods(10, "0", "1G", "pg22"),
+			),
+			Queues: []*schedulingv1.Queue{
+				buildQueueWithParent("root", "", 1, nil),
+				buildQueueWithParent("sci", "root", 1, nil),
+				buildQueueWithParent("eng", "root", 1, nil),
+				buildQueueWithParent("dev", "eng", 1, nil),
+				buildQueueWithParent("prod", "eng", 1, nil),
+			},
+			Nodes: nodes,
+		},
+		{
+			Name:      "the deprecated annotations build the same hierarchy",
+			Plugins:   plugins,
+			PodGroups: podGroups,
+			Pods: mergePods(
+				makePods(10, "1", "1G", "pg1"),
+				makePods(10, "1", "0G", "pg21"),
+				makePods(10, "0", "1G", "pg22"),
+			),
+			Queues: []*schedulingv1.Queue{
+				buildQueueWithParent("sci", "root", 1, map[string]string{
+					schedulingv1.KubeHierarchyAnnotationKey:       "root/sci",
+					schedulingv1.KubeHierarchyWeightAnnotationKey: "1/1",
+				}),
+				buildQueueWithParent("dev", "root", 1, map[string]string{
+					schedulingv1.KubeHierarchyAnnotationKey:       "root/eng/dev",
+					schedulingv1.KubeHierarchyWeightAnnotationKey: "1/1/1",
+				}),
+				buildQueueWithParent("prod", "root", 1, map[string]string{
+					schedulingv1.KubeHierarchyAnnotationKey:       "root/eng/prod",
+					schedulingv1.KubeHierarchyWeightAnnotationKey: "1/1/1",
+				}),
+			},
+			Nodes: nodes,
+		},
+	}
+
+	trueValue := true
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:              PluginName,
+					EnabledHierarchy:  &trueValue,
+					EnabledQueueOrder: &trueValue,
+					EnabledJobOrder:   &trueValue,
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			ssn := test.RegisterSession(tiers, nil)
+			defer test.Close()
+			test.Run([]framework.Action{allocate.New()})
+			for _, job := range ssn.Jobs {
+				if !equality.Semantic.DeepEqual(expected[job.Name], job.Allocated) {
+					t.Errorf("job %s expected resource %s, but got %s", job.Name, expected[job.Name], job.Allocated)
+				}
+			}
+		})
+	}
+}
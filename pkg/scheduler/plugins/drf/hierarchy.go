@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drf
+
+import (
+	"strconv"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+)
+
+const rootQueueID = "root"
+
+// queueHierarchy is the path of a queue in the hierarchical tree from the root, and the weights of the nodes along
+// the path, both are separated by slashes, e.g. root/eng/dev and 1/2/1.
+type queueHierarchy struct {
+	path    string
+	weights string
+}
+
+// buildQueueHierarchies builds the paths of the queues from the tree of Queue.spec.parent, which is the same tree as
+// the one of the capacity and proportion plugins. The weight of a node is the weight of the queue.
+func (drf *drfPlugin) buildQueueHierarchies(ssn *framework.Session) {
+	for _, queue := range ssn.Queues {
+		drf.queueHierarchies[queue.UID] = buildQueueHierarchy(ssn, queue)
+		klog.V(4).Infof("Queue <%s> has hierarchy <%s> with weights <%s>",
+			queue.Name, drf.queueHierarchies[queue.UID].path, drf.queueHierarchies[queue.UID].weights)
+	}
+}
+
+func buildQueueHierarchy(ssn *framework.Session, queue *api.QueueInfo) queueHierarchy {
+	// The queues right under the root queue may still use the deprecated annotations, the queue controller sets
+	// the parent of a queue to the root queue if it's not set.
+	parent := queue.Queue.Spec.Parent
+	if queue.Hierarchy != "" && (parent == "" || parent == rootQueueID) {
+		klog.V(3).Infof("Queue <%s> uses the deprecated hierarchy annotations, set spec.parent instead", queue.Name)
+		return queueHierarchy{path: queue.Hierarchy, weights: queue.Weights}
+	}
+
+	fallback := queueHierarchy{
+		path:    rootQueueID + "/" + queue.Name,
+		weights: "1/" + strconv.Itoa(int(queue.Weight)),
+	}
+	var paths, weights []string
+	visited := map[api.QueueID]bool{}
+	for current := queue; current.Name != rootQueueID; {
+		if visited[current.UID] {
+			klog.Errorf("The queue <%s> is in a cycle of parent queues, it's put under the root queue", queue.Name)
+			return fallback
+		}
+		visited[current.UID] = true
+		paths = append(paths, current.Name)
+		weights = append(weights, strconv.Itoa(int(current.Weight)))
+
+		parent := current.Queue.Spec.Parent
+		if parent == "" || parent == rootQueueID {
+			break
+		}
+		next, found := ssn.Queues[api.QueueID(parent)]
+		if !found {
+			klog.Errorf("The queue <%s> has invalid parent queue <%s>, it's put under the root queue", current.Name, parent)
+			return fallback
+		}
+		current = next
+	}
+	paths = append(paths, rootQueueID)
+	weights = append(weights, "1")
+
+	for i, j := 0, len(paths)-1; i < j; i, j = i+1, j-1 {
+		paths[i], paths[j] = paths[j], paths[i]
+		weights[i], weights[j] = weights[j], weights[i]
+	}
+	return queueHierarchy{path: strings.Join(paths, "/"), weights: strings.Join(weights, "/")}
+}
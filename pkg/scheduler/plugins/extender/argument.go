@@ -71,3 +71,94 @@ type JobReadyRequest struct {
 type JobReadyResponse struct {
 	Status bool `json:"status"`
 }
+
+type BatchPredicateRequest struct {
+	Task  *api.TaskInfo   `json:"task"`
+	Nodes []*api.NodeInfo `json:"nodes"`
+}
+
+type BatchPredicateResponse struct {
+	// Results are the predicate results of nodes: node name -> result, the nodes absent are accepted
+	Results      map[string]*PredicateResponse `json:"results"`
+	ErrorMessage string                        `json:"errorMessage"`
+}
+
+type JobOrderRequest struct {
+	Left  *api.JobInfo `json:"left"`
+	Right *api.JobInfo `json:"right"`
+}
+
+type JobOrderResponse struct {
+	// Result is -1 if left should be scheduled first, 1 if right should be scheduled first, otherwise 0
+	Result int `json:"result"`
+}
+
+type QueueOrderRequest struct {
+	Left  *api.QueueInfo `json:"left"`
+	Right *api.QueueInfo `json:"right"`
+}
+
+type QueueOrderResponse JobOrderResponse
+
+type TaskOrderRequest struct {
+	Left  *api.TaskInfo `json:"left"`
+	Right *api.TaskInfo `json:"right"`
+}
+
+type TaskOrderResponse JobOrderResponse
+
+type AllocatableRequest struct {
+	Queue *api.QueueInfo `json:"queue"`
+	Task  *api.TaskInfo  `json:"task"`
+}
+
+type AllocatableResponse struct {
+	Allocatable bool `json:"allocatable"`
+}
+
+type PreemptiveRequest struct {
+	Queue *api.QueueInfo `json:"queue"`
+	Task  *api.TaskInfo  `json:"task"`
+}
+
+type PreemptiveResponse struct {
+	Preemptive bool `json:"preemptive"`
+}
+
+type JobValidRequest struct {
+	Job *api.JobInfo `json:"job"`
+}
+
+type JobValidResponse struct {
+	Pass    bool   `json:"pass"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+type JobStarvingRequest struct {
+	Job *api.JobInfo `json:"job"`
+}
+
+type JobStarvingResponse struct {
+	Starving bool `json:"starving"`
+}
+
+type VictimTasksRequest struct {
+	Tasks []*api.TaskInfo `json:"tasks"`
+}
+
+type VictimTasksResponse struct {
+	// Victims are the tasks to be evicted, which are matched to the tasks in request by uid
+	Victims []*api.TaskInfo `json:"victims"`
+}
+
+type BestNodeRequest struct {
+	Task *api.TaskInfo `json:"task"`
+	// NodeScores are the scores of candidate nodes: node name -> score
+	NodeScores map[string]float64 `json:"nodeScores"`
+}
+
+type BestNodeResponse struct {
+	// Node is the name of best node, empty means no preference
+	Node string `json:"node"`
+}
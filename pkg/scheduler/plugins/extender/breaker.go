@@ -0,0 +1,97 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extender
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// errCircuitOpen is returned instead of calling extender while the circuit breaker is open.
+var errCircuitOpen = errors.New("circuit breaker of extender is open")
+
+// circuitBreaker stops calling a degraded extender: the circuit opens after threshold consecutive
+// failures, and calls fail fast until cooldown elapses; then a single trial call is let through,
+// which closes the circuit if succeeds or opens it again for another cooldown.
+type circuitBreaker struct {
+	sync.Mutex
+	threshold int
+	cooldown  time.Duration
+
+	failures  int
+	openUntil time.Time
+}
+
+// breakers are the circuit breakers of extenders keyed by transport and urlPrefix,
+// which keep the state of extender across sessions.
+var breakers = struct {
+	sync.Mutex
+	breakers map[string]*circuitBreaker
+}{breakers: map[string]*circuitBreaker{}}
+
+// getCircuitBreaker returns the circuit breaker of extender, whose settings are updated
+// to the ones in the latest configuration.
+func getCircuitBreaker(key string, threshold int, cooldown time.Duration) *circuitBreaker {
+	breakers.Lock()
+	defer breakers.Unlock()
+
+	cb, found := breakers.breakers[key]
+	if !found {
+		cb = &circuitBreaker{}
+		breakers.breakers[key] = cb
+	}
+
+	cb.Lock()
+	cb.threshold = threshold
+	cb.cooldown = cooldown
+	cb.Unlock()
+
+	return cb
+}
+
+// allow tells whether extender can be called now.
+func (cb *circuitBreaker) allow() bool {
+	cb.Lock()
+	defer cb.Unlock()
+
+	if cb.threshold <= 0 || cb.failures < cb.threshold {
+		return true
+	}
+	now := time.Now()
+	if now.Before(cb.openUntil) {
+		return false
+	}
+	// half open: let this call through as a trial and keep others failing fast until it's done
+	cb.openUntil = now.Add(cb.cooldown)
+	return true
+}
+
+// done records the result of a call to extender.
+func (cb *circuitBreaker) done(err error) {
+	cb.Lock()
+	defer cb.Unlock()
+
+	if err == nil {
+		cb.failures = 0
+		return
+	}
+	cb.failures++
+	if cb.threshold > 0 && cb.failures >= cb.threshold {
+		cb.openUntil = time.Now().Add(cb.cooldown)
+	}
+}
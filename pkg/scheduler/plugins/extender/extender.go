@@ -17,12 +17,11 @@ limitations under the License.
 package extender
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"errors"
-	"fmt"
-	"net/http"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"k8s.io/klog/v2"
@@ -38,7 +37,10 @@ const (
 
 	// ExtenderURLPrefix is the key for providing extender endpoint address
 	ExtenderURLPrefix = "extender.urlPrefix"
-	// ExtenderHTTPTimeout is the timeout for extender http calls
+	// ExtenderTransport is the transport to call extender, http or grpc, default is http
+	ExtenderTransport = "extender.transport"
+	// ExtenderHTTPTimeout is the timeout for extender calls, it can be overridden for a verb by
+	// the argument named after the verb argument with the suffix Timeout, e.g. extender.predicateTimeout
 	ExtenderHTTPTimeout = "extender.httpTimeout"
 	// ExtenderOnSessionOpenVerb is the verb of OnSessionOpen method
 	ExtenderOnSessionOpenVerb = "extender.onSessionOpenVerb"
@@ -46,6 +48,9 @@ const (
 	ExtenderOnSessionCloseVerb = "extender.onSessionCloseVerb"
 	// ExtenderPredicateVerb is the verb of Predicate method
 	ExtenderPredicateVerb = "extender.predicateVerb"
+	// ExtenderBatchPredicateVerb is the verb of BatchPredicate method, which predicates a task against
+	// all the nodes in one call, it is used instead of ExtenderPredicateVerb if both are set
+	ExtenderBatchPredicateVerb = "extender.batchPredicateVerb"
 	// ExtenderPrioritizeVerb is the verb of Prioritize method
 	ExtenderPrioritizeVerb = "extender.prioritizeVerb"
 	// ExtenderPreemptableVerb is the verb of Preemptable method
@@ -58,28 +63,132 @@ const (
 	ExtenderJobEnqueueableVerb = "extender.jobEnqueueableVerb"
 	// ExtenderJobReadyVerb is the verb of JobReady method
 	ExtenderJobReadyVerb = "extender.jobReadyVerb"
+	// ExtenderJobOrderVerb is the verb of JobOrder method
+	ExtenderJobOrderVerb = "extender.jobOrderVerb"
+	// ExtenderQueueOrderVerb is the verb of QueueOrder method
+	ExtenderQueueOrderVerb = "extender.queueOrderVerb"
+	// ExtenderTaskOrderVerb is the verb of TaskOrder method
+	ExtenderTaskOrderVerb = "extender.taskOrderVerb"
+	// ExtenderAllocatableVerb is the verb of Allocatable method
+	ExtenderAllocatableVerb = "extender.allocatableVerb"
+	// ExtenderPreemptiveVerb is the verb of Preemptive method
+	ExtenderPreemptiveVerb = "extender.preemptiveVerb"
+	// ExtenderJobValidVerb is the verb of JobValid method
+	ExtenderJobValidVerb = "extender.jobValidVerb"
+	// ExtenderJobStarvingVerb is the verb of JobStarving method
+	ExtenderJobStarvingVerb = "extender.jobStarvingVerb"
+	// ExtenderVictimTasksVerb is the verb of VictimTasks method
+	ExtenderVictimTasksVerb = "extender.victimTasksVerb"
+	// ExtenderBestNodeVerb is the verb of BestNode method
+	ExtenderBestNodeVerb = "extender.bestNodeVerb"
 	// ExtenderIgnorable indicates whether the extender can ignore unexpected errors
 	ExtenderIgnorable = "extender.ignorable"
+	// ExtenderCircuitBreakerThreshold is the number of consecutive failed calls opening the circuit breaker
+	// of extender, extender is not called while the circuit breaker is open, 0 disables the circuit breaker
+	ExtenderCircuitBreakerThreshold = "extender.circuitBreakerThreshold"
+	// ExtenderCircuitBreakerCooldown is how long the circuit breaker keeps open before trying extender again
+	ExtenderCircuitBreakerCooldown = "extender.circuitBreakerCooldown"
+
+	// verbTimeoutSuffix replaces the suffix Verb of verb argument to get the timeout argument of the verb
+	verbTimeoutSuffix = "Timeout"
+
+	defaultCircuitBreakerCooldown = 30 * time.Second
 )
 
+// verbArguments are the arguments of all the verbs
+var verbArguments = []string{
+	ExtenderOnSessionOpenVerb,
+	ExtenderOnSessionCloseVerb,
+	ExtenderPredicateVerb,
+	ExtenderBatchPredicateVerb,
+	ExtenderPrioritizeVerb,
+	ExtenderPreemptableVerb,
+	ExtenderReclaimableVerb,
+	ExtenderQueueOverusedVerb,
+	ExtenderJobEnqueueableVerb,
+	ExtenderJobReadyVerb,
+	ExtenderJobOrderVerb,
+	ExtenderQueueOrderVerb,
+	ExtenderTaskOrderVerb,
+	ExtenderAllocatableVerb,
+	ExtenderPreemptiveVerb,
+	ExtenderJobValidVerb,
+	ExtenderJobStarvingVerb,
+	ExtenderVictimTasksVerb,
+	ExtenderBestNodeVerb,
+}
+
+// reasonExtenderFailed is the reason of job validation failure when extender can not be called
+const reasonExtenderFailed = "ExtenderFailed"
+
+// ArgumentsSchema is the schema of the arguments of extender plugin
+var ArgumentsSchema = argumentsSchema()
+
+func argumentsSchema() framework.ArgumentsSchema {
+	schema := framework.ArgumentsSchema{
+		ExtenderURLPrefix:               framework.StringArgument,
+		ExtenderTransport:               framework.StringArgument,
+		ExtenderHTTPTimeout:             framework.DurationArgument,
+		ExtenderIgnorable:               framework.BoolArgument,
+		ExtenderCircuitBreakerThreshold: framework.IntArgument,
+		ExtenderCircuitBreakerCooldown:  framework.DurationArgument,
+	}
+	for _, verbArgument := range verbArguments {
+		schema[verbArgument] = framework.StringArgument
+		schema[strings.TrimSuffix(verbArgument, "Verb")+verbTimeoutSuffix] = framework.DurationArgument
+	}
+	return schema
+}
+
 type extenderConfig struct {
 	urlPrefix          string
+	transport          string
 	httpTimeout        time.Duration
 	onSessionOpenVerb  string
 	onSessionCloseVerb string
 	predicateVerb      string
+	batchPredicateVerb string
 	prioritizeVerb     string
 	preemptableVerb    string
 	reclaimableVerb    string
 	queueOverusedVerb  string
 	jobEnqueueableVerb string
 	jobReadyVerb       string
+	jobOrderVerb       string
+	queueOrderVerb     string
+	taskOrderVerb      string
+	allocatableVerb    string
+	preemptiveVerb     string
+	jobValidVerb       string
+	jobStarvingVerb    string
+	victimTasksVerb    string
+	bestNodeVerb       string
 	ignorable          bool
+	// verbTimeouts are the timeouts of verbs overriding httpTimeout: verb -> timeout
+	verbTimeouts map[string]time.Duration
+
+	circuitBreakerThreshold int
+	circuitBreakerCooldown  time.Duration
 }
 
 type extenderPlugin struct {
-	client http.Client
-	config *extenderConfig
+	transport transport
+	breaker   *circuitBreaker
+	config    *extenderConfig
+
+	// predicateResults caches the batch predicate results of tasks in the session,
+	// it's cleared once a task is allocated or deallocated as the nodes are changed.
+	predicateMutex   sync.Mutex
+	predicateResults map[api.TaskID]*batchPredicateResult
+}
+
+// batchPredicateResult is the result of batch predicate of a task, which is fetched
+// only once even if the nodes are predicated in parallel.
+type batchPredicateResult struct {
+	once sync.Once
+	// failures are the failed nodes: node name -> failure
+	failures map[string]*api.Status
+	err      error
 }
 
 func parseExtenderConfig(arguments framework.Arguments) *extenderConfig {
@@ -96,30 +205,58 @@ func parseExtenderConfig(arguments framework.Arguments) *extenderConfig {
 			 - name: extender
 		       arguments:
 				   extender.urlPrefix: http://127.0.0.1
+				   extender.transport: http
 				   extender.httpTimeout: 100ms
 				   extender.onSessionOpenVerb: onSessionOpen
 				   extender.onSessionCloseVerb: onSessionClose
 				   extender.predicateVerb: predicate
+				   extender.batchPredicateVerb: batchPredicate
+				   extender.batchPredicateTimeout: 500ms
 				   extender.prioritizeVerb: prioritize
 				   extender.preemptableVerb: preemptable
 				   extender.reclaimableVerb: reclaimable
 				   extender.queueOverusedVerb: queueOverused
 				   extender.jobEnqueueableVerb: jobEnqueueable
+				   extender.jobOrderVerb: jobOrder
+				   extender.queueOrderVerb: queueOrder
+				   extender.taskOrderVerb: taskOrder
+				   extender.allocatableVerb: allocatable
+				   extender.preemptiveVerb: preemptive
+				   extender.jobValidVerb: jobValid
+				   extender.jobStarvingVerb: jobStarving
+				   extender.victimTasksVerb: victimTasks
+				   extender.bestNodeVerb: bestNode
 				   extender.ignorable: true
+				   extender.circuitBreakerThreshold: 5
+				   extender.circuitBreakerCooldown: 30s
 		     - name: proportion
 		     - name: nodeorder
 	*/
 	ec := &extenderConfig{}
 	ec.urlPrefix, _ = arguments[ExtenderURLPrefix].(string)
+	ec.transport, _ = arguments[ExtenderTransport].(string)
+	if ec.transport == "" {
+		ec.transport = TransportHTTP
+	}
 	ec.onSessionOpenVerb, _ = arguments[ExtenderOnSessionOpenVerb].(string)
 	ec.onSessionCloseVerb, _ = arguments[ExtenderOnSessionCloseVerb].(string)
 	ec.predicateVerb, _ = arguments[ExtenderPredicateVerb].(string)
+	ec.batchPredicateVerb, _ = arguments[ExtenderBatchPredicateVerb].(string)
 	ec.prioritizeVerb, _ = arguments[ExtenderPrioritizeVerb].(string)
 	ec.preemptableVerb, _ = arguments[ExtenderPreemptableVerb].(string)
 	ec.reclaimableVerb, _ = arguments[ExtenderReclaimableVerb].(string)
 	ec.queueOverusedVerb, _ = arguments[ExtenderQueueOverusedVerb].(string)
 	ec.jobEnqueueableVerb, _ = arguments[ExtenderJobEnqueueableVerb].(string)
 	ec.jobReadyVerb, _ = arguments[ExtenderJobReadyVerb].(string)
+	ec.jobOrderVerb, _ = arguments[ExtenderJobOrderVerb].(string)
+	ec.queueOrderVerb, _ = arguments[ExtenderQueueOrderVerb].(string)
+	ec.taskOrderVerb, _ = arguments[ExtenderTaskOrderVerb].(string)
+	ec.allocatableVerb, _ = arguments[ExtenderAllocatableVerb].(string)
+	ec.preemptiveVerb, _ = arguments[ExtenderPreemptiveVerb].(string)
+	ec.jobValidVerb, _ = arguments[ExtenderJobValidVerb].(string)
+	ec.jobStarvingVerb, _ = arguments[ExtenderJobStarvingVerb].(string)
+	ec.victimTasksVerb, _ = arguments[ExtenderVictimTasksVerb].(string)
+	ec.bestNodeVerb, _ = arguments[ExtenderBestNodeVerb].(string)
 
 	arguments.GetBool(&ec.ignorable, ExtenderIgnorable)
 
@@ -130,13 +267,55 @@ func parseExtenderConfig(arguments framework.Arguments) *extenderConfig {
 		}
 	}
 
+	ec.verbTimeouts = map[string]time.Duration{}
+	for _, verbArgument := range verbArguments {
+		verb, _ := arguments[verbArgument].(string)
+		timeoutArgument := strings.TrimSuffix(verbArgument, "Verb") + verbTimeoutSuffix
+		if timeout, _ := arguments[timeoutArgument].(string); verb != "" && timeout != "" {
+			if timeoutDuration, err := time.ParseDuration(timeout); err == nil {
+				ec.verbTimeouts[verb] = timeoutDuration
+			}
+		}
+	}
+
+	arguments.GetInt(&ec.circuitBreakerThreshold, ExtenderCircuitBreakerThreshold)
+	ec.circuitBreakerCooldown = defaultCircuitBreakerCooldown
+	if cooldown, _ := arguments[ExtenderCircuitBreakerCooldown].(string); cooldown != "" {
+		if cooldownDuration, err := time.ParseDuration(cooldown); err == nil {
+			ec.circuitBreakerCooldown = cooldownDuration
+		}
+	}
+
 	return ec
 }
 
 func New(arguments framework.Arguments) framework.Plugin {
 	cfg := parseExtenderConfig(arguments)
-	klog.V(4).Infof("Initialize extender plugin with endpoint address %s", cfg.urlPrefix)
-	return &extenderPlugin{client: http.Client{Timeout: cfg.httpTimeout}, config: cfg}
+	klog.V(4).Infof("Initialize extender plugin with endpoint address %s over %s", cfg.urlPrefix, cfg.transport)
+
+	var t transport
+	switch cfg.transport {
+	case TransportGRPC:
+		gt, err := newGRPCTransport(cfg.urlPrefix)
+		if err != nil {
+			klog.Errorf("Failed to create grpc transport of extender: %v", err)
+			t = &brokenTransport{err: err}
+		} else {
+			t = gt
+		}
+	case TransportHTTP:
+		t = &httpTransport{urlPrefix: cfg.urlPrefix}
+	default:
+		err := errors.New("unknown extender transport " + strconv.Quote(cfg.transport))
+		klog.Error(err)
+		t = &brokenTransport{err: err}
+	}
+
+	return &extenderPlugin{
+		transport: t,
+		breaker:   getCircuitBreaker(cfg.transport+"://"+cfg.urlPrefix, cfg.circuitBreakerThreshold, cfg.circuitBreakerCooldown),
+		config:    cfg,
+	}
 }
 
 func (ep *extenderPlugin) Name() string {
@@ -144,7 +323,8 @@ func (ep *extenderPlugin) Name() string {
 }
 
 func (ep *extenderPlugin) OnSessionOpen(ssn *framework.Session) {
-	if ep.config.onSessionOpenVerb != "" {
+	// the extender is not notified of a dry-run session, e.g. explaining a job, which changes nothing
+	if ep.config.onSessionOpenVerb != "" && !ssn.DryRun() {
 		err := ep.send(ep.config.onSessionOpenVerb, &OnSessionOpenRequest{
 			Jobs:           ssn.Jobs,
 			Nodes:          ssn.Nodes,
@@ -160,7 +340,20 @@ func (ep *extenderPlugin) OnSessionOpen(ssn *framework.Session) {
 		}
 	}
 
-	if ep.config.predicateVerb != "" {
+	if ep.config.batchPredicateVerb != "" {
+		ep.predicateResults = map[api.TaskID]*batchPredicateResult{}
+		ssn.AddPredicateFn(ep.Name(), func(task *api.TaskInfo, node *api.NodeInfo) error {
+			return ep.batchPredicate(ssn, task, node)
+		})
+		ssn.AddEventHandler(&framework.EventHandler{
+			AllocateFunc: func(event *framework.Event) {
+				ep.clearPredicateResults()
+			},
+			DeallocateFunc: func(event *framework.Event) {
+				ep.clearPredicateResults()
+			},
+		})
+	} else if ep.config.predicateVerb != "" {
 		ssn.AddPredicateFn(ep.Name(), func(task *api.TaskInfo, node *api.NodeInfo) error {
 			resp := &PredicateResponse{}
 			err := ep.send(ep.config.predicateVerb, &PredicateRequest{Task: task, Node: node}, resp)
@@ -286,43 +479,233 @@ func (ep *extenderPlugin) OnSessionOpen(ssn *framework.Session) {
 			return resp.Status
 		})
 	}
+
+	if ep.config.jobOrderVerb != "" {
+		ssn.AddJobOrderFn(ep.Name(), func(l, r interface{}) int {
+			resp := &JobOrderResponse{}
+			err := ep.send(ep.config.jobOrderVerb, &JobOrderRequest{Left: l.(*api.JobInfo), Right: r.(*api.JobInfo)}, resp)
+			if err != nil {
+				klog.Warningf("JobOrder failed with error %v", err)
+				return 0
+			}
+
+			return resp.Result
+		})
+	}
+
+	if ep.config.queueOrderVerb != "" {
+		ssn.AddQueueOrderFn(ep.Name(), func(l, r interface{}) int {
+			resp := &QueueOrderResponse{}
+			err := ep.send(ep.config.queueOrderVerb, &QueueOrderRequest{Left: l.(*api.QueueInfo), Right: r.(*api.QueueInfo)}, resp)
+			if err != nil {
+				klog.Warningf("QueueOrder failed with error %v", err)
+				return 0
+			}
+
+			return resp.Result
+		})
+	}
+
+	if ep.config.taskOrderVerb != "" {
+		ssn.AddTaskOrderFn(ep.Name(), func(l, r interface{}) int {
+			resp := &TaskOrderResponse{}
+			err := ep.send(ep.config.taskOrderVerb, &TaskOrderRequest{Left: l.(*api.TaskInfo), Right: r.(*api.TaskInfo)}, resp)
+			if err != nil {
+				klog.Warningf("TaskOrder failed with error %v", err)
+				return 0
+			}
+
+			return resp.Result
+		})
+	}
+
+	if ep.config.allocatableVerb != "" {
+		ssn.AddAllocatableFn(ep.Name(), func(queue *api.QueueInfo, candidate *api.TaskInfo) bool {
+			resp := &AllocatableResponse{}
+			err := ep.send(ep.config.allocatableVerb, &AllocatableRequest{Queue: queue, Task: candidate}, resp)
+			if err != nil {
+				klog.Warningf("Allocatable failed with error %v", err)
+
+				return ep.config.ignorable
+			}
+
+			return resp.Allocatable
+		})
+	}
+
+	if ep.config.preemptiveVerb != "" {
+		ssn.AddPreemptiveFn(ep.Name(), func(obj interface{}, candidate interface{}) bool {
+			resp := &PreemptiveResponse{}
+			err := ep.send(ep.config.preemptiveVerb, &PreemptiveRequest{Queue: obj.(*api.QueueInfo), Task: candidate.(*api.TaskInfo)}, resp)
+			if err != nil {
+				klog.Warningf("Preemptive failed with error %v", err)
+
+				return ep.config.ignorable
+			}
+
+			return resp.Preemptive
+		})
+	}
+
+	if ep.config.jobValidVerb != "" {
+		ssn.AddJobValidFn(ep.Name(), func(obj interface{}) *api.ValidateResult {
+			resp := &JobValidResponse{}
+			err := ep.send(ep.config.jobValidVerb, &JobValidRequest{Job: obj.(*api.JobInfo)}, resp)
+			if err != nil {
+				klog.Warningf("JobValid failed with error %v", err)
+
+				if ep.config.ignorable {
+					return nil
+				}
+				return &api.ValidateResult{Pass: false, Reason: reasonExtenderFailed, Message: err.Error()}
+			}
+
+			if resp.Pass {
+				return nil
+			}
+			return &api.ValidateResult{Pass: false, Reason: resp.Reason, Message: resp.Message}
+		})
+	}
+
+	if ep.config.jobStarvingVerb != "" {
+		ssn.AddJobStarvingFns(ep.Name(), func(obj interface{}) bool {
+			resp := &JobStarvingResponse{}
+			err := ep.send(ep.config.jobStarvingVerb, &JobStarvingRequest{Job: obj.(*api.JobInfo)}, resp)
+			if err != nil {
+				klog.Warningf("JobStarving failed with error %v", err)
+
+				return ep.config.ignorable
+			}
+
+			return resp.Starving
+		})
+	}
+
+	if ep.config.victimTasksVerb != "" {
+		ssn.AddVictimTasksFns(ep.Name(), []api.VictimTasksFn{func(tasks []*api.TaskInfo) []*api.TaskInfo {
+			resp := &VictimTasksResponse{}
+			err := ep.send(ep.config.victimTasksVerb, &VictimTasksRequest{Tasks: tasks}, resp)
+			if err != nil {
+				klog.Warningf("VictimTasks failed with error %v", err)
+				return nil
+			}
+
+			// the victims decoded from response are copies, return the tasks in session instead
+			victims := make(map[api.TaskID]bool, len(resp.Victims))
+			for _, victim := range resp.Victims {
+				if victim != nil {
+					victims[victim.UID] = true
+				}
+			}
+			var result []*api.TaskInfo
+			for _, task := range tasks {
+				if victims[task.UID] {
+					result = append(result, task)
+				}
+			}
+			return result
+		}})
+	}
+
+	if ep.config.bestNodeVerb != "" {
+		ssn.AddBestNodeFn(ep.Name(), func(task *api.TaskInfo, nodeScores map[float64][]*api.NodeInfo) *api.NodeInfo {
+			// json can not encode float keys, so the scores are sent by node name
+			scores := map[string]float64{}
+			nodes := map[string]*api.NodeInfo{}
+			for score, scoredNodes := range nodeScores {
+				for _, node := range scoredNodes {
+					scores[node.Name] = score
+					nodes[node.Name] = node
+				}
+			}
+
+			resp := &BestNodeResponse{}
+			err := ep.send(ep.config.bestNodeVerb, &BestNodeRequest{Task: task, NodeScores: scores}, resp)
+			if err != nil {
+				klog.Warningf("BestNode failed with error %v", err)
+				return nil
+			}
+
+			return nodes[resp.Node]
+		})
+	}
 }
 
 func (ep *extenderPlugin) OnSessionClose(ssn *framework.Session) {
-	if ep.config.onSessionCloseVerb != "" {
+	if ep.config.onSessionCloseVerb != "" && !ssn.DryRun() {
 		if err := ep.send(ep.config.onSessionCloseVerb, &OnSessionCloseRequest{}, nil); err != nil {
 			klog.Warningf("OnSessionClose failed with error %v", err)
 		}
 	}
 }
 
-func (ep *extenderPlugin) send(action string, args interface{}, result interface{}) error {
-	out, err := json.Marshal(args)
-	if err != nil {
-		return err
+// batchPredicate predicates the task against all the nodes of session in one call at the first time
+// a node of task is predicated, and returns the cached result of node afterwards.
+func (ep *extenderPlugin) batchPredicate(ssn *framework.Session, task *api.TaskInfo, node *api.NodeInfo) error {
+	ep.predicateMutex.Lock()
+	result, found := ep.predicateResults[task.UID]
+	if !found {
+		result = &batchPredicateResult{}
+		ep.predicateResults[task.UID] = result
 	}
+	ep.predicateMutex.Unlock()
 
-	url := strings.TrimRight(ep.config.urlPrefix, "/") + "/" + action
+	result.once.Do(func() {
+		resp := &BatchPredicateResponse{}
+		result.err = ep.send(ep.config.batchPredicateVerb, &BatchPredicateRequest{Task: task, Nodes: ssn.NodeList}, resp)
+		if result.err == nil && resp.ErrorMessage != "" {
+			result.err = errors.New(resp.ErrorMessage)
+		}
+		if result.err != nil {
+			klog.Warningf("BatchPredicate failed with error %v", result.err)
+			return
+		}
 
-	req, err := http.NewRequest("POST", url, bytes.NewReader(out))
-	if err != nil {
-		return err
+		result.failures = make(map[string]*api.Status, len(resp.Results))
+		for nodeName, nodeResult := range resp.Results {
+			if nodeResult == nil || len(nodeResult.ErrorMessage) == 0 {
+				continue
+			}
+			code := nodeResult.Code
+			if code == api.Success {
+				code = api.Error
+			}
+			result.failures[nodeName] = &api.Status{Code: code, Reason: nodeResult.ErrorMessage, Plugin: PluginName}
+		}
+	})
+
+	if result.err != nil {
+		if ep.config.ignorable {
+			return nil
+		}
+		return api.NewFitError(task, node, result.err.Error())
 	}
+	if status, failed := result.failures[node.Name]; failed {
+		return api.NewFitErrWithStatus(task, node, status)
+	}
+	return nil
+}
 
-	req.Header.Set("Content-Type", "application/json")
+func (ep *extenderPlugin) clearPredicateResults() {
+	ep.predicateMutex.Lock()
+	ep.predicateResults = map[api.TaskID]*batchPredicateResult{}
+	ep.predicateMutex.Unlock()
+}
 
-	resp, err := ep.client.Do(req)
-	if err != nil {
-		return err
+// send calls the verb of extender with the timeout of verb, it fails fast while the circuit breaker is open.
+func (ep *extenderPlugin) send(action string, args interface{}, result interface{}) error {
+	if !ep.breaker.allow() {
+		return errCircuitOpen
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed %v with extender at URL %v, code %v", action, url, resp.StatusCode)
+	timeout, found := ep.config.verbTimeouts[action]
+	if !found {
+		timeout = ep.config.httpTimeout
 	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
 
-	if result != nil {
-		return json.NewDecoder(resp.Body).Decode(result)
-	}
-	return nil
+	err := ep.transport.send(ctx, action, args, result)
+	ep.breaker.done(err)
+	return err
 }
@@ -0,0 +1,437 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extender
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	v1 "k8s.io/api/core/v1"
+
+	schedulingv1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	schedulingutil "volcano.sh/volcano/pkg/scheduler/util"
+)
+
+// fakeExtender rejects node n2 in batch predicate and prefers node n1 in prioritize, orders jobs and
+// tasks by name and queues by name reversely, doesn't allow allocating task named big, invalidates
+// jobs in namespace c2, selects tasks named with prefix "low" as victims and picks the node of lowest
+// score as best node, it sleeps for a second in verb slow and fails all the verbs if broken.
+type fakeExtender struct {
+	broken atomic.Bool
+	calls  map[string]*int32
+}
+
+func newFakeExtender() *fakeExtender {
+	fe := &fakeExtender{calls: map[string]*int32{}}
+	for _, verb := range []string{"batchPredicate", "predicate", "prioritize", "slow", "jobOrder", "queueOrder", "taskOrder",
+		"allocatable", "preemptive", "jobValid", "jobStarving", "victimTasks", "bestNode"} {
+		fe.calls[verb] = new(int32)
+	}
+	return fe
+}
+
+func (fe *fakeExtender) handle(verb string, body []byte) (interface{}, error) {
+	atomic.AddInt32(fe.calls[verb], 1)
+	if fe.broken.Load() {
+		return nil, errors.New("extender is broken")
+	}
+
+	switch verb {
+	case "batchPredicate":
+		req := &BatchPredicateRequest{}
+		if err := json.Unmarshal(body, req); err != nil {
+			return nil, err
+		}
+		resp := &BatchPredicateResponse{Results: map[string]*PredicateResponse{}}
+		for _, node := range req.Nodes {
+			if node.Name == "n2" {
+				resp.Results[node.Name] = &PredicateResponse{ErrorMessage: "n2 is reserved", Code: api.UnschedulableAndUnresolvable}
+			}
+		}
+		return resp, nil
+	case "prioritize":
+		req := &PrioritizeRequest{}
+		if err := json.Unmarshal(body, req); err != nil {
+			return nil, err
+		}
+		resp := &PrioritizeResponse{NodeScore: map[string]float64{}}
+		for _, node := range req.Nodes {
+			if node.Name == "n1" {
+				resp.NodeScore[node.Name] = 100
+			}
+		}
+		return resp, nil
+	case "jobOrder":
+		req := &JobOrderRequest{}
+		if err := json.Unmarshal(body, req); err != nil {
+			return nil, err
+		}
+		return &JobOrderResponse{Result: strings.Compare(req.Left.Name, req.Right.Name)}, nil
+	case "queueOrder":
+		req := &QueueOrderRequest{}
+		if err := json.Unmarshal(body, req); err != nil {
+			return nil, err
+		}
+		return &QueueOrderResponse{Result: strings.Compare(req.Right.Name, req.Left.Name)}, nil
+	case "taskOrder":
+		req := &TaskOrderRequest{}
+		if err := json.Unmarshal(body, req); err != nil {
+			return nil, err
+		}
+		return &TaskOrderResponse{Result: strings.Compare(req.Left.Name, req.Right.Name)}, nil
+	case "allocatable":
+		req := &AllocatableRequest{}
+		if err := json.Unmarshal(body, req); err != nil {
+			return nil, err
+		}
+		return &AllocatableResponse{Allocatable: req.Task.Name != "big"}, nil
+	case "preemptive":
+		return &PreemptiveResponse{Preemptive: true}, nil
+	case "jobValid":
+		req := &JobValidRequest{}
+		if err := json.Unmarshal(body, req); err != nil {
+			return nil, err
+		}
+		if req.Job.Namespace == "c2" {
+			return &JobValidResponse{Reason: "Forbidden", Message: "namespace c2 is frozen"}, nil
+		}
+		return &JobValidResponse{Pass: true}, nil
+	case "jobStarving":
+		return &JobStarvingResponse{Starving: true}, nil
+	case "victimTasks":
+		req := &VictimTasksRequest{}
+		if err := json.Unmarshal(body, req); err != nil {
+			return nil, err
+		}
+		resp := &VictimTasksResponse{}
+		for _, task := range req.Tasks {
+			if strings.HasPrefix(task.Name, "low") {
+				resp.Victims = append(resp.Victims, task)
+			}
+		}
+		return resp, nil
+	case "bestNode":
+		req := &BestNodeRequest{}
+		if err := json.Unmarshal(body, req); err != nil {
+			return nil, err
+		}
+		resp := &BestNodeResponse{}
+		for node, score := range req.NodeScores {
+			if resp.Node == "" || score < req.NodeScores[resp.Node] {
+				resp.Node = node
+			}
+		}
+		return resp, nil
+	case "slow":
+		time.Sleep(time.Second)
+	}
+	return &PredicateResponse{}, nil
+}
+
+func (fe *fakeExtender) count(verb string) int32 {
+	return atomic.LoadInt32(fe.calls[verb])
+}
+
+func startHTTPExtender(t *testing.T, fe *fakeExtender) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := json.RawMessage{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		resp, err := fe.handle(strings.TrimPrefix(r.URL.Path, "/"), body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+// startGRPCExtender serves all the verbs with an unknown service handler,
+// which is how an extender can be written in Go without generated code.
+func startGRPCExtender(t *testing.T, fe *fakeExtender) string {
+	socket := filepath.Join(t.TempDir(), "extender.sock")
+	lis, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatalf("failed to listen on %s: %v", socket, err)
+	}
+	server := grpc.NewServer(grpc.UnknownServiceHandler(func(srv interface{}, stream grpc.ServerStream) error {
+		method, _ := grpc.MethodFromServerStream(stream)
+		body := json.RawMessage{}
+		if err := stream.RecvMsg(&body); err != nil {
+			return err
+		}
+		resp, err := fe.handle(strings.TrimPrefix(method, "/"+GRPCServiceName+"/"), body)
+		if err != nil {
+			return err
+		}
+		return stream.SendMsg(resp)
+	}))
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+	return "unix://" + socket
+}
+
+func openSession(arguments framework.Arguments) *framework.Session {
+	framework.RegisterPluginBuilder(PluginName, New)
+
+	schedulerCache := cache.NewDefaultMockSchedulerCache("volcano")
+	for _, name := range []string{"n1", "n2", "n3"} {
+		schedulerCache.AddOrUpdateNode(schedulingutil.BuildNode(name, api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil))
+	}
+	schedulerCache.AddQueueV1beta1(schedulingutil.BuildQueue("q1", 1, nil))
+	schedulerCache.AddQueueV1beta1(schedulingutil.BuildQueue("q2", 1, nil))
+	schedulerCache.AddPodGroupV1beta1(schedulingutil.BuildPodGroup("pg1", "c1", "q1", 1, nil, schedulingv1.PodGroupInqueue))
+	schedulerCache.AddPodGroupV1beta1(schedulingutil.BuildPodGroup("pg2", "c2", "q2", 1, nil, schedulingv1.PodGroupRunning))
+	schedulerCache.AddPod(schedulingutil.BuildPod("c1", "p1", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil))
+	schedulerCache.AddPod(schedulingutil.BuildPod("c2", "low-1", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg2", nil, nil))
+	schedulerCache.AddPod(schedulingutil.BuildPod("c2", "mid-1", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg2", nil, nil))
+
+	trueValue := true
+	tiers := []conf.Tier{{Plugins: []conf.PluginOption{{
+		Name:               PluginName,
+		EnabledPredicate:   &trueValue,
+		EnabledNodeOrder:   &trueValue,
+		EnabledJobOrder:    &trueValue,
+		EnabledQueueOrder:  &trueValue,
+		EnabledTaskOrder:   &trueValue,
+		EnabledAllocatable: &trueValue,
+		EnablePreemptive:   &trueValue,
+		EnabledJobStarving: &trueValue,
+		EnabledVictim:      &trueValue,
+		EnabledBestNode:    &trueValue,
+		Arguments:          arguments,
+	}}}}
+	return framework.OpenSession(schedulerCache, tiers, nil)
+}
+
+func pendingTask(ssn *framework.Session) *api.TaskInfo {
+	for _, job := range ssn.Jobs {
+		for _, task := range job.TaskStatusIndex[api.Pending] {
+			return task
+		}
+	}
+	return nil
+}
+
+func findJob(ssn *framework.Session, namespace string) *api.JobInfo {
+	for _, job := range ssn.Jobs {
+		if job.Namespace == namespace {
+			return job
+		}
+	}
+	return nil
+}
+
+func TestBatchVerbs(t *testing.T) {
+	defer framework.CleanupPluginBuilders()
+
+	for _, transport := range []string{TransportHTTP, TransportGRPC} {
+		t.Run(transport, func(t *testing.T) {
+			fe := newFakeExtender()
+			urlPrefix := startHTTPExtender(t, fe)
+			if transport == TransportGRPC {
+				urlPrefix = startGRPCExtender(t, fe)
+			}
+
+			ssn := openSession(framework.Arguments{
+				ExtenderURLPrefix:          urlPrefix,
+				ExtenderTransport:          transport,
+				ExtenderPredicateVerb:      "predicate",
+				ExtenderBatchPredicateVerb: "batchPredicate",
+				ExtenderPrioritizeVerb:     "prioritize",
+			})
+			defer framework.CloseSession(ssn)
+
+			task := pendingTask(ssn)
+			assert.NoError(t, ssn.PredicateFn(task, ssn.Nodes["n1"]))
+			assert.Error(t, ssn.PredicateFn(task, ssn.Nodes["n2"]))
+			assert.NoError(t, ssn.PredicateFn(task, ssn.Nodes["n3"]))
+			assert.Equal(t, int32(1), fe.count("batchPredicate"), "nodes of a task should be predicated in one call")
+			assert.Equal(t, int32(0), fe.count("predicate"), "predicate verb should be replaced by batch predicate verb")
+
+			scores, err := ssn.BatchNodeOrderFn(task, []*api.NodeInfo{ssn.Nodes["n1"], ssn.Nodes["n3"]})
+			assert.NoError(t, err)
+			assert.Equal(t, float64(100), scores["n1"])
+			assert.Equal(t, float64(0), scores["n3"])
+
+			// the results are predicated again once the nodes are changed
+			stmt := framework.NewStatement(ssn)
+			assert.NoError(t, stmt.Allocate(task, ssn.Nodes["n1"]))
+			stmt.Discard()
+			assert.NoError(t, ssn.PredicateFn(task, ssn.Nodes["n3"]))
+			assert.Equal(t, int32(2), fe.count("batchPredicate"))
+		})
+	}
+}
+
+func TestVerbTimeout(t *testing.T) {
+	fe := newFakeExtender()
+	address := startGRPCExtender(t, fe)
+
+	ep := New(framework.Arguments{
+		ExtenderURLPrefix:           address,
+		ExtenderTransport:           TransportGRPC,
+		ExtenderHTTPTimeout:         "5s",
+		ExtenderPredicateVerb:       "slow",
+		"extender.predicateTimeout": "100ms",
+		ExtenderPrioritizeVerb:      "prioritize",
+	}).(*extenderPlugin)
+
+	assert.Equal(t, map[string]time.Duration{"slow": 100 * time.Millisecond}, ep.config.verbTimeouts)
+	start := time.Now()
+	assert.Error(t, ep.send("slow", &PredicateRequest{}, &PredicateResponse{}))
+	assert.Less(t, time.Since(start), time.Second)
+	assert.NoError(t, ep.send("prioritize", &PrioritizeRequest{}, &PrioritizeResponse{}))
+
+	// the connection is shared by the sessions
+	other := New(framework.Arguments{ExtenderURLPrefix: address, ExtenderTransport: TransportGRPC}).(*extenderPlugin)
+	assert.Same(t, ep.transport.(*grpcTransport).conn, other.transport.(*grpcTransport).conn)
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	defer framework.CleanupPluginBuilders()
+
+	tests := []struct {
+		name      string
+		ignorable bool
+	}{
+		{name: "ignorable extender is skipped while degraded", ignorable: true},
+		{name: "non-ignorable extender rejects nodes while degraded", ignorable: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fe := newFakeExtender()
+			fe.broken.Store(true)
+			urlPrefix := startHTTPExtender(t, fe)
+
+			arguments := framework.Arguments{
+				ExtenderURLPrefix:               urlPrefix,
+				ExtenderPredicateVerb:           "predicate",
+				ExtenderIgnorable:               test.ignorable,
+				ExtenderCircuitBreakerThreshold: 2,
+				ExtenderCircuitBreakerCooldown:  "100ms",
+			}
+			ssn := openSession(arguments)
+			task := pendingTask(ssn)
+			for _, node := range ssn.NodeList {
+				err := ssn.PredicateFn(task, node)
+				if test.ignorable {
+					assert.NoError(t, err)
+				} else {
+					assert.Error(t, err)
+				}
+			}
+			framework.CloseSession(ssn)
+			assert.Equal(t, int32(2), fe.count("predicate"), "extender should not be called once the circuit breaker is open")
+
+			// the state of circuit breaker is kept across sessions, and the circuit is closed
+			// once the trial call after cooldown succeeds
+			fe.broken.Store(false)
+			ssn = openSession(arguments)
+			defer framework.CloseSession(ssn)
+			task = pendingTask(ssn)
+			if !test.ignorable {
+				assert.Error(t, ssn.PredicateFn(task, ssn.Nodes["n1"]))
+			}
+			assert.Equal(t, int32(2), fe.count("predicate"))
+
+			time.Sleep(150 * time.Millisecond)
+			for _, node := range ssn.NodeList {
+				assert.NoError(t, ssn.PredicateFn(task, node))
+			}
+			assert.Equal(t, int32(5), fe.count("predicate"))
+		})
+	}
+}
+
+func TestSessionHooks(t *testing.T) {
+	defer framework.CleanupPluginBuilders()
+
+	fe := newFakeExtender()
+	ssn := openSession(framework.Arguments{
+		ExtenderURLPrefix:       startHTTPExtender(t, fe),
+		ExtenderJobOrderVerb:    "jobOrder",
+		ExtenderQueueOrderVerb:  "queueOrder",
+		ExtenderTaskOrderVerb:   "taskOrder",
+		ExtenderAllocatableVerb: "allocatable",
+		ExtenderPreemptiveVerb:  "preemptive",
+		ExtenderJobValidVerb:    "jobValid",
+		ExtenderJobStarvingVerb: "jobStarving",
+		ExtenderVictimTasksVerb: "victimTasks",
+		ExtenderBestNodeVerb:    "bestNode",
+	})
+	defer framework.CloseSession(ssn)
+
+	job1, job2 := findJob(ssn, "c1"), findJob(ssn, "c2")
+	assert.True(t, ssn.JobOrderFn(job1, job2))
+	assert.False(t, ssn.JobOrderFn(job2, job1))
+
+	q1, q2 := ssn.Queues["q1"], ssn.Queues["q2"]
+	assert.True(t, ssn.QueueOrderFn(q2, q1))
+	assert.False(t, ssn.QueueOrderFn(q1, q2))
+
+	var low, mid *api.TaskInfo
+	for _, task := range job2.Tasks {
+		if task.Name == "low-1" {
+			low = task
+		} else {
+			mid = task
+		}
+	}
+	assert.True(t, ssn.TaskOrderFn(low, mid))
+	assert.False(t, ssn.TaskOrderFn(mid, low))
+
+	task := pendingTask(ssn)
+	assert.True(t, ssn.Allocatable(q1, task))
+	big := task.Clone()
+	big.Name = "big"
+	assert.False(t, ssn.Allocatable(q1, big))
+	assert.True(t, ssn.Preemptive(q1, task))
+
+	assert.Nil(t, ssn.JobValid(job1))
+	assert.Equal(t, &api.ValidateResult{Pass: false, Reason: "Forbidden", Message: "namespace c2 is frozen"}, ssn.JobValid(job2))
+	assert.True(t, ssn.JobStarving(job1))
+
+	victims := ssn.VictimTasks([]*api.TaskInfo{low, mid})
+	assert.Equal(t, map[*api.TaskInfo]bool{low: true}, victims, "victims should be the tasks in session")
+
+	bestNode := ssn.BestNodeFn(task, map[float64][]*api.NodeInfo{
+		10: {ssn.Nodes["n1"]},
+		5:  {ssn.Nodes["n2"], ssn.Nodes["n3"]},
+	})
+	if assert.NotNil(t, bestNode) {
+		assert.Contains(t, []string{"n2", "n3"}, bestNode.Name)
+	}
+}
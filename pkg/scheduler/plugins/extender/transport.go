@@ -0,0 +1,136 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extender
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"volcano.sh/volcano/pkg/scheduler/plugins/util/jsoncodec"
+)
+
+const (
+	// TransportHTTP posts the json encoded request to urlPrefix/verb
+	TransportHTTP = "http"
+	// TransportGRPC calls the method /volcano.scheduler.extender.v1.Extender/verb of the gRPC
+	// target urlPrefix with the json encoded request, i.e. the content-type is "application/grpc+json"
+	TransportGRPC = "grpc"
+
+	// GRPCServiceName is the full name of gRPC service implemented by extenders using grpc transport,
+	// the methods are the verbs in configuration.
+	GRPCServiceName = "volcano.scheduler.extender.v1.Extender"
+)
+
+// transport sends the request of a verb to extender and decodes the response into result.
+type transport interface {
+	send(ctx context.Context, verb string, args interface{}, result interface{}) error
+}
+
+// httpClient is shared by all the sessions so that the connections to extender are reused,
+// the timeout of each call is set by the context of request.
+var httpClient = func() *http.Client {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	// the predicates of tasks may be sent to extender concurrently
+	t.MaxIdleConnsPerHost = 64
+	return &http.Client{Transport: t}
+}()
+
+type httpTransport struct {
+	urlPrefix string
+}
+
+func (ht *httpTransport) send(ctx context.Context, verb string, args interface{}, result interface{}) error {
+	out, err := json.Marshal(args)
+	if err != nil {
+		return err
+	}
+
+	url := strings.TrimRight(ht.urlPrefix, "/") + "/" + verb
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(out))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed %v with extender at URL %v, code %v", verb, url, resp.StatusCode)
+	}
+
+	if result != nil {
+		return json.NewDecoder(resp.Body).Decode(result)
+	}
+	return nil
+}
+
+// grpcConns are the connections to extenders keyed by target, which are shared by all the sessions.
+var grpcConns = struct {
+	sync.Mutex
+	conns map[string]*grpc.ClientConn
+}{conns: map[string]*grpc.ClientConn{}}
+
+// newGRPCTransport returns the grpc transport to target, reusing the connection created before.
+func newGRPCTransport(target string) (*grpcTransport, error) {
+	grpcConns.Lock()
+	defer grpcConns.Unlock()
+
+	if conn, found := grpcConns.conns[target]; found {
+		return &grpcTransport{conn: conn}, nil
+	}
+	// the connection is established lazily, so the extender can start after vc-scheduler
+	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create connection to extender at %s: %v", target, err)
+	}
+	grpcConns.conns[target] = conn
+	return &grpcTransport{conn: conn}, nil
+}
+
+type grpcTransport struct {
+	conn grpc.ClientConnInterface
+}
+
+func (gt *grpcTransport) send(ctx context.Context, verb string, args interface{}, result interface{}) error {
+	if result == nil {
+		result = &json.RawMessage{}
+	}
+	return gt.conn.Invoke(ctx, "/"+GRPCServiceName+"/"+verb, args, result, grpc.CallContentSubtype(jsoncodec.Name))
+}
+
+// brokenTransport fails all the calls, it is used when the transport of extender can not be created.
+type brokenTransport struct {
+	err error
+}
+
+func (bt *brokenTransport) send(context.Context, string, interface{}, interface{}) error {
+	return bt.err
+}
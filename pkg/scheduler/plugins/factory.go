@@ -25,6 +25,7 @@ import (
 	"volcano.sh/volcano/pkg/scheduler/plugins/deviceshare"
 	"volcano.sh/volcano/pkg/scheduler/plugins/drf"
 	"volcano.sh/volcano/pkg/scheduler/plugins/extender"
+	"volcano.sh/volcano/pkg/scheduler/plugins/fairshare"
 	"volcano.sh/volcano/pkg/scheduler/plugins/gang"
 	"volcano.sh/volcano/pkg/scheduler/plugins/nodegroup"
 	"volcano.sh/volcano/pkg/scheduler/plugins/nodeorder"
@@ -35,6 +36,7 @@ import (
 	"volcano.sh/volcano/pkg/scheduler/plugins/priority"
 	"volcano.sh/volcano/pkg/scheduler/plugins/proportion"
 	"volcano.sh/volcano/pkg/scheduler/plugins/rescheduling"
+	"volcano.sh/volcano/pkg/scheduler/plugins/reservation"
 	"volcano.sh/volcano/pkg/scheduler/plugins/resourcequota"
 	"volcano.sh/volcano/pkg/scheduler/plugins/sla"
 	tasktopology "volcano.sh/volcano/pkg/scheduler/plugins/task-topology"
@@ -62,14 +64,32 @@ func init() {
 	framework.RegisterPluginBuilder(usage.PluginName, usage.New)
 	framework.RegisterPluginBuilder(pdb.PluginName, pdb.New)
 	framework.RegisterPluginBuilder(nodegroup.PluginName, nodegroup.New)
+	framework.RegisterPluginBuilder(reservation.PluginName, reservation.New)
 
 	// Plugins for Queues
 	framework.RegisterPluginBuilder(proportion.PluginName, proportion.New)
 	framework.RegisterPluginBuilder(capacity.PluginName, capacity.New)
+	framework.RegisterPluginBuilder(fairshare.PluginName, fairshare.New)
 
 	// Plugins for Extender
 	framework.RegisterPluginBuilder(extender.PluginName, extender.New)
 
 	// Plugins for ResourceQuota
 	framework.RegisterPluginBuilder(resourcequota.PluginName, resourcequota.New)
+
+	// Schemas of the plugin arguments
+	framework.RegisterPluginArgumentsSchema(binpack.PluginName, binpack.ArgumentsSchema)
+	framework.RegisterPluginArgumentsSchema(deviceshare.PluginName, deviceshare.ArgumentsSchema)
+	framework.RegisterPluginArgumentsSchema(extender.PluginName, extender.ArgumentsSchema)
+	framework.RegisterPluginArgumentsSchema(fairshare.PluginName, fairshare.ArgumentsSchema)
+	framework.RegisterPluginArgumentsSchema(nodeorder.PluginName, nodeorder.ArgumentsSchema)
+	framework.RegisterPluginArgumentsSchema(numaaware.PluginName, numaaware.ArgumentsSchema)
+	framework.RegisterPluginArgumentsSchema(overcommit.PluginName, overcommit.ArgumentsSchema)
+	framework.RegisterPluginArgumentsSchema(predicates.PluginName, predicates.ArgumentsSchema)
+	framework.RegisterPluginArgumentsSchema(rescheduling.PluginName, rescheduling.ArgumentsSchema)
+	framework.RegisterPluginArgumentsSchema(reservation.PluginName, reservation.ArgumentsSchema)
+	framework.RegisterPluginArgumentsSchema(sla.PluginName, sla.ArgumentsSchema)
+	framework.RegisterPluginArgumentsSchema(tasktopology.PluginName, tasktopology.ArgumentsSchema)
+	framework.RegisterPluginArgumentsSchema(tdm.PluginName, tdm.ArgumentsSchema)
+	framework.RegisterPluginArgumentsSchema(usage.PluginName, usage.ArgumentsSchema)
 }
@@ -0,0 +1,183 @@
+/*
+Copyright 2026 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fairshare
+
+import (
+	"time"
+
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/metrics"
+)
+
+const (
+	// PluginName indicates name of volcano scheduler plugin.
+	PluginName = "fairshare"
+	// HalfLife is the duration after which the usage counts half, defaults to 24h.
+	HalfLife = "fairshare.halfLife"
+	// ConfigMap is the namespace/name of the ConfigMap the usage is persisted in across scheduler restarts,
+	// the usage is kept in memory only if it's not set.
+	ConfigMap = "fairshare.configMap"
+	// PersistPeriod is the minimum interval between two writes of the ConfigMap, defaults to 1m.
+	PersistPeriod = "fairshare.persistPeriod"
+
+	defaultHalfLife      = 24 * time.Hour
+	defaultPersistPeriod = time.Minute
+)
+
+// ArgumentsSchema is the schema of the arguments of fairshare plugin
+var ArgumentsSchema = framework.ArgumentsSchema{
+	HalfLife:      framework.DurationArgument,
+	ConfigMap:     framework.StringArgument,
+	PersistPeriod: framework.DurationArgument,
+}
+
+/*
+   actions: "enqueue, allocate, backfill, reclaim"
+   tiers:
+   - plugins:
+     - name: fairshare
+       arguments:
+         fairshare.halfLife: 24h
+         fairshare.configMap: volcano-system/volcano-scheduler-fairshare
+         fairshare.persistPeriod: 1m
+*/
+
+type fairSharePlugin struct {
+	halfLife      time.Duration
+	persistPeriod time.Duration
+
+	// namespace and name of the ConfigMap, empty if the usage is not persisted
+	cmNamespace string
+	cmName      string
+}
+
+// New function returns fairshare plugin object.
+func New(arguments framework.Arguments) framework.Plugin {
+	fp := &fairSharePlugin{
+		halfLife:      defaultHalfLife,
+		persistPeriod: defaultPersistPeriod,
+	}
+
+	if value, ok := arguments[HalfLife].(string); ok {
+		if halfLife, err := time.ParseDuration(value); err != nil || halfLife <= 0 {
+			klog.Warningf("Invalid half-life setting: %s in fairshare plugin, use default %v.", value, defaultHalfLife)
+		} else {
+			fp.halfLife = halfLife
+		}
+	}
+	if value, ok := arguments[PersistPeriod].(string); ok {
+		if period, err := time.ParseDuration(value); err != nil || period < 0 {
+			klog.Warningf("Invalid persist period setting: %s in fairshare plugin, use default %v.", value, defaultPersistPeriod)
+		} else {
+			fp.persistPeriod = period
+		}
+	}
+	var key string
+	arguments.GetString(&key, ConfigMap)
+	if key != "" {
+		namespace, name, err := cache.SplitMetaNamespaceKey(key)
+		if err != nil || namespace == "" || name == "" {
+			klog.Warningf("Invalid ConfigMap setting: %s in fairshare plugin, the usage is not persisted.", key)
+		} else {
+			fp.cmNamespace, fp.cmName = namespace, name
+		}
+	}
+
+	return fp
+}
+
+func (fp *fairSharePlugin) Name() string {
+	return PluginName
+}
+
+// QueueShare returns the decayed usage of the queue normalized by the total resource, it's 0 if
+// the fairshare plugin is not enabled.
+func QueueShare(queue api.QueueID, total *api.Resource) float64 {
+	return store.queueShare(string(queue), total)
+}
+
+// NamespaceShare returns the decayed usage of the namespace normalized by the total resource, it's 0 if
+// the fairshare plugin is not enabled.
+func NamespaceShare(namespace string, total *api.Resource) float64 {
+	return store.namespaceShare(namespace, total)
+}
+
+func (fp *fairSharePlugin) OnSessionOpen(ssn *framework.Session) {
+	klog.V(4).Infof("Enter fairshare plugin ...")
+	defer klog.V(4).Infof("Leaving fairshare plugin.")
+
+	// a dry-run session works on a copy of the usage, so the usage shared by the sessions is unchanged
+	usage := store
+	if ssn.DryRun() {
+		usage = store.clone()
+	}
+	if fp.cmName != "" {
+		if err := usage.load(ssn.KubeClient(), fp.cmNamespace, fp.cmName); err != nil {
+			klog.Errorf("Failed to load the usage from ConfigMap %s/%s: %v", fp.cmNamespace, fp.cmName, err)
+		}
+	}
+	// the filtered jobs are counted, the usage of a queue doesn't depend on the scheduler profile
+	usage.update(ssn.AllJobs(), time.Now(), fp.halfLife)
+
+	total := ssn.TotalResource
+	for queueID, queue := range ssn.Queues {
+		share := usage.queueShare(string(queueID), total)
+		klog.V(5).Infof("Queue <%s> has fair share usage <%v>", queue.Name, share)
+		if !ssn.DryRun() {
+			metrics.UpdateQueueFairShareUsage(queue.Name, share)
+		}
+	}
+
+	ssn.AddQueueOrderFn(fp.Name(), func(l, r interface{}) int {
+		lv := l.(*api.QueueInfo)
+		rv := r.(*api.QueueInfo)
+		return compare(usage.queueShare(string(lv.UID), total), usage.queueShare(string(rv.UID), total))
+	})
+
+	ssn.AddJobOrderFn(fp.Name(), func(l, r interface{}) int {
+		lv := l.(*api.JobInfo)
+		rv := r.(*api.JobInfo)
+		if lv.Namespace == rv.Namespace {
+			return 0
+		}
+		return compare(usage.namespaceShare(lv.Namespace, total), usage.namespaceShare(rv.Namespace, total))
+	})
+}
+
+func (fp *fairSharePlugin) OnSessionClose(ssn *framework.Session) {
+	if fp.cmName == "" || ssn.DryRun() {
+		return
+	}
+	if err := store.persist(ssn.KubeClient(), fp.cmNamespace, fp.cmName, time.Now(), fp.persistPeriod); err != nil {
+		klog.Errorf("Failed to persist the usage to ConfigMap %s/%s: %v", fp.cmNamespace, fp.cmName, err)
+	}
+}
+
+// compare orders the lower usage first
+func compare(l, r float64) int {
+	if l == r {
+		return 0
+	}
+	if l < r {
+		return -1
+	}
+	return 1
+}
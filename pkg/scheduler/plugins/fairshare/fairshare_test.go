@@ -0,0 +1,251 @@
+/*
+Copyright 2026 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fairshare
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/cmd/scheduler/app/options"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/uthelper"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func init() {
+	options.Default()
+}
+
+func buildRunningJob(name, namespace, queue, cpu, memory string) *api.JobInfo {
+	pod := util.BuildPod(namespace, name+"-0", "node1", v1.PodRunning, api.BuildResourceList(cpu, memory), name, nil, nil)
+	job := api.NewJobInfo(api.JobID(namespace+"/"+name), api.NewTaskInfo(pod))
+	job.Queue = api.QueueID(queue)
+	job.Namespace = namespace
+	return job
+}
+
+func almostEqual(l, r float64) bool {
+	return math.Abs(l-r) <= 1e-6*math.Max(math.Abs(l), math.Abs(r))
+}
+
+func TestUsageStoreUpdate(t *testing.T) {
+	halfLife := time.Hour
+	now := time.Now()
+	total := api.NewResource(api.BuildResourceList("4", "4Gi"))
+	jobs := []*api.JobInfo{
+		buildRunningJob("job1", "ns1", "q1", "2", "1Gi"),
+		buildRunningJob("job2", "ns2", "q2", "1", "2Gi"),
+	}
+
+	s := newUsageStore()
+	s.update(jobs, now, halfLife)
+	if len(s.record.Queues) != 0 {
+		t.Fatalf("expected no usage on the first update, got %v", s.record.Queues)
+	}
+
+	// after one half-life the usage is half of the one of holding the resources forever
+	s.update(jobs, now.Add(halfLife), halfLife)
+	expected := 2000 * halfLife.Seconds() / math.Ln2 / 2
+	if got := s.record.Queues["q1"][v1.ResourceCPU]; !almostEqual(got, expected) {
+		t.Errorf("expected cpu usage %v of q1, got %v", expected, got)
+	}
+	if got := s.queueShare("q1", total); !almostEqual(got, 0.25) {
+		t.Errorf("expected share 0.25 of q1, got %v", got)
+	}
+	// the share of q2 is dominated by memory
+	if got := s.queueShare("q2", total); !almostEqual(got, 0.25) {
+		t.Errorf("expected share 0.25 of q2, got %v", got)
+	}
+	if got := s.namespaceShare("ns1", total); !almostEqual(got, 0.25) {
+		t.Errorf("expected share 0.25 of ns1, got %v", got)
+	}
+	if got := s.queueShare("q3", total); got != 0 {
+		t.Errorf("expected share 0 of q3 without usage, got %v", got)
+	}
+
+	// without allocation the usage decays by half every half-life
+	s.update(nil, now.Add(2*halfLife), halfLife)
+	if got := s.queueShare("q1", total); !almostEqual(got, 0.125) {
+		t.Errorf("expected share 0.125 of q1 after decay, got %v", got)
+	}
+
+	// the usage is dropped once it's negligible
+	s.update(nil, now.Add(100*halfLife), halfLife)
+	if len(s.record.Queues) != 0 || len(s.record.Namespaces) != 0 {
+		t.Errorf("expected the usage to be dropped, got %v and %v", s.record.Queues, s.record.Namespaces)
+	}
+}
+
+func TestUsageStorePersist(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	now := time.Now()
+	halfLife := time.Hour
+
+	s := newUsageStore()
+	// a missing ConfigMap starts with no usage
+	if err := s.load(client, "volcano-system", "fairshare"); err != nil {
+		t.Fatalf("failed to load missing ConfigMap: %v", err)
+	}
+	jobs := []*api.JobInfo{buildRunningJob("job1", "ns1", "q1", "2", "1Gi")}
+	s.update(jobs, now, halfLife)
+	s.update(jobs, now.Add(time.Minute), halfLife)
+
+	if err := s.persist(client, "volcano-system", "fairshare", now, time.Minute); err != nil {
+		t.Fatalf("failed to create ConfigMap: %v", err)
+	}
+	// the period has not passed, the record is not written
+	s.update(jobs, now.Add(2*time.Minute), halfLife)
+	if err := s.persist(client, "volcano-system", "fairshare", now.Add(30*time.Second), time.Minute); err != nil {
+		t.Fatalf("failed to persist: %v", err)
+	}
+	restored := newUsageStore()
+	if err := restored.load(client, "volcano-system", "fairshare"); err != nil {
+		t.Fatalf("failed to load ConfigMap: %v", err)
+	}
+	if restored.record.LastUpdate.Equal(s.record.LastUpdate) {
+		t.Errorf("expected the record not to be written within the persist period")
+	}
+
+	if err := s.persist(client, "volcano-system", "fairshare", now.Add(time.Minute), time.Minute); err != nil {
+		t.Fatalf("failed to update ConfigMap: %v", err)
+	}
+	restored = newUsageStore()
+	if err := restored.load(client, "volcano-system", "fairshare"); err != nil {
+		t.Fatalf("failed to load ConfigMap: %v", err)
+	}
+	expected, _ := json.Marshal(s.record)
+	got, _ := json.Marshal(restored.record)
+	if string(expected) != string(got) {
+		t.Errorf("expected restored record %s, got %s", expected, got)
+	}
+}
+
+func TestFairShareOrder(t *testing.T) {
+	trueValue := true
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:              PluginName,
+					EnabledQueueOrder: &trueValue,
+					EnabledJobOrder:   &trueValue,
+				},
+			},
+		},
+	}
+	test := uthelper.TestCommonStruct{
+		Name:    "order queues and jobs by decayed usage",
+		Plugins: map[string]framework.PluginBuilder{PluginName: New},
+		PodGroups: []*schedulingv1beta1.PodGroup{
+			util.BuildPodGroup("pg1", "ns1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue),
+			util.BuildPodGroup("pg2", "ns2", "q2", 1, nil, schedulingv1beta1.PodGroupInqueue),
+		},
+		Pods: []*v1.Pod{
+			util.BuildPod("ns1", "p1", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg1", nil, nil),
+			util.BuildPod("ns2", "p2", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg2", nil, nil),
+		},
+		Nodes: []*v1.Node{
+			util.BuildNode("node1", api.BuildResourceList("4", "4G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil),
+		},
+		Queues: []*schedulingv1beta1.Queue{
+			util.BuildQueue("q1", 1, nil),
+			util.BuildQueue("q2", 1, nil),
+		},
+	}
+
+	// q1 and ns1 have used resources recently
+	store = newUsageStore()
+	store.record.LastUpdate = time.Now()
+	store.record.Queues["q1"] = usageList{v1.ResourceCPU: 1e6}
+	store.record.Namespaces["ns1"] = usageList{v1.ResourceCPU: 1e6}
+	defer func() { store = newUsageStore() }()
+
+	ssn := test.RegisterSession(tiers, nil)
+	defer test.Close()
+
+	if !ssn.QueueOrderFn(ssn.Queues["q2"], ssn.Queues["q1"]) {
+		t.Errorf("expected q2 without usage to be ordered before q1")
+	}
+	if ssn.QueueOrderFn(ssn.Queues["q1"], ssn.Queues["q2"]) {
+		t.Errorf("expected q1 with usage not to be ordered before q2")
+	}
+	if !ssn.JobOrderFn(ssn.Jobs["ns2/pg2"], ssn.Jobs["ns1/pg1"]) {
+		t.Errorf("expected the job of ns2 without usage to be ordered before the one of ns1")
+	}
+	if QueueShare("q1", ssn.TotalResource) <= 0 {
+		t.Errorf("expected a positive share of q1")
+	}
+}
+
+func TestFairShareExplain(t *testing.T) {
+	framework.RegisterPluginBuilder(PluginName, New)
+	defer framework.CleanupPluginBuilders()
+
+	trueValue := true
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:              PluginName,
+					EnabledQueueOrder: &trueValue,
+					EnabledJobOrder:   &trueValue,
+					Arguments: framework.Arguments{
+						ConfigMap:     "volcano-system/fairshare",
+						PersistPeriod: "0s",
+					},
+				},
+			},
+		},
+	}
+	sc := cache.NewDefaultMockSchedulerCache("volcano")
+	sc.AddOrUpdateNode(util.BuildNode("node1", api.BuildResourceList("4", "4G", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil))
+	sc.AddQueueV1beta1(util.BuildQueue("q1", 1, nil))
+	sc.AddPodGroupV1beta1(util.BuildPodGroup("pg1", "ns1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning))
+	sc.AddPod(util.BuildPod("ns1", "p1", "node1", v1.PodRunning, api.BuildResourceList("1", "1G"), "pg1", nil, nil))
+
+	store = newUsageStore()
+	store.record.LastUpdate = time.Now().Add(-time.Hour)
+	store.record.Queues["q1"] = usageList{v1.ResourceCPU: 1e6}
+	defer func() { store = newUsageStore() }()
+	expected, _ := json.Marshal(store.record)
+
+	if _, err := framework.Explain(sc, tiers, nil, nil, "ns1", "pg1"); err != nil {
+		t.Fatalf("failed to explain job: %v", err)
+	}
+	// explaining a job neither accumulates nor persists the usage
+	if got, _ := json.Marshal(store.record); string(got) != string(expected) {
+		t.Errorf("expected the usage %s not changed by explaining a job, got %s", expected, got)
+	}
+	if store.loaded {
+		t.Errorf("expected the usage not loaded by explaining a job")
+	}
+	if _, err := sc.Client().CoreV1().ConfigMaps("volcano-system").Get(context.TODO(), "fairshare", metav1.GetOptions{}); !errors.IsNotFound(err) {
+		t.Errorf("expected the usage not persisted by explaining a job, got %v", err)
+	}
+}
@@ -0,0 +1,275 @@
+/*
+Copyright 2026 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fairshare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+const (
+	// usageDataKey is the key of the usage record in the data of the ConfigMap
+	usageDataKey = "usage.json"
+	// minUsage is the usage below which an entry is dropped, it's less than one milli cpu second
+	minUsage = 1
+)
+
+// usageList is the decayed usage of each resource, in resource units multiplied by seconds
+type usageList map[v1.ResourceName]float64
+
+// usageRecord is the decayed usage of the queues and the namespaces, it's persisted as json
+type usageRecord struct {
+	Queues     map[string]usageList `json:"queues"`
+	Namespaces map[string]usageList `json:"namespaces"`
+	LastUpdate time.Time            `json:"lastUpdate"`
+}
+
+// usageStore keeps the usage record across sessions
+type usageStore struct {
+	sync.RWMutex
+	record      usageRecord
+	halfLife    time.Duration
+	loaded      bool
+	lastPersist time.Time
+}
+
+// store is shared by all the sessions, so that the usage accumulates across scheduling cycles
+var store = newUsageStore()
+
+func newUsageStore() *usageStore {
+	return &usageStore{
+		record: usageRecord{
+			Queues:     map[string]usageList{},
+			Namespaces: map[string]usageList{},
+		},
+	}
+}
+
+// clone returns a copy of the store, the copy is changed without changing the store
+func (s *usageStore) clone() *usageStore {
+	s.RLock()
+	defer s.RUnlock()
+
+	c := &usageStore{
+		record: usageRecord{
+			Queues:     make(map[string]usageList, len(s.record.Queues)),
+			Namespaces: make(map[string]usageList, len(s.record.Namespaces)),
+			LastUpdate: s.record.LastUpdate,
+		},
+		halfLife:    s.halfLife,
+		loaded:      s.loaded,
+		lastPersist: s.lastPersist,
+	}
+	for key, usage := range s.record.Queues {
+		c.record.Queues[key] = usage.clone()
+	}
+	for key, usage := range s.record.Namespaces {
+		c.record.Namespaces[key] = usage.clone()
+	}
+	return c
+}
+
+func (u usageList) clone() usageList {
+	c := make(usageList, len(u))
+	for rn, used := range u {
+		c[rn] = used
+	}
+	return c
+}
+
+// update decays the usage to now and adds the resources allocated to the jobs since the last update.
+// The allocation is assumed to be constant since the last update, so its contribution is
+// alloc * halfLife / ln2 * (1 - 2^(-elapsed/halfLife)), which is close to alloc * elapsed for short periods.
+func (s *usageStore) update(jobs []*api.JobInfo, now time.Time, halfLife time.Duration) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.halfLife = halfLife
+	if s.record.LastUpdate.IsZero() {
+		s.record.LastUpdate = now
+		return
+	}
+	elapsed := now.Sub(s.record.LastUpdate)
+	if elapsed <= 0 {
+		return
+	}
+	s.record.LastUpdate = now
+
+	factor := math.Exp2(-elapsed.Seconds() / halfLife.Seconds())
+	decay(s.record.Queues, factor)
+	decay(s.record.Namespaces, factor)
+
+	weight := halfLife.Seconds() / math.Ln2 * (1 - factor)
+	for _, job := range jobs {
+		allocated := api.EmptyResource()
+		for status, tasks := range job.TaskStatusIndex {
+			if !api.AllocatedStatus(status) {
+				continue
+			}
+			for _, task := range tasks {
+				allocated.Add(task.Resreq)
+			}
+		}
+		if allocated.IsEmpty() {
+			continue
+		}
+		add(s.record.Queues, string(job.Queue), allocated, weight)
+		add(s.record.Namespaces, job.Namespace, allocated, weight)
+	}
+}
+
+func decay(usages map[string]usageList, factor float64) {
+	for key, usage := range usages {
+		used := false
+		for rn := range usage {
+			usage[rn] *= factor
+			if usage[rn] >= minUsage {
+				used = true
+			}
+		}
+		if !used {
+			delete(usages, key)
+		}
+	}
+}
+
+func add(usages map[string]usageList, key string, allocated *api.Resource, weight float64) {
+	usage, found := usages[key]
+	if !found {
+		usage = usageList{}
+		usages[key] = usage
+	}
+	for _, rn := range allocated.ResourceNames() {
+		usage[rn] += allocated.Get(rn) * weight
+	}
+}
+
+// share returns the usage normalized by the usage of a consumer holding the total resource forever,
+// it's the max share of all the resources and is between 0 and 1 when the total resource is constant.
+func share(usage usageList, total *api.Resource, halfLife time.Duration) float64 {
+	res := 0.0
+	for rn, used := range usage {
+		capacity := total.Get(rn) * halfLife.Seconds() / math.Ln2
+		if capacity <= 0 || math.IsInf(capacity, 0) {
+			continue
+		}
+		res = math.Max(res, used/capacity)
+	}
+	return res
+}
+
+func (s *usageStore) queueShare(queue string, total *api.Resource) float64 {
+	s.RLock()
+	defer s.RUnlock()
+	return share(s.record.Queues[queue], total, s.halfLife)
+}
+
+func (s *usageStore) namespaceShare(namespace string, total *api.Resource) float64 {
+	s.RLock()
+	defer s.RUnlock()
+	return share(s.record.Namespaces[namespace], total, s.halfLife)
+}
+
+// load reads the usage record from the ConfigMap once, a missing ConfigMap starts with no usage
+func (s *usageStore) load(client kubernetes.Interface, namespace, name string) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.loaded {
+		return nil
+	}
+	cm, err := client.CoreV1().ConfigMaps(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			s.loaded = true
+			return nil
+		}
+		return err
+	}
+	s.loaded = true
+
+	data, found := cm.Data[usageDataKey]
+	if !found {
+		return nil
+	}
+	record := usageRecord{}
+	if err := json.Unmarshal([]byte(data), &record); err != nil {
+		return fmt.Errorf("failed to parse %s of ConfigMap %s/%s: %v", usageDataKey, namespace, name, err)
+	}
+	if record.Queues == nil {
+		record.Queues = map[string]usageList{}
+	}
+	if record.Namespaces == nil {
+		record.Namespaces = map[string]usageList{}
+	}
+	s.record = record
+	klog.V(3).Infof("Loaded the usage of %d queues and %d namespaces from ConfigMap %s/%s",
+		len(record.Queues), len(record.Namespaces), namespace, name)
+	return nil
+}
+
+// persist writes the usage record to the ConfigMap if the period has passed since the last write
+func (s *usageStore) persist(client kubernetes.Interface, namespace, name string, now time.Time, period time.Duration) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if now.Sub(s.lastPersist) < period {
+		return nil
+	}
+	data, err := json.Marshal(s.record)
+	if err != nil {
+		return err
+	}
+
+	configMaps := client.CoreV1().ConfigMaps(namespace)
+	cm, err := configMaps.Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		cm = &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Data:       map[string]string{usageDataKey: string(data)},
+		}
+		if _, err := configMaps.Create(context.TODO(), cm, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+	} else {
+		cm = cm.DeepCopy()
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[usageDataKey] = string(data)
+		if _, err := configMaps.Update(context.TODO(), cm, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+	}
+	s.lastPersist = now
+	return nil
+}
@@ -185,7 +185,9 @@ func (gp *gangPlugin) OnSessionClose(ssn *framework.Session) {
 				unreadyTaskCount, len(job.Tasks), job.FitError())
 
 			unScheduleJobCount++
-			metrics.RegisterJobRetries(job.Name)
+			if !ssn.DryRun() {
+				metrics.RegisterJobRetries(job.Name)
+			}
 
 			// TODO: If the Job is gang-unschedulable due to scheduling gates
 			// we need a new message and reason to tell users
@@ -218,9 +220,13 @@ func (gp *gangPlugin) OnSessionClose(ssn *framework.Session) {
 					job.Namespace, job.Name, err)
 			}
 		}
-		metrics.UpdateUnscheduleTaskCount(job.Name, int(unreadyTaskCount))
+		if !ssn.DryRun() {
+			metrics.UpdateUnscheduleTaskCount(job.Name, int(unreadyTaskCount))
+		}
 		unreadyTaskCount = 0
 	}
 
-	metrics.UpdateUnscheduleJobCount(unScheduleJobCount)
+	if !ssn.DryRun() {
+		metrics.UpdateUnscheduleJobCount(unScheduleJobCount)
+	}
 }
@@ -62,6 +62,18 @@ const (
 	PodTopologySpreadWeight = "podtopologyspread.weight"
 )
 
+// ArgumentsSchema is the schema of the arguments of nodeorder plugin
+var ArgumentsSchema = framework.ArgumentsSchema{
+	NodeAffinityWeight:      framework.IntArgument,
+	PodAffinityWeight:       framework.IntArgument,
+	LeastRequestedWeight:    framework.IntArgument,
+	BalancedResourceWeight:  framework.IntArgument,
+	MostRequestedWeight:     framework.IntArgument,
+	TaintTolerationWeight:   framework.IntArgument,
+	ImageLocalityWeight:     framework.IntArgument,
+	PodTopologySpreadWeight: framework.IntArgument,
+}
+
 type nodeOrderPlugin struct {
 	// Arguments given for the plugin
 	pluginArguments framework.Arguments
@@ -45,6 +45,11 @@ const (
 	NumaTopoWeight = "weight"
 )
 
+// ArgumentsSchema is the schema of the arguments of numa-aware plugin
+var ArgumentsSchema = framework.ArgumentsSchema{
+	NumaTopoWeight: framework.IntArgument,
+}
+
 type numaPlugin struct {
 	sync.Mutex
 	// Arguments given for the plugin
@@ -257,7 +262,7 @@ func getNumaNodeCntForCPUID(cpus cpuset.CPUSet, cpuDetails topology.CPUDetails)
 }
 
 func (pp *numaPlugin) OnSessionClose(ssn *framework.Session) {
-	if len(pp.taskBindNodeMap) == 0 {
+	if len(pp.taskBindNodeMap) == 0 || ssn.DryRun() {
 		return
 	}
 
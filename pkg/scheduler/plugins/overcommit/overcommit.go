@@ -37,6 +37,11 @@ const (
 	defaultOverCommitFactor = 1.2
 )
 
+// ArgumentsSchema is the schema of the arguments of overcommit plugin
+var ArgumentsSchema = framework.ArgumentsSchema{
+	overCommitFactor: framework.Float64Argument,
+}
+
 type overcommitPlugin struct {
 	// Arguments given for the plugin
 	pluginArguments  framework.Arguments
@@ -80,6 +80,24 @@ const (
 	ProportionalResourcesPrefix = ProportionalResource + "."
 )
 
+// ArgumentsSchema is the schema of the arguments of predicates plugin
+var ArgumentsSchema = framework.ArgumentsSchema{
+	NodeAffinityEnable:      framework.BoolArgument,
+	NodePortsEnable:         framework.BoolArgument,
+	TaintTolerationEnable:   framework.BoolArgument,
+	PodAffinityEnable:       framework.BoolArgument,
+	NodeVolumeLimitsEnable:  framework.BoolArgument,
+	VolumeZoneEnable:        framework.BoolArgument,
+	PodTopologySpreadEnable: framework.BoolArgument,
+	CachePredicate:          framework.BoolArgument,
+	ProportionalPredicate:   framework.BoolArgument,
+	// predicate.GPUSharingEnable and predicate.GPUNumberEnable are moved to deviceshare plugin, they are accepted but ignored
+	"predicate.GPUSharingEnable":      framework.BoolArgument,
+	"predicate.GPUNumberEnable":       framework.BoolArgument,
+	ProportionalResource:              framework.StringArgument,
+	ProportionalResourcesPrefix + "*": framework.Float64Argument,
+}
+
 type predicatesPlugin struct {
 	// Arguments given for the plugin
 	pluginArguments framework.Arguments
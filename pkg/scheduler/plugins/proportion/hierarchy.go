@@ -0,0 +1,160 @@
+/*
+Copyright 2026 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proportion
+
+import (
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/metrics"
+)
+
+const rootQueueID = "root"
+
+// buildHierarchicalQueueAttrs builds the attributes of all the queues in the tree under the root queue, and divides
+// the resources of each queue among its children by their weights. It returns false if the tree is invalid.
+func (pp *proportionPlugin) buildHierarchicalQueueAttrs(ssn *framework.Session) bool {
+	if _, found := ssn.Queues[rootQueueID]; !found {
+		klog.Errorf("The root queue <%s> is not found", rootQueueID)
+		return false
+	}
+
+	for _, queue := range ssn.Queues {
+		attr := newQueueAttr(queue)
+		attr.children = map[api.QueueID]*queueAttr{}
+		pp.queueOpts[queue.UID] = attr
+	}
+	for _, queue := range ssn.Queues {
+		if queue.UID == rootQueueID {
+			continue
+		}
+		parent := rootQueueID
+		if queue.Queue.Spec.Parent != "" {
+			parent = queue.Queue.Spec.Parent
+		}
+		parentAttr, found := pp.queueOpts[api.QueueID(parent)]
+		if !found {
+			klog.Errorf("The queue <%s> has invalid parent queue <%s>", queue.Name, parent)
+			return false
+		}
+		parentAttr.children[queue.UID] = pp.queueOpts[queue.UID]
+	}
+
+	rootAttr := pp.queueOpts[rootQueueID]
+	pp.setAncestors(rootAttr, nil)
+	for _, attr := range pp.queueOpts {
+		// the queues in a cycle are not reachable from the root queue
+		if attr.queueID != rootQueueID && len(attr.ancestors) == 0 {
+			klog.Errorf("The queue <%s> is not under the root queue", attr.name)
+			return false
+		}
+	}
+
+	for _, job := range ssn.Jobs {
+		klog.V(4).Infof("Considering Job <%s/%s>.", job.Namespace, job.Name)
+		attr := pp.queueOpts[job.Queue]
+		if len(attr.children) > 0 {
+			klog.Errorf("The Queue <%s> of Job <%s/%s> is not leaf queue", attr.name, job.Namespace, job.Name)
+			return false
+		}
+
+		addJobResources(attr, job)
+		for _, ancestor := range attr.ancestors {
+			addJobResources(pp.queueOpts[ancestor], job)
+		}
+	}
+
+	// the root queue deserves all the resources of the cluster
+	rootAttr.realCapability = pp.totalResource.Clone()
+	rootAttr.deserved = pp.totalResource.Clone()
+	pp.updateShare(rootAttr)
+	metrics.UpdateQueueDeserved(rootAttr.name, rootAttr.deserved.MilliCPU, rootAttr.deserved.Memory, rootAttr.deserved.ScalarResources)
+	pp.distributeHierarchically(rootAttr)
+
+	// Record metrics
+	for _, attr := range pp.queueOpts {
+		metrics.UpdateQueueAllocated(attr.name, attr.allocated.MilliCPU, attr.allocated.Memory, attr.allocated.ScalarResources)
+		metrics.UpdateQueueRequest(attr.name, attr.request.MilliCPU, attr.request.Memory, attr.request.ScalarResources)
+		metrics.UpdateQueueWeight(attr.name, attr.weight)
+	}
+
+	return true
+}
+
+// setAncestors sets the ancestors of the queue and all its descendants.
+func (pp *proportionPlugin) setAncestors(attr *queueAttr, ancestors []api.QueueID) {
+	attr.ancestors = ancestors
+	childAncestors := append(append([]api.QueueID{}, ancestors...), attr.queueID)
+	for _, child := range attr.children {
+		pp.setAncestors(child, childAncestors)
+	}
+}
+
+// distributeHierarchically divides the deserved of the queue among its children by their weights, then the deserved
+// of each child among its own children. The real capability of a child is bounded by the one of its parent minus the
+// guarantee of its siblings.
+func (pp *proportionPlugin) distributeHierarchically(attr *queueAttr) {
+	if len(attr.children) == 0 {
+		return
+	}
+
+	totalGuarantee := api.EmptyResource()
+	for _, child := range attr.children {
+		totalGuarantee.Add(child.guarantee)
+	}
+	for _, child := range attr.children {
+		realCapability := api.ExceededPart(attr.realCapability, totalGuarantee).Add(child.guarantee)
+		if child.capability != nil {
+			realCapability.MinDimensionResource(child.capability, api.Infinity)
+		}
+		child.realCapability = realCapability
+	}
+
+	pp.distribute(attr.deserved.Clone(), attr.children)
+	for _, child := range attr.children {
+		pp.distributeHierarchically(child)
+	}
+}
+
+// compareHierarchically compares the shares of the queues on the paths of the two queues right below their lowest
+// common ancestor first, so that the queues under a less used parent are ordered first, then the shares of the queues.
+func (pp *proportionPlugin) compareHierarchically(l, r *queueAttr) int {
+	lPath := append(append([]api.QueueID{}, l.ancestors...), l.queueID)
+	rPath := append(append([]api.QueueID{}, r.ancestors...), r.queueID)
+	for i := 0; i < len(lPath) && i < len(rPath); i++ {
+		if lPath[i] == rPath[i] {
+			continue
+		}
+		if result := compareShare(pp.queueOpts[lPath[i]].share, pp.queueOpts[rPath[i]].share); result != 0 {
+			return result
+		}
+		break
+	}
+
+	return compareShare(l.share, r.share)
+}
+
+func compareShare(l, r float64) int {
+	if l == r {
+		return 0
+	}
+	if l < r {
+		return -1
+	}
+	return 1
+}
@@ -18,6 +18,7 @@ package proportion
 
 import (
 	"math"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -47,6 +48,10 @@ type queueAttr struct {
 	name    string
 	weight  int32
 	share   float64
+	// ancestors are the queues from the root queue to the parent queue, children are the child queues,
+	// both are only set if hierarchy is enabled
+	ancestors []api.QueueID
+	children  map[api.QueueID]*queueAttr
 
 	deserved  *api.Resource
 	allocated *api.Resource
@@ -75,171 +80,32 @@ func (pp *proportionPlugin) Name() string {
 	return PluginName
 }
 
-func (pp *proportionPlugin) OnSessionOpen(ssn *framework.Session) {
-	// Prepare scheduling data for this session.
-	pp.totalResource.Add(ssn.TotalResource)
-
-	klog.V(4).Infof("The total resource is <%v>", pp.totalResource)
-	for _, queue := range ssn.Queues {
-		if len(queue.Queue.Spec.Guarantee.Resource) == 0 {
-			continue
-		}
-		guarantee := api.NewResource(queue.Queue.Spec.Guarantee.Resource)
-		pp.totalGuarantee.Add(guarantee)
-	}
-	klog.V(4).Infof("The total guarantee resource is <%v>", pp.totalGuarantee)
-	// Build attributes for Queues.
-	for _, job := range ssn.Jobs {
-		klog.V(4).Infof("Considering Job <%s/%s>.", job.Namespace, job.Name)
-		if _, found := pp.queueOpts[job.Queue]; !found {
-			queue := ssn.Queues[job.Queue]
-			attr := &queueAttr{
-				queueID: queue.UID,
-				name:    queue.Name,
-				weight:  queue.Weight,
-
-				deserved:  api.EmptyResource(),
-				allocated: api.EmptyResource(),
-				request:   api.EmptyResource(),
-				elastic:   api.EmptyResource(),
-				inqueue:   api.EmptyResource(),
-				guarantee: api.EmptyResource(),
-			}
-			if len(queue.Queue.Spec.Capability) != 0 {
-				attr.capability = api.NewResource(queue.Queue.Spec.Capability)
-				if attr.capability.MilliCPU <= 0 {
-					attr.capability.MilliCPU = math.MaxFloat64
-				}
-				if attr.capability.Memory <= 0 {
-					attr.capability.Memory = math.MaxFloat64
-				}
-			}
-			if len(queue.Queue.Spec.Guarantee.Resource) != 0 {
-				attr.guarantee = api.NewResource(queue.Queue.Spec.Guarantee.Resource)
-			}
-			realCapability := api.ExceededPart(pp.totalResource, pp.totalGuarantee).Add(attr.guarantee)
-			if attr.capability == nil {
-				attr.realCapability = realCapability
-			} else {
-				realCapability.MinDimensionResource(attr.capability, api.Infinity)
-				attr.realCapability = realCapability
-			}
-			pp.queueOpts[job.Queue] = attr
-			klog.V(4).Infof("Added Queue <%s> attributes.", job.Queue)
-		}
-
-		attr := pp.queueOpts[job.Queue]
-		for status, tasks := range job.TaskStatusIndex {
-			if api.AllocatedStatus(status) {
-				for _, t := range tasks {
-					attr.allocated.Add(t.Resreq)
-					attr.request.Add(t.Resreq)
-				}
-			} else if status == api.Pending {
-				for _, t := range tasks {
-					attr.request.Add(t.Resreq)
-				}
-			}
-		}
-
-		if job.PodGroup.Status.Phase == scheduling.PodGroupInqueue {
-			attr.inqueue.Add(job.DeductSchGatedResources(job.GetMinResources()))
-		}
-
-		// calculate inqueue resource for running jobs
-		// the judgement 'job.PodGroup.Status.Running >= job.PodGroup.Spec.MinMember' will work on cases such as the following condition:
-		// Considering a Spark job is completed(driver pod is completed) while the podgroup keeps running, the allocated resource will be reserved again if without the judgement.
-		if job.PodGroup.Status.Phase == scheduling.PodGroupRunning &&
-			job.PodGroup.Spec.MinResources != nil &&
-			int32(util.CalculateAllocatedTaskNum(job)) >= job.PodGroup.Spec.MinMember {
-			inqueued := util.GetInqueueResource(job, job.Allocated)
-			// deduct scheduling gated tasks from inqueue resources
-			attr.inqueue.Add(job.DeductSchGatedResources(inqueued))
-		}
-		attr.elastic.Add(job.GetElasticResources())
-		klog.V(5).Infof("Queue %s allocated <%s> request <%s> inqueue <%s> elastic <%s>",
-			attr.name, attr.allocated.String(), attr.request.String(), attr.inqueue.String(), attr.elastic.String())
-	}
-
-	// Record metrics
-	for queueID, queueInfo := range ssn.Queues {
-		if attr, ok := pp.queueOpts[queueID]; ok {
-			metrics.UpdateQueueAllocated(attr.name, attr.allocated.MilliCPU, attr.allocated.Memory, attr.allocated.ScalarResources)
-			metrics.UpdateQueueRequest(attr.name, attr.request.MilliCPU, attr.request.Memory, attr.request.ScalarResources)
-			metrics.UpdateQueueWeight(attr.name, attr.weight)
-			continue
-		}
-		metrics.UpdateQueueAllocated(queueInfo.Name, 0, 0, map[v1.ResourceName]float64{})
-		metrics.UpdateQueueRequest(queueInfo.Name, 0, 0, map[v1.ResourceName]float64{})
-	}
-
-	remaining := pp.totalResource.Clone()
-	meet := map[api.QueueID]struct{}{}
-	for {
-		totalWeight := int32(0)
-		for _, attr := range pp.queueOpts {
-			if _, found := meet[attr.queueID]; found {
+// HierarchyEnabled returns if hierarchy is enabled
+func (pp *proportionPlugin) HierarchyEnabled(ssn *framework.Session) bool {
+	for _, tier := range ssn.Tiers {
+		for _, plugin := range tier.Plugins {
+			if plugin.Name != PluginName {
 				continue
 			}
-			totalWeight += attr.weight
-		}
-
-		// If no queues, break
-		if totalWeight == 0 {
-			klog.V(4).Infof("Exiting when total weight is 0")
-			break
+			return plugin.EnabledHierarchy != nil && *plugin.EnabledHierarchy
 		}
+	}
+	return false
+}
 
-		oldRemaining := remaining.Clone()
-		// Calculates the deserved of each Queue.
-		// increasedDeserved is the increased value for attr.deserved of processed queues
-		// decreasedDeserved is the decreased value for attr.deserved of processed queues
-		increasedDeserved := api.EmptyResource()
-		decreasedDeserved := api.EmptyResource()
-		for _, attr := range pp.queueOpts {
-			klog.V(4).Infof("Considering Queue <%s>: weight <%d>, total weight <%d>.",
-				attr.name, attr.weight, totalWeight)
-			if _, found := meet[attr.queueID]; found {
-				continue
-			}
-
-			oldDeserved := attr.deserved.Clone()
-			attr.deserved.Add(remaining.Clone().Multi(float64(attr.weight) / float64(totalWeight)))
-
-			if attr.realCapability != nil {
-				attr.deserved.MinDimensionResource(attr.realCapability, api.Infinity)
-			}
-			attr.deserved.MinDimensionResource(attr.request, api.Zero)
-
-			attr.deserved = helpers.Max(attr.deserved, attr.guarantee)
-			pp.updateShare(attr)
-			klog.V(4).Infof("Format queue <%s> deserved resource to <%v>", attr.name, attr.deserved)
-
-			if attr.request.LessEqual(attr.deserved, api.Zero) {
-				meet[attr.queueID] = struct{}{}
-				klog.V(4).Infof("queue <%s> is meet", attr.name)
-			} else if equality.Semantic.DeepEqual(attr.deserved, oldDeserved) {
-				meet[attr.queueID] = struct{}{}
-				klog.V(4).Infof("queue <%s> is meet cause of the capability", attr.name)
-			}
-
-			klog.V(4).Infof("The attributes of queue <%s> in proportion: deserved <%v>, realCapability <%v>, allocate <%v>, request <%v>, elastic <%v>, share <%0.2f>",
-				attr.name, attr.deserved, attr.realCapability, attr.allocated, attr.request, attr.elastic, attr.share)
-
-			increased, decreased := attr.deserved.Diff(oldDeserved, api.Zero)
-			increasedDeserved.Add(increased)
-			decreasedDeserved.Add(decreased)
+func (pp *proportionPlugin) OnSessionOpen(ssn *framework.Session) {
+	// Prepare scheduling data for this session.
+	graceQueues := util.ApplyScheduledQuotas(ssn, time.Now())
+	pp.totalResource.Add(ssn.TotalResource)
 
-			// Record metrics
-			metrics.UpdateQueueDeserved(attr.name, attr.deserved.MilliCPU, attr.deserved.Memory, attr.deserved.ScalarResources)
-		}
+	klog.V(4).Infof("The total resource is <%v>", pp.totalResource)
 
-		remaining.Sub(increasedDeserved).Add(decreasedDeserved)
-		klog.V(4).Infof("Remaining resource is  <%s>", remaining)
-		if remaining.IsEmpty() || equality.Semantic.DeepEqual(remaining, oldRemaining) {
-			klog.V(4).Infof("Exiting when remaining is empty or no queue has more resource request:  <%v>", remaining)
-			break
-		}
+	hierarchyEnabled := pp.HierarchyEnabled(ssn)
+	readyToSchedule := true
+	if hierarchyEnabled {
+		readyToSchedule = pp.buildHierarchicalQueueAttrs(ssn)
+	} else {
+		pp.buildQueueAttrs(ssn)
 	}
 
 	ssn.AddQueueOrderFn(pp.Name(), func(l, r interface{}) int {
@@ -251,6 +117,13 @@ func (pp *proportionPlugin) OnSessionOpen(ssn *framework.Session) {
 			return int(rv.Queue.Spec.Priority) - int(lv.Queue.Spec.Priority)
 		}
 
+		if !readyToSchedule {
+			return 0
+		}
+		if hierarchyEnabled {
+			return pp.compareHierarchically(pp.queueOpts[lv.UID], pp.queueOpts[rv.UID])
+		}
+
 		if pp.queueOpts[lv.UID].share == pp.queueOpts[rv.UID].share {
 			return 0
 		}
@@ -265,10 +138,19 @@ func (pp *proportionPlugin) OnSessionOpen(ssn *framework.Session) {
 	ssn.AddReclaimableFn(pp.Name(), func(reclaimer *api.TaskInfo, reclaimees []*api.TaskInfo) ([]*api.TaskInfo, int) {
 		var victims []*api.TaskInfo
 		allocations := map[api.QueueID]*api.Resource{}
+		if !readyToSchedule {
+			klog.V(3).Infof("Proportion plugin failed to check queue's hierarchical structure!")
+			return victims, util.Reject
+		}
 
 		for _, reclaimee := range reclaimees {
 			job := ssn.Jobs[reclaimee.Job]
 			attr := pp.queueOpts[job.Queue]
+			if graceQueues[job.Queue] {
+				klog.V(4).Infof("Queue <%s> is in the grace period of its scheduled quota window, skip reclaimee <%s/%s>",
+					attr.name, reclaimee.Namespace, reclaimee.Name)
+				continue
+			}
 
 			if _, found := allocations[job.Queue]; !found {
 				allocations[job.Queue] = attr.allocated.Clone()
@@ -285,6 +167,11 @@ func (pp *proportionPlugin) OnSessionOpen(ssn *framework.Session) {
 	})
 
 	ssn.AddOverusedFn(pp.Name(), func(obj interface{}) bool {
+		if !readyToSchedule {
+			klog.V(3).Infof("Proportion plugin failed to check queue's hierarchical structure!")
+			return true
+		}
+
 		queue := obj.(*api.QueueInfo)
 		attr := pp.queueOpts[queue.UID]
 
@@ -293,22 +180,40 @@ func (pp *proportionPlugin) OnSessionOpen(ssn *framework.Session) {
 		if overused {
 			klog.V(3).Infof("Queue <%v>: deserved <%v>, allocated <%v>, share <%v>",
 				queue.Name, attr.deserved, attr.allocated, attr.share)
+			return true
 		}
 
-		return overused
+		// If hierarchy is enabled, the queue is overused if any of its ancestors is overused.
+		for _, ancestor := range attr.ancestors {
+			ancestorAttr := pp.queueOpts[ancestor]
+			if ancestorAttr.deserved.LessEqual(ancestorAttr.allocated, api.Zero) {
+				klog.V(3).Infof("Queue <%v>: ancestor <%v> deserved <%v>, allocated <%v>, share <%v>",
+					queue.Name, ancestorAttr.name, ancestorAttr.deserved, ancestorAttr.allocated, ancestorAttr.share)
+				return true
+			}
+		}
+
+		return false
 	})
 
 	queueAllocatable := func(queue *api.QueueInfo, candidate *api.TaskInfo) bool {
-		attr := pp.queueOpts[queue.UID]
-
-		futureUsed := attr.allocated.Clone().Add(candidate.Resreq)
-		allocatable := futureUsed.LessEqualWithDimension(attr.deserved, candidate.Resreq)
-		if !allocatable {
-			klog.V(3).Infof("Queue <%v>: deserved <%v>, allocated <%v>; Candidate <%v>: resource request <%v>",
-				queue.Name, attr.deserved, attr.allocated, candidate.Name, candidate.Resreq)
+		if !readyToSchedule {
+			klog.V(3).Infof("Proportion plugin failed to check queue's hierarchical structure!")
+			return false
 		}
 
-		return allocatable
+		attr := pp.queueOpts[queue.UID]
+		if hierarchyEnabled && len(attr.children) > 0 {
+			klog.V(3).Infof("Queue <%s> is not a leaf queue, can not allocate task <%s>.", queue.Name, candidate.Name)
+			return false
+		}
+		// If hierarchy is enabled, the task must fit in the deserved of the queue and all its ancestors.
+		for _, ancestor := range attr.ancestors {
+			if !pp.queueAllocatable(pp.queueOpts[ancestor], candidate) {
+				return false
+			}
+		}
+		return pp.queueAllocatable(attr, candidate)
 	}
 
 	ssn.AddAllocatableFn(pp.Name(), func(queue *api.QueueInfo, candidate *api.TaskInfo) bool {
@@ -321,10 +226,19 @@ func (pp *proportionPlugin) OnSessionOpen(ssn *framework.Session) {
 	})
 
 	ssn.AddJobEnqueueableFn(pp.Name(), func(obj interface{}) int {
+		if !readyToSchedule {
+			klog.V(3).Infof("Proportion plugin failed to check queue's hierarchical structure!")
+			return util.Reject
+		}
+
 		job := obj.(*api.JobInfo)
 		queueID := job.Queue
 		attr := pp.queueOpts[queueID]
 		queue := ssn.Queues[queueID]
+		if hierarchyEnabled && len(attr.children) > 0 {
+			klog.V(3).Infof("Queue <%s> is not a leaf queue, can not enqueue job <%s/%s>.", queue.Name, job.Namespace, job.Name)
+			return util.Reject
+		}
 		// If no capability is set, always enqueue the job.
 		if attr.realCapability == nil {
 			klog.V(4).Infof("Capability of queue <%s> was not set, allow job <%s/%s> to Inqueue.",
@@ -338,17 +252,20 @@ func (pp *proportionPlugin) OnSessionOpen(ssn *framework.Session) {
 		}
 		minReq := job.GetMinResources()
 
-		klog.V(5).Infof("job %s min resource <%s>, queue %s capability <%s> allocated <%s> inqueue <%s> elastic <%s>",
-			job.Name, minReq.String(), queue.Name, attr.realCapability.String(), attr.allocated.String(), attr.inqueue.String(), attr.elastic.String())
-		// The queue resource quota limit has not reached
-		r := minReq.Clone().Add(attr.allocated).Add(attr.inqueue).Sub(attr.elastic)
-
-		inqueue := r.LessEqualWithDimension(attr.realCapability, minReq)
+		// If hierarchy is enabled, the job must fit in the real capability of the queue and all its ancestors.
+		inqueue := pp.jobEnqueueable(attr, job, minReq)
+		for _, ancestor := range attr.ancestors {
+			inqueue = inqueue && pp.jobEnqueueable(pp.queueOpts[ancestor], job, minReq)
+		}
 		klog.V(5).Infof("job %s inqueue %v", job.Name, inqueue)
 		if inqueue {
 			// deduct the resources of scheduling gated tasks in a job when calculating inqueued resources
 			// so that it will not block other jobs from being inqueued.
-			attr.inqueue.Add(job.DeductSchGatedResources(minReq))
+			deductedResources := job.DeductSchGatedResources(minReq)
+			attr.inqueue.Add(deductedResources)
+			for _, ancestor := range attr.ancestors {
+				pp.queueOpts[ancestor].inqueue.Add(deductedResources)
+			}
 			return util.Permit
 		}
 		ssn.RecordPodGroupEvent(job.PodGroup, v1.EventTypeNormal, string(scheduling.PodGroupUnschedulableType), "queue resource quota insufficient")
@@ -359,22 +276,42 @@ func (pp *proportionPlugin) OnSessionOpen(ssn *framework.Session) {
 	ssn.AddEventHandler(&framework.EventHandler{
 		AllocateFunc: func(event *framework.Event) {
 			job := ssn.Jobs[event.Task.Job]
-			attr := pp.queueOpts[job.Queue]
+			attr, found := pp.queueOpts[job.Queue]
+			if !found {
+				klog.V(4).Infof("Queue <%s> of job <%s/%s> is not found in proportion", job.Queue, job.Namespace, job.Name)
+				return
+			}
 			attr.allocated.Add(event.Task.Resreq)
 			metrics.UpdateQueueAllocated(attr.name, attr.allocated.MilliCPU, attr.allocated.Memory, attr.allocated.ScalarResources)
 
 			pp.updateShare(attr)
+			for _, ancestor := range attr.ancestors {
+				ancestorAttr := pp.queueOpts[ancestor]
+				ancestorAttr.allocated.Add(event.Task.Resreq)
+				metrics.UpdateQueueAllocated(ancestorAttr.name, ancestorAttr.allocated.MilliCPU, ancestorAttr.allocated.Memory, ancestorAttr.allocated.ScalarResources)
+				pp.updateShare(ancestorAttr)
+			}
 
 			klog.V(4).Infof("Proportion AllocateFunc: task <%v/%v>, resreq <%v>,  share <%v>",
 				event.Task.Namespace, event.Task.Name, event.Task.Resreq, attr.share)
 		},
 		DeallocateFunc: func(event *framework.Event) {
 			job := ssn.Jobs[event.Task.Job]
-			attr := pp.queueOpts[job.Queue]
+			attr, found := pp.queueOpts[job.Queue]
+			if !found {
+				klog.V(4).Infof("Queue <%s> of job <%s/%s> is not found in proportion", job.Queue, job.Namespace, job.Name)
+				return
+			}
 			attr.allocated.Sub(event.Task.Resreq)
 			metrics.UpdateQueueAllocated(attr.name, attr.allocated.MilliCPU, attr.allocated.Memory, attr.allocated.ScalarResources)
 
 			pp.updateShare(attr)
+			for _, ancestor := range attr.ancestors {
+				ancestorAttr := pp.queueOpts[ancestor]
+				ancestorAttr.allocated.Sub(event.Task.Resreq)
+				metrics.UpdateQueueAllocated(ancestorAttr.name, ancestorAttr.allocated.MilliCPU, ancestorAttr.allocated.Memory, ancestorAttr.allocated.ScalarResources)
+				pp.updateShare(ancestorAttr)
+			}
 
 			klog.V(4).Infof("Proportion EvictFunc: task <%v/%v>, resreq <%v>,  share <%v>",
 				event.Task.Namespace, event.Task.Name, event.Task.Resreq, attr.share)
@@ -382,6 +319,205 @@ func (pp *proportionPlugin) OnSessionOpen(ssn *framework.Session) {
 	})
 }
 
+func (pp *proportionPlugin) buildQueueAttrs(ssn *framework.Session) {
+	for _, queue := range ssn.Queues {
+		if len(queue.Queue.Spec.Guarantee.Resource) == 0 {
+			continue
+		}
+		guarantee := api.NewResource(queue.Queue.Spec.Guarantee.Resource)
+		pp.totalGuarantee.Add(guarantee)
+	}
+	klog.V(4).Infof("The total guarantee resource is <%v>", pp.totalGuarantee)
+	// Build attributes for Queues, the jobs of the other scheduler profiles share the queues.
+	for _, job := range ssn.AllJobs() {
+		klog.V(4).Infof("Considering Job <%s/%s>.", job.Namespace, job.Name)
+		if _, found := pp.queueOpts[job.Queue]; !found {
+			attr := newQueueAttr(ssn.Queues[job.Queue])
+			realCapability := api.ExceededPart(pp.totalResource, pp.totalGuarantee).Add(attr.guarantee)
+			if attr.capability == nil {
+				attr.realCapability = realCapability
+			} else {
+				realCapability.MinDimensionResource(attr.capability, api.Infinity)
+				attr.realCapability = realCapability
+			}
+			pp.queueOpts[job.Queue] = attr
+			klog.V(4).Infof("Added Queue <%s> attributes.", job.Queue)
+		}
+
+		attr := pp.queueOpts[job.Queue]
+		addJobResources(attr, job)
+	}
+
+	// Record metrics
+	for queueID, queueInfo := range ssn.Queues {
+		if attr, ok := pp.queueOpts[queueID]; ok {
+			metrics.UpdateQueueAllocated(attr.name, attr.allocated.MilliCPU, attr.allocated.Memory, attr.allocated.ScalarResources)
+			metrics.UpdateQueueRequest(attr.name, attr.request.MilliCPU, attr.request.Memory, attr.request.ScalarResources)
+			metrics.UpdateQueueWeight(attr.name, attr.weight)
+			continue
+		}
+		metrics.UpdateQueueAllocated(queueInfo.Name, 0, 0, map[v1.ResourceName]float64{})
+		metrics.UpdateQueueRequest(queueInfo.Name, 0, 0, map[v1.ResourceName]float64{})
+	}
+
+	pp.distribute(pp.totalResource.Clone(), pp.queueOpts)
+}
+
+// newQueueAttr returns the attributes of the queue without any job
+func newQueueAttr(queue *api.QueueInfo) *queueAttr {
+	attr := &queueAttr{
+		queueID: queue.UID,
+		name:    queue.Name,
+		weight:  queue.Weight,
+
+		deserved:  api.EmptyResource(),
+		allocated: api.EmptyResource(),
+		request:   api.EmptyResource(),
+		elastic:   api.EmptyResource(),
+		inqueue:   api.EmptyResource(),
+		guarantee: api.EmptyResource(),
+	}
+	if len(queue.Queue.Spec.Capability) != 0 {
+		attr.capability = api.NewResource(queue.Queue.Spec.Capability)
+		if attr.capability.MilliCPU <= 0 {
+			attr.capability.MilliCPU = math.MaxFloat64
+		}
+		if attr.capability.Memory <= 0 {
+			attr.capability.Memory = math.MaxFloat64
+		}
+	}
+	if len(queue.Queue.Spec.Guarantee.Resource) != 0 {
+		attr.guarantee = api.NewResource(queue.Queue.Spec.Guarantee.Resource)
+	}
+	return attr
+}
+
+// addJobResources adds the allocated, requested, inqueue and elastic resources of the job to the queue
+func addJobResources(attr *queueAttr, job *api.JobInfo) {
+	for status, tasks := range job.TaskStatusIndex {
+		if api.AllocatedStatus(status) {
+			for _, t := range tasks {
+				attr.allocated.Add(t.Resreq)
+				attr.request.Add(t.Resreq)
+			}
+		} else if status == api.Pending {
+			for _, t := range tasks {
+				attr.request.Add(t.Resreq)
+			}
+		}
+	}
+
+	if job.PodGroup.Status.Phase == scheduling.PodGroupInqueue {
+		attr.inqueue.Add(job.DeductSchGatedResources(job.GetMinResources()))
+	}
+
+	// calculate inqueue resource for running jobs
+	// the judgement 'job.PodGroup.Status.Running >= job.PodGroup.Spec.MinMember' will work on cases such as the following condition:
+	// Considering a Spark job is completed(driver pod is completed) while the podgroup keeps running, the allocated resource will be reserved again if without the judgement.
+	if job.PodGroup.Status.Phase == scheduling.PodGroupRunning &&
+		job.PodGroup.Spec.MinResources != nil &&
+		int32(util.CalculateAllocatedTaskNum(job)) >= job.PodGroup.Spec.MinMember {
+		inqueued := util.GetInqueueResource(job, job.Allocated)
+		// deduct scheduling gated tasks from inqueue resources
+		attr.inqueue.Add(job.DeductSchGatedResources(inqueued))
+	}
+	attr.elastic.Add(job.GetElasticResources())
+	klog.V(5).Infof("Queue %s allocated <%s> request <%s> inqueue <%s> elastic <%s>",
+		attr.name, attr.allocated.String(), attr.request.String(), attr.inqueue.String(), attr.elastic.String())
+}
+
+// distribute divides the remaining resource among the queues by their weights. The deserved of a queue is at most
+// its real capability and request, and at least its guarantee, the resource beyond it is divided among the other queues.
+func (pp *proportionPlugin) distribute(remaining *api.Resource, queues map[api.QueueID]*queueAttr) {
+	meet := map[api.QueueID]struct{}{}
+	for {
+		totalWeight := int32(0)
+		for _, attr := range queues {
+			if _, found := meet[attr.queueID]; found {
+				continue
+			}
+			totalWeight += attr.weight
+		}
+
+		// If no queues, break
+		if totalWeight == 0 {
+			klog.V(4).Infof("Exiting when total weight is 0")
+			break
+		}
+
+		oldRemaining := remaining.Clone()
+		// Calculates the deserved of each Queue.
+		// increasedDeserved is the increased value for attr.deserved of processed queues
+		// decreasedDeserved is the decreased value for attr.deserved of processed queues
+		increasedDeserved := api.EmptyResource()
+		decreasedDeserved := api.EmptyResource()
+		for _, attr := range queues {
+			klog.V(4).Infof("Considering Queue <%s>: weight <%d>, total weight <%d>.",
+				attr.name, attr.weight, totalWeight)
+			if _, found := meet[attr.queueID]; found {
+				continue
+			}
+
+			oldDeserved := attr.deserved.Clone()
+			attr.deserved.Add(remaining.Clone().Multi(float64(attr.weight) / float64(totalWeight)))
+
+			if attr.realCapability != nil {
+				attr.deserved.MinDimensionResource(attr.realCapability, api.Infinity)
+			}
+			attr.deserved.MinDimensionResource(attr.request, api.Zero)
+
+			attr.deserved = helpers.Max(attr.deserved, attr.guarantee)
+			pp.updateShare(attr)
+			klog.V(4).Infof("Format queue <%s> deserved resource to <%v>", attr.name, attr.deserved)
+
+			if attr.request.LessEqual(attr.deserved, api.Zero) {
+				meet[attr.queueID] = struct{}{}
+				klog.V(4).Infof("queue <%s> is meet", attr.name)
+			} else if equality.Semantic.DeepEqual(attr.deserved, oldDeserved) {
+				meet[attr.queueID] = struct{}{}
+				klog.V(4).Infof("queue <%s> is meet cause of the capability", attr.name)
+			}
+
+			klog.V(4).Infof("The attributes of queue <%s> in proportion: deserved <%v>, realCapability <%v>, allocate <%v>, request <%v>, elastic <%v>, share <%0.2f>",
+				attr.name, attr.deserved, attr.realCapability, attr.allocated, attr.request, attr.elastic, attr.share)
+
+			increased, decreased := attr.deserved.Diff(oldDeserved, api.Zero)
+			increasedDeserved.Add(increased)
+			decreasedDeserved.Add(decreased)
+
+			// Record metrics
+			metrics.UpdateQueueDeserved(attr.name, attr.deserved.MilliCPU, attr.deserved.Memory, attr.deserved.ScalarResources)
+		}
+
+		remaining.Sub(increasedDeserved).Add(decreasedDeserved)
+		klog.V(4).Infof("Remaining resource is  <%s>", remaining)
+		if remaining.IsEmpty() || equality.Semantic.DeepEqual(remaining, oldRemaining) {
+			klog.V(4).Infof("Exiting when remaining is empty or no queue has more resource request:  <%v>", remaining)
+			break
+		}
+	}
+}
+
+func (pp *proportionPlugin) queueAllocatable(attr *queueAttr, candidate *api.TaskInfo) bool {
+	futureUsed := attr.allocated.Clone().Add(candidate.Resreq)
+	allocatable := futureUsed.LessEqualWithDimension(attr.deserved, candidate.Resreq)
+	if !allocatable {
+		klog.V(3).Infof("Queue <%v>: deserved <%v>, allocated <%v>; Candidate <%v>: resource request <%v>",
+			attr.name, attr.deserved, attr.allocated, candidate.Name, candidate.Resreq)
+	}
+
+	return allocatable
+}
+
+func (pp *proportionPlugin) jobEnqueueable(attr *queueAttr, job *api.JobInfo, minReq *api.Resource) bool {
+	klog.V(5).Infof("job %s min resource <%s>, queue %s capability <%s> allocated <%s> inqueue <%s> elastic <%s>",
+		job.Name, minReq.String(), attr.name, attr.realCapability.String(), attr.allocated.String(), attr.inqueue.String(), attr.elastic.String())
+	// The queue resource quota limit has not reached
+	r := minReq.Clone().Add(attr.allocated).Add(attr.inqueue).Sub(attr.elastic)
+
+	return r.LessEqualWithDimension(attr.realCapability, minReq)
+}
+
 func (pp *proportionPlugin) OnSessionClose(ssn *framework.Session) {
 	pp.totalResource = nil
 	pp.totalGuarantee = nil
@@ -486,3 +486,218 @@ func TestAllocate(t *testing.T) {
 		})
 	}
 }
+
+func buildQueueWithParent(name, parent string, weight int32) *schedulingv1beta1.Queue {
+	queue := util.BuildQueue(name, weight, nil)
+	queue.Spec.Parent = parent
+	return queue
+}
+
+func TestHierarchicalProportion(t *testing.T) {
+	trueValue := true
+	actions := []framework.Action{allocate.New()}
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:               PluginName,
+					EnabledQueueOrder:  &trueValue,
+					EnabledAllocatable: &trueValue,
+					EnabledOverused:    &trueValue,
+					EnabledHierarchy:   &trueValue,
+				},
+			},
+		},
+	}
+
+	n1 := util.BuildNode("n1", api.BuildResourceList("8", "8Gi", []api.ScalarResource{{Name: "pods", Value: "20"}}...), make(map[string]string))
+	// root -> org-a (weight 3) -> a1, a2; root -> org-b (weight 1) -> b1
+	queues := []*schedulingv1beta1.Queue{
+		buildQueueWithParent("root", "", 1),
+		buildQueueWithParent("org-a", "root", 3),
+		buildQueueWithParent("org-b", "root", 1),
+		buildQueueWithParent("a1", "org-a", 1),
+		buildQueueWithParent("a2", "org-a", 1),
+		buildQueueWithParent("b1", "org-b", 1),
+	}
+	buildPods := func(pg string, num int) []*apiv1.Pod {
+		var pods []*apiv1.Pod
+		for i := 0; i < num; i++ {
+			pods = append(pods, util.BuildPod("ns1", pg+"-"+strconv.Itoa(i), "", apiv1.PodPending, api.BuildResourceList("1", "1Gi"), pg, nil, nil))
+		}
+		return pods
+	}
+
+	tests := []struct {
+		uthelper.TestCommonStruct
+		expectedDeserved  map[api.QueueID]float64
+		expectedAllocated map[api.QueueID]float64
+	}{
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "case0: the deserved of each queue is divided among its children by weight",
+				Pods: append(append(buildPods("pg1", 6), buildPods("pg2", 6)...), buildPods("pg3", 6)...),
+				PodGroups: []*schedulingv1beta1.PodGroup{
+					util.BuildPodGroup("pg1", "ns1", "a1", 1, nil, schedulingv1beta1.PodGroupInqueue),
+					util.BuildPodGroup("pg2", "ns1", "a2", 1, nil, schedulingv1beta1.PodGroupInqueue),
+					util.BuildPodGroup("pg3", "ns1", "b1", 1, nil, schedulingv1beta1.PodGroupInqueue),
+				},
+				Nodes:  []*apiv1.Node{n1},
+				Queues: queues,
+			},
+			expectedDeserved:  map[api.QueueID]float64{"root": 8000, "org-a": 6000, "org-b": 2000, "a1": 3000, "a2": 3000, "b1": 2000},
+			expectedAllocated: map[api.QueueID]float64{"root": 8000, "org-a": 6000, "org-b": 2000, "a1": 3000, "a2": 3000, "b1": 2000},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "case1: the resources not requested by a subtree are divided among the other subtrees",
+				Pods: append(append(buildPods("pg4", 6), buildPods("pg5", 1)...), buildPods("pg6", 6)...),
+				PodGroups: []*schedulingv1beta1.PodGroup{
+					util.BuildPodGroup("pg4", "ns1", "a1", 1, nil, schedulingv1beta1.PodGroupInqueue),
+					util.BuildPodGroup("pg5", "ns1", "a2", 1, nil, schedulingv1beta1.PodGroupInqueue),
+					util.BuildPodGroup("pg6", "ns1", "b1", 1, nil, schedulingv1beta1.PodGroupInqueue),
+				},
+				Nodes:  []*apiv1.Node{n1},
+				Queues: queues,
+			},
+			expectedDeserved:  map[api.QueueID]float64{"root": 8000, "org-a": 6000, "org-b": 2000, "a1": 5000, "a2": 1000, "b1": 2000},
+			expectedAllocated: map[api.QueueID]float64{"root": 8000, "org-a": 6000, "org-b": 2000, "a1": 5000, "a2": 1000, "b1": 2000},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name: "case2: no task is allocated if a job is in a non-leaf queue",
+				Pods: append(buildPods("pg7", 1), buildPods("pg8", 1)...),
+				PodGroups: []*schedulingv1beta1.PodGroup{
+					util.BuildPodGroup("pg7", "ns1", "org-a", 1, nil, schedulingv1beta1.PodGroupInqueue),
+					util.BuildPodGroup("pg8", "ns1", "b1", 1, nil, schedulingv1beta1.PodGroupInqueue),
+				},
+				Nodes:  []*apiv1.Node{n1},
+				Queues: queues,
+			},
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			var pp *proportionPlugin
+			test.Plugins = map[string]framework.PluginBuilder{PluginName: func(arguments framework.Arguments) framework.Plugin {
+				pp = New(arguments).(*proportionPlugin)
+				return pp
+			}}
+			test.RegisterSession(tiers, nil)
+			defer test.Close()
+
+			for queue, expected := range test.expectedDeserved {
+				if got := pp.queueOpts[queue].deserved.MilliCPU; got != expected {
+					t.Errorf("expected deserved cpu %v of queue %s, got %v", expected, queue, got)
+				}
+			}
+			test.Run(actions)
+			for queue, expected := range test.expectedAllocated {
+				if got := pp.queueOpts[queue].allocated.MilliCPU; got != expected {
+					t.Errorf("expected allocated cpu %v of queue %s, got %v", expected, queue, got)
+				}
+			}
+			if test.expectedAllocated == nil {
+				if err := test.CheckBind(i); err != nil {
+					t.Error(err)
+				}
+			}
+		})
+	}
+}
+
+func TestQueueSharedByProfiles(t *testing.T) {
+	plugins := map[string]framework.PluginBuilder{PluginName: New}
+	trueValue := true
+	actions := []framework.Action{allocate.New()}
+
+	n1 := util.BuildNode("n1", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string))
+	// p1 is scheduled by the other profile, but it still uses the capability of the queue
+	p1 := util.BuildPod("ns1", "p1", "n1", apiv1.PodRunning, api.BuildResourceList("2", "2Gi"), "pg1", make(map[string]string), make(map[string]string))
+	p2 := util.BuildPod("ns1", "p2", "", apiv1.PodPending, api.BuildResourceList("1", "1Gi"), "pg2", make(map[string]string), make(map[string]string))
+	pg1 := util.BuildPodGroup("pg1", "ns1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning)
+	pg2 := util.BuildPodGroup("pg2", "ns1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue)
+	queue1 := util.BuildQueue("q1", 1, api.BuildResourceList("2", "4Gi"))
+
+	tests := []uthelper.TestCommonStruct{
+		{
+			Name:           "case0: the queue is full with the jobs of the session",
+			Plugins:        plugins,
+			Pods:           []*apiv1.Pod{p1, p2},
+			Nodes:          []*apiv1.Node{n1},
+			PodGroups:      []*schedulingv1beta1.PodGroup{pg1, pg2},
+			Queues:         []*schedulingv1beta1.Queue{queue1},
+			ExpectBindMap:  map[string]string{},
+			ExpectBindsNum: 0,
+		},
+		{
+			Name:           "case1: the queue is full with the jobs of the other profile",
+			Plugins:        plugins,
+			Pods:           []*apiv1.Pod{p1, p2},
+			Nodes:          []*apiv1.Node{n1},
+			PodGroups:      []*schedulingv1beta1.PodGroup{pg1, pg2},
+			Queues:         []*schedulingv1beta1.Queue{queue1},
+			JobFilter:      func(job *api.JobInfo) bool { return job.Name != "pg1" },
+			ExpectBindMap:  map[string]string{},
+			ExpectBindsNum: 0,
+		},
+	}
+
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:               PluginName,
+					EnabledAllocatable: &trueValue,
+					EnabledQueueOrder:  &trueValue,
+				},
+			},
+		},
+	}
+
+	for i, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			test.RegisterSession(tiers, nil)
+			defer test.Close()
+			test.Run(actions)
+			if err := test.CheckAll(i); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestEventHandlersWithoutRootQueue(t *testing.T) {
+	trueValue := true
+	tiers := []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:             PluginName,
+					EnabledHierarchy: &trueValue,
+				},
+			},
+		},
+	}
+
+	// the hierarchy is not built without the root queue, no queue of proportion is found
+	test := uthelper.TestCommonStruct{
+		Name:      "the tasks are allocated and deallocated without the root queue",
+		Plugins:   map[string]framework.PluginBuilder{PluginName: New},
+		Pods:      []*apiv1.Pod{util.BuildPod("ns1", "p1", "", apiv1.PodPending, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil)},
+		Nodes:     []*apiv1.Node{util.BuildNode("n1", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil)},
+		PodGroups: []*schedulingv1beta1.PodGroup{util.BuildPodGroup("pg1", "ns1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue)},
+		Queues:    []*schedulingv1beta1.Queue{buildQueueWithParent("q1", "", 1)},
+	}
+	ssn := test.RegisterSession(tiers, nil)
+	defer test.Close()
+
+	stmt := framework.NewStatement(ssn)
+	for _, task := range ssn.Jobs["ns1/pg1"].Tasks {
+		if err := stmt.Allocate(task, ssn.Nodes["n1"]); err != nil {
+			t.Fatalf("failed to allocate task %s: %v", task.Name, err)
+		}
+	}
+	stmt.Discard()
+}
@@ -0,0 +1,274 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/plugins/util"
+)
+
+// ManifestSuffix is the file name suffix of remote plugin manifests in plugins dir,
+// the plugin name is the file name without the suffix.
+const ManifestSuffix = ".grpc.yaml"
+
+// defaultTimeout is the timeout of each call to remote plugin if not set in manifest
+const defaultTimeout = time.Second
+
+// Manifest describes how to reach a remote plugin.
+type Manifest struct {
+	// Address is the gRPC target of plugin, e.g. unix:///var/run/volcano/plugin.sock or 127.0.0.1:9090
+	Address string `json:"address"`
+	// Timeout is the timeout of each call to plugin
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+	// Ignorable indicates whether the failure of plugin can be ignored, the hooks of an ignorable
+	// plugin abstain instead of rejecting when the plugin is unreachable or returns error
+	Ignorable bool `json:"ignorable,omitempty"`
+}
+
+// LoadPlugins registers a plugin builder for each remote plugin manifest found in pluginsDir,
+// the connection to a remote plugin is shared by all the sessions.
+func LoadPlugins(pluginsDir string) error {
+	manifestPaths, _ := filepath.Glob(filepath.Join(pluginsDir, "*"+ManifestSuffix))
+	for _, manifestPath := range manifestPaths {
+		data, err := os.ReadFile(manifestPath)
+		if err != nil {
+			return fmt.Errorf("failed to read remote plugin manifest %s: %v", manifestPath, err)
+		}
+		manifest := &Manifest{}
+		if err := yaml.Unmarshal(data, manifest); err != nil {
+			return fmt.Errorf("failed to decode remote plugin manifest %s: %v", manifestPath, err)
+		}
+
+		pluginName := strings.TrimSuffix(filepath.Base(manifestPath), ManifestSuffix)
+		builder, err := NewBuilder(pluginName, manifest)
+		if err != nil {
+			return err
+		}
+		framework.RegisterPluginBuilder(pluginName, builder)
+		klog.V(4).Infof("Remote plugin %s at %s loaded", pluginName, manifest.Address)
+	}
+
+	return nil
+}
+
+// NewBuilder returns the builder of remote plugin described by manifest.
+func NewBuilder(name string, manifest *Manifest) (framework.PluginBuilder, error) {
+	if manifest.Address == "" {
+		return nil, fmt.Errorf("address of remote plugin %s is not set", name)
+	}
+	// the connection is established lazily, so the plugin can start after vc-scheduler
+	conn, err := grpc.NewClient(manifest.Address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create connection to remote plugin %s at %s: %v", name, manifest.Address, err)
+	}
+
+	timeout := manifest.Timeout.Duration
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	return func(arguments framework.Arguments) framework.Plugin {
+		return &remotePlugin{
+			name:      name,
+			client:    &pluginClient{conn: conn},
+			timeout:   timeout,
+			ignorable: manifest.Ignorable,
+			arguments: arguments,
+		}
+	}, nil
+}
+
+type remotePlugin struct {
+	name      string
+	client    *pluginClient
+	timeout   time.Duration
+	ignorable bool
+	arguments framework.Arguments
+	// sessionUID is the uid of session the plugin is opened in
+	sessionUID string
+	// jobRanks is the order of jobs returned by plugin when the session is opened: job uid -> rank
+	jobRanks map[api.JobID]int
+}
+
+func (rp *remotePlugin) Name() string {
+	return rp.name
+}
+
+func (rp *remotePlugin) call(method string, in, out interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), rp.timeout)
+	defer cancel()
+
+	if err := rp.client.invoke(ctx, method, in, out); err != nil {
+		klog.Warningf("Remote plugin %s failed to call %s: %v", rp.name, method, err)
+		return err
+	}
+	return nil
+}
+
+func (rp *remotePlugin) OnSessionOpen(ssn *framework.Session) {
+	rp.sessionUID = string(ssn.UID)
+
+	// the session is opened and closed in the plugin even in a dry run, as the plugin returns its hooks and keeps the
+	// state of session between the calls; the plugin is told it's a dry run instead
+	resp := &OnSessionOpenResponse{}
+	req := &OnSessionOpenRequest{SessionUID: rp.sessionUID, Arguments: rp.arguments, DryRun: ssn.DryRun()}
+	if err := rp.call("OnSessionOpen", req, resp); err != nil {
+		if rp.ignorable {
+			klog.Warningf("Remote plugin %s is ignored in session %s as it failed to open: %v", rp.name, rp.sessionUID, err)
+			return
+		}
+		// the hooks implemented by plugin are unknown, reject all the tasks and jobs in the session,
+		// the same as what hooks of a not ignorable plugin do when the plugin fails.
+		klog.Errorf("Remote plugin %s failed to open session %s, all the tasks and jobs are rejected: %v", rp.name, rp.sessionUID, err)
+		ssn.AddPredicateFn(rp.name, func(task *api.TaskInfo, node *api.NodeInfo) error {
+			return api.NewFitErrWithStatus(task, node, &api.Status{Code: api.Error, Reason: err.Error(), Plugin: rp.name})
+		})
+		ssn.AddJobEnqueueableFn(rp.name, func(obj interface{}) int {
+			return util.Reject
+		})
+		ssn.AddPreemptableFn(rp.name, func(preemptor *api.TaskInfo, preemptees []*api.TaskInfo) ([]*api.TaskInfo, int) {
+			return nil, util.Reject
+		})
+		return
+	}
+
+	for _, hook := range resp.Hooks {
+		switch hook {
+		case HookPredicate:
+			ssn.AddPredicateFn(rp.name, rp.predicate)
+		case HookNodeOrder:
+			ssn.AddBatchNodeOrderFn(rp.name, rp.nodeOrder)
+		case HookJobOrder:
+			rp.orderJobs(ssn)
+			ssn.AddJobOrderFn(rp.name, rp.jobOrder)
+		case HookPreemptable:
+			ssn.AddPreemptableFn(rp.name, rp.preemptable)
+		case HookJobEnqueueable:
+			ssn.AddJobEnqueueableFn(rp.name, rp.jobEnqueueable)
+		default:
+			klog.Warningf("Unknown hook %s of remote plugin %s is ignored", hook, rp.name)
+		}
+	}
+}
+
+func (rp *remotePlugin) OnSessionClose(ssn *framework.Session) {
+	rp.call("OnSessionClose", &OnSessionCloseRequest{SessionUID: rp.sessionUID}, &OnSessionCloseResponse{})
+}
+
+func (rp *remotePlugin) predicate(task *api.TaskInfo, node *api.NodeInfo) error {
+	resp := &PredicateResponse{}
+	if err := rp.call("Predicate", &PredicateRequest{SessionUID: rp.sessionUID, Task: task, Node: node}, resp); err != nil {
+		if rp.ignorable {
+			return nil
+		}
+		return api.NewFitErrWithStatus(task, node, &api.Status{Code: api.Error, Reason: err.Error(), Plugin: rp.name})
+	}
+
+	if resp.Code == api.Success {
+		return nil
+	}
+	return api.NewFitErrWithStatus(task, node, &api.Status{Code: resp.Code, Reason: resp.Reason, Plugin: rp.name})
+}
+
+func (rp *remotePlugin) nodeOrder(task *api.TaskInfo, nodes []*api.NodeInfo) (map[string]float64, error) {
+	resp := &NodeOrderResponse{}
+	if err := rp.call("NodeOrder", &NodeOrderRequest{SessionUID: rp.sessionUID, Task: task, Nodes: nodes}, resp); err != nil {
+		if rp.ignorable {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return resp.Scores, nil
+}
+
+// orderJobs gets the order of all the jobs in session from plugin, so that comparing two jobs
+// doesn't call the plugin.
+func (rp *remotePlugin) orderJobs(ssn *framework.Session) {
+	req := &JobOrderRequest{SessionUID: rp.sessionUID}
+	for _, job := range ssn.Jobs {
+		req.Jobs = append(req.Jobs, job)
+	}
+	resp := &JobOrderResponse{}
+	if err := rp.call("JobOrder", req, resp); err != nil {
+		return
+	}
+
+	rp.jobRanks = make(map[api.JobID]int, len(resp.Jobs))
+	for rank, uid := range resp.Jobs {
+		rp.jobRanks[uid] = rank
+	}
+}
+
+func (rp *remotePlugin) jobOrder(l, r interface{}) int {
+	lRank, lFound := rp.jobRanks[l.(*api.JobInfo).UID]
+	rRank, rFound := rp.jobRanks[r.(*api.JobInfo).UID]
+	if !lFound || !rFound || lRank == rRank {
+		return 0
+	}
+	if lRank < rRank {
+		return -1
+	}
+	return 1
+}
+
+func (rp *remotePlugin) preemptable(preemptor *api.TaskInfo, preemptees []*api.TaskInfo) ([]*api.TaskInfo, int) {
+	resp := &PreemptableResponse{}
+	req := &PreemptableRequest{SessionUID: rp.sessionUID, Preemptor: preemptor, Preemptees: preemptees}
+	if err := rp.call("Preemptable", req, resp); err != nil {
+		if rp.ignorable {
+			return nil, util.Abstain
+		}
+		return nil, util.Reject
+	}
+
+	victims := make(map[api.TaskID]bool, len(resp.Victims))
+	for _, uid := range resp.Victims {
+		victims[uid] = true
+	}
+	var result []*api.TaskInfo
+	for _, preemptee := range preemptees {
+		if victims[preemptee.UID] {
+			result = append(result, preemptee)
+		}
+	}
+	return result, resp.Status
+}
+
+func (rp *remotePlugin) jobEnqueueable(obj interface{}) int {
+	resp := &JobEnqueueableResponse{}
+	if err := rp.call("JobEnqueueable", &JobEnqueueableRequest{SessionUID: rp.sessionUID, Job: obj.(*api.JobInfo)}, resp); err != nil {
+		if rp.ignorable {
+			return util.Abstain
+		}
+		return util.Reject
+	}
+	return resp.Status
+}
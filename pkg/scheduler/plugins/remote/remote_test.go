@@ -0,0 +1,258 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	v1 "k8s.io/api/core/v1"
+
+	schedulingv1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/plugins/util"
+	schedulingutil "volcano.sh/volcano/pkg/scheduler/util"
+)
+
+// fakePluginServer prefers node n1, rejects node n2, orders jobs by name, permits evicting
+// preemptees named with prefix "low" and rejects enqueueing jobs in namespace c2.
+type fakePluginServer struct {
+	arguments     map[string]interface{}
+	closed        []string
+	jobOrderCalls int
+}
+
+func (s *fakePluginServer) OnSessionOpen(ctx context.Context, req *OnSessionOpenRequest) (*OnSessionOpenResponse, error) {
+	s.arguments = req.Arguments
+	return &OnSessionOpenResponse{Hooks: []string{HookPredicate, HookNodeOrder, HookJobOrder, HookPreemptable, HookJobEnqueueable}}, nil
+}
+
+func (s *fakePluginServer) OnSessionClose(ctx context.Context, req *OnSessionCloseRequest) (*OnSessionCloseResponse, error) {
+	s.closed = append(s.closed, req.SessionUID)
+	return &OnSessionCloseResponse{}, nil
+}
+
+func (s *fakePluginServer) Predicate(ctx context.Context, req *PredicateRequest) (*PredicateResponse, error) {
+	if req.Node.Name == "n2" {
+		return &PredicateResponse{Code: api.UnschedulableAndUnresolvable, Reason: "n2 is reserved"}, nil
+	}
+	return &PredicateResponse{Code: api.Success}, nil
+}
+
+func (s *fakePluginServer) NodeOrder(ctx context.Context, req *NodeOrderRequest) (*NodeOrderResponse, error) {
+	scores := map[string]float64{}
+	for _, node := range req.Nodes {
+		if node.Name == "n1" {
+			scores[node.Name] = 100
+		}
+	}
+	return &NodeOrderResponse{Scores: scores}, nil
+}
+
+func (s *fakePluginServer) JobOrder(ctx context.Context, req *JobOrderRequest) (*JobOrderResponse, error) {
+	s.jobOrderCalls++
+	sort.Slice(req.Jobs, func(i, j int) bool { return req.Jobs[i].Name < req.Jobs[j].Name })
+	resp := &JobOrderResponse{}
+	for _, job := range req.Jobs {
+		resp.Jobs = append(resp.Jobs, job.UID)
+	}
+	return resp, nil
+}
+
+func (s *fakePluginServer) Preemptable(ctx context.Context, req *PreemptableRequest) (*PreemptableResponse, error) {
+	resp := &PreemptableResponse{Status: util.Permit}
+	for _, preemptee := range req.Preemptees {
+		if strings.HasPrefix(preemptee.Name, "low") {
+			resp.Victims = append(resp.Victims, preemptee.UID)
+		}
+	}
+	return resp, nil
+}
+
+func (s *fakePluginServer) JobEnqueueable(ctx context.Context, req *JobEnqueueableRequest) (*JobEnqueueableResponse, error) {
+	if req.Job.Namespace == "c2" {
+		return &JobEnqueueableResponse{Status: util.Reject}, nil
+	}
+	return &JobEnqueueableResponse{Status: util.Permit}, nil
+}
+
+func startPluginServer(t *testing.T, dir string, srv PluginServer) string {
+	socket := filepath.Join(dir, "plugin.sock")
+	lis, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatalf("failed to listen on %s: %v", socket, err)
+	}
+	server := grpc.NewServer()
+	RegisterPluginServer(server, srv)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+	return "unix://" + socket
+}
+
+func writeManifest(t *testing.T, dir, name, content string) {
+	if err := os.WriteFile(filepath.Join(dir, name+ManifestSuffix), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+}
+
+func openSession(pluginName string) *framework.Session {
+	schedulerCache := cache.NewDefaultMockSchedulerCache("volcano")
+	for _, name := range []string{"n1", "n2", "n3"} {
+		schedulerCache.AddOrUpdateNode(schedulingutil.BuildNode(name, api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil))
+	}
+	schedulerCache.AddQueueV1beta1(schedulingutil.BuildQueue("q1", 1, nil))
+	schedulerCache.AddPodGroupV1beta1(schedulingutil.BuildPodGroup("pg1", "c1", "q1", 1, nil, schedulingv1.PodGroupPending))
+	schedulerCache.AddPodGroupV1beta1(schedulingutil.BuildPodGroup("pg2", "c2", "q1", 1, nil, schedulingv1.PodGroupPending))
+	schedulerCache.AddPod(schedulingutil.BuildPod("c1", "high", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil))
+	schedulerCache.AddPod(schedulingutil.BuildPod("c2", "low-1", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg2", nil, nil))
+	schedulerCache.AddPod(schedulingutil.BuildPod("c2", "mid-1", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg2", nil, nil))
+
+	trueValue := true
+	tiers := []conf.Tier{{Plugins: []conf.PluginOption{{
+		Name:               pluginName,
+		EnabledPredicate:   &trueValue,
+		EnabledNodeOrder:   &trueValue,
+		EnabledJobOrder:    &trueValue,
+		EnabledPreemptable: &trueValue,
+		EnabledJobEnqueued: &trueValue,
+		Arguments:          framework.Arguments{"magic.threshold": 10},
+	}}}}
+	return framework.OpenSession(schedulerCache, tiers, nil)
+}
+
+func findJob(ssn *framework.Session, namespace string) *api.JobInfo {
+	for _, job := range ssn.Jobs {
+		if job.Namespace == namespace {
+			return job
+		}
+	}
+	return nil
+}
+
+func TestRemotePlugin(t *testing.T) {
+	defer framework.CleanupPluginBuilders()
+
+	dir := t.TempDir()
+	srv := &fakePluginServer{}
+	address := startPluginServer(t, dir, srv)
+	writeManifest(t, dir, "magic", fmt.Sprintf("address: %s\ntimeout: 5s\n", address))
+	if err := LoadPlugins(dir); err != nil {
+		t.Fatalf("failed to load remote plugins: %v", err)
+	}
+
+	ssn := openSession("magic")
+	assert.Equal(t, map[string]interface{}{"magic.threshold": float64(10)}, srv.arguments)
+
+	job1, job2 := findJob(ssn, "c1"), findJob(ssn, "c2")
+	var task *api.TaskInfo
+	for _, t := range job1.Tasks {
+		task = t
+	}
+
+	assert.NoError(t, ssn.PredicateFn(task, ssn.Nodes["n1"]))
+	assert.Error(t, ssn.PredicateFn(task, ssn.Nodes["n2"]))
+
+	scores, err := ssn.BatchNodeOrderFn(task, []*api.NodeInfo{ssn.Nodes["n1"], ssn.Nodes["n3"]})
+	assert.NoError(t, err)
+	assert.Equal(t, float64(100), scores["n1"])
+	assert.Equal(t, float64(0), scores["n3"])
+
+	assert.True(t, ssn.JobOrderFn(job1, job2))
+	assert.False(t, ssn.JobOrderFn(job2, job1))
+	assert.Equal(t, 1, srv.jobOrderCalls, "jobs should be ordered by plugin once in a session")
+
+	var preemptees []*api.TaskInfo
+	for _, t := range job2.Tasks {
+		preemptees = append(preemptees, t)
+	}
+	sort.Slice(preemptees, func(i, j int) bool { return preemptees[i].Name < preemptees[j].Name })
+	victims := ssn.Preemptable(task, preemptees)
+	if assert.Len(t, victims, 1) {
+		assert.Equal(t, "low-1", victims[0].Name)
+	}
+
+	assert.True(t, ssn.JobEnqueueable(job1))
+	assert.False(t, ssn.JobEnqueueable(job2))
+
+	uid := string(ssn.UID)
+	framework.CloseSession(ssn)
+	assert.Equal(t, []string{uid}, srv.closed)
+}
+
+func TestUnreachableRemotePlugin(t *testing.T) {
+	defer framework.CleanupPluginBuilders()
+
+	dir := t.TempDir()
+	address := "unix://" + filepath.Join(dir, "missing.sock")
+	writeManifest(t, dir, "strict", fmt.Sprintf("address: %s\ntimeout: 100ms\n", address))
+	writeManifest(t, dir, "lenient", fmt.Sprintf("address: %s\ntimeout: 100ms\nignorable: true\n", address))
+	if err := LoadPlugins(dir); err != nil {
+		t.Fatalf("failed to load remote plugins: %v", err)
+	}
+
+	for _, name := range []string{"strict", "lenient"} {
+		builder, found := framework.GetPluginBuilder(name)
+		if !found {
+			t.Fatalf("remote plugin %s is not registered", name)
+		}
+		plugin := builder(nil).(*remotePlugin)
+
+		ssn := openSession(name)
+		var task *api.TaskInfo
+		for _, t := range findJob(ssn, "c1").Tasks {
+			task = t
+		}
+		err := plugin.predicate(task, ssn.Nodes["n1"])
+		vote := plugin.jobEnqueueable(findJob(ssn, "c1"))
+		// the plugin failed to open the session too
+		ssnErr := ssn.PredicateFn(task, ssn.Nodes["n1"])
+		enqueueable := ssn.JobEnqueueable(findJob(ssn, "c1"))
+		if plugin.ignorable {
+			assert.NoError(t, err, name)
+			assert.Equal(t, util.Abstain, vote, name)
+			assert.NoError(t, ssnErr, name)
+			assert.True(t, enqueueable, name)
+		} else {
+			assert.Error(t, err, name)
+			assert.Equal(t, util.Reject, vote, name)
+			assert.Error(t, ssnErr, name)
+			assert.False(t, enqueueable, name)
+		}
+		framework.CloseSession(ssn)
+	}
+}
+
+func TestLoadPluginsWithInvalidManifest(t *testing.T) {
+	defer framework.CleanupPluginBuilders()
+
+	dir := t.TempDir()
+	writeManifest(t, dir, "invalid", "timeout: 1s\n")
+	if err := LoadPlugins(dir); err == nil {
+		t.Errorf("expect error when loading manifest without address")
+	}
+}
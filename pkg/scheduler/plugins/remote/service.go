@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"volcano.sh/volcano/pkg/scheduler/plugins/util/jsoncodec"
+)
+
+// ServiceName is the full name of gRPC service implemented by remote plugins.
+//
+// The messages are encoded as json with the content-subtype "json", i.e. the content-type
+// is "application/grpc+json", so that a remote plugin can be written in any language
+// without sharing generated code with vc-scheduler.
+const ServiceName = "volcano.scheduler.plugin.v1.Plugin"
+
+// PluginServer is the server API of remote plugins, it can be used to implement a remote plugin in Go.
+type PluginServer interface {
+	OnSessionOpen(context.Context, *OnSessionOpenRequest) (*OnSessionOpenResponse, error)
+	OnSessionClose(context.Context, *OnSessionCloseRequest) (*OnSessionCloseResponse, error)
+	Predicate(context.Context, *PredicateRequest) (*PredicateResponse, error)
+	NodeOrder(context.Context, *NodeOrderRequest) (*NodeOrderResponse, error)
+	JobOrder(context.Context, *JobOrderRequest) (*JobOrderResponse, error)
+	Preemptable(context.Context, *PreemptableRequest) (*PreemptableResponse, error)
+	JobEnqueueable(context.Context, *JobEnqueueableRequest) (*JobEnqueueableResponse, error)
+}
+
+// RegisterPluginServer registers the remote plugin implementation to gRPC server.
+func RegisterPluginServer(s *grpc.Server, srv PluginServer) {
+	s.RegisterService(&serviceDesc, srv)
+}
+
+// unaryHandler builds the gRPC method handler calling fn of PluginServer with request of type Req.
+func unaryHandler[Req any, Resp any](method string, fn func(PluginServer, context.Context, *Req) (*Resp, error)) grpc.MethodDesc {
+	return grpc.MethodDesc{
+		MethodName: method,
+		Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+			in := new(Req)
+			if err := dec(in); err != nil {
+				return nil, err
+			}
+			if interceptor == nil {
+				return fn(srv.(PluginServer), ctx, in)
+			}
+			info := &grpc.UnaryServerInfo{
+				Server:     srv,
+				FullMethod: "/" + ServiceName + "/" + method,
+			}
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				return fn(srv.(PluginServer), ctx, req.(*Req))
+			}
+			return interceptor(ctx, in, info, handler)
+		},
+	}
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*PluginServer)(nil),
+	Methods: []grpc.MethodDesc{
+		unaryHandler("OnSessionOpen", PluginServer.OnSessionOpen),
+		unaryHandler("OnSessionClose", PluginServer.OnSessionClose),
+		unaryHandler("Predicate", PluginServer.Predicate),
+		unaryHandler("NodeOrder", PluginServer.NodeOrder),
+		unaryHandler("JobOrder", PluginServer.JobOrder),
+		unaryHandler("Preemptable", PluginServer.Preemptable),
+		unaryHandler("JobEnqueueable", PluginServer.JobEnqueueable),
+	},
+}
+
+// pluginClient is the client API of remote plugins.
+type pluginClient struct {
+	conn grpc.ClientConnInterface
+}
+
+func (c *pluginClient) invoke(ctx context.Context, method string, in, out interface{}) error {
+	return c.conn.Invoke(ctx, "/"+ServiceName+"/"+method, in, out, grpc.CallContentSubtype(jsoncodec.Name))
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// Hooks which a remote plugin can implement, a remote plugin tells the hooks it implements
+// in OnSessionOpenResponse, and only those hooks are called in the session.
+const (
+	HookPredicate      = "predicate"
+	HookNodeOrder      = "nodeOrder"
+	HookJobOrder       = "jobOrder"
+	HookPreemptable    = "preemptable"
+	HookJobEnqueueable = "jobEnqueueable"
+)
+
+type OnSessionOpenRequest struct {
+	SessionUID string `json:"sessionUID"`
+	// Arguments are the arguments of the plugin in scheduler configuration
+	Arguments map[string]interface{} `json:"arguments,omitempty"`
+	// DryRun means the session changes nothing, e.g. explaining a job, the plugin should not change its state
+	// out of the session either
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+type OnSessionOpenResponse struct {
+	// Hooks are the hooks implemented by the plugin
+	Hooks []string `json:"hooks"`
+}
+
+type OnSessionCloseRequest struct {
+	SessionUID string `json:"sessionUID"`
+}
+
+type OnSessionCloseResponse struct{}
+
+type PredicateRequest struct {
+	SessionUID string        `json:"sessionUID"`
+	Task       *api.TaskInfo `json:"task"`
+	Node       *api.NodeInfo `json:"node"`
+}
+
+type PredicateResponse struct {
+	// Code is the status code of predicate, e.g. api.Unschedulable, the node is accepted if it is api.Success
+	Code   int    `json:"code"`
+	Reason string `json:"reason,omitempty"`
+}
+
+type NodeOrderRequest struct {
+	SessionUID string          `json:"sessionUID"`
+	Task       *api.TaskInfo   `json:"task"`
+	Nodes      []*api.NodeInfo `json:"nodes"`
+}
+
+type NodeOrderResponse struct {
+	// Scores are the scores of nodes: node name -> score
+	Scores map[string]float64 `json:"scores"`
+}
+
+// JobOrderRequest is sent once when the session is opened, with all the jobs in session.
+type JobOrderRequest struct {
+	SessionUID string         `json:"sessionUID"`
+	Jobs       []*api.JobInfo `json:"jobs"`
+}
+
+type JobOrderResponse struct {
+	// Jobs are the uid of jobs in the order to be scheduled, the jobs not returned are not ordered by the plugin
+	Jobs []api.JobID `json:"jobs"`
+}
+
+type PreemptableRequest struct {
+	SessionUID string          `json:"sessionUID"`
+	Preemptor  *api.TaskInfo   `json:"preemptor"`
+	Preemptees []*api.TaskInfo `json:"preemptees"`
+}
+
+type PreemptableResponse struct {
+	// Status is the vote of plugin: 1 means permit, 0 means abstain and -1 means reject
+	Status int `json:"status"`
+	// Victims are the uid of preemptees which can be evicted
+	Victims []api.TaskID `json:"victims,omitempty"`
+}
+
+type JobEnqueueableRequest struct {
+	SessionUID string       `json:"sessionUID"`
+	Job        *api.JobInfo `json:"job"`
+}
+
+type JobEnqueueableResponse struct {
+	// Status is the vote of plugin: 1 means permit, 0 means abstain and -1 means reject
+	Status int `json:"status"`
+}
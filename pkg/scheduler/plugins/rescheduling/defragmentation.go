@@ -0,0 +1,204 @@
+/*
+Copyright 2026 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rescheduling
+
+import (
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// Defragmentation is the strategy evicting the tasks of small jobs from the nodes of a resource, e.g. GPU, so that
+// the pending tasks of the starving gang jobs which fit no node now are able to run on the freed nodes.
+const Defragmentation = "defragmentation"
+
+// DefragmentationConf is the configuration of the defragmentation strategy
+type DefragmentationConf struct {
+	// ResourceName is the resource to defragment, nvidia.com/gpu by default
+	ResourceName string `mapstructure:"resourceName"`
+	// MaxNodesToFree is the maximum number of nodes freed in one run, 1 by default
+	MaxNodesToFree int `mapstructure:"maxNodesToFree"`
+	// SmallJobMaxMinAvailable is the maximum minAvailable of the small jobs whose tasks are evicted, 1 by default
+	SmallJobMaxMinAvailable int32 `mapstructure:"smallJobMaxMinAvailable"`
+}
+
+// NewDefragmentationConf returns the pointer of DefragmentationConf object with default value
+func NewDefragmentationConf() *DefragmentationConf {
+	return &DefragmentationConf{
+		ResourceName:            "nvidia.com/gpu",
+		MaxNodesToFree:          1,
+		SmallJobMaxMinAvailable: 1,
+	}
+}
+
+// freeableNode is a node whose tasks using the resource all belong to small jobs.
+type freeableNode struct {
+	node  *api.NodeInfo
+	tasks []*api.TaskInfo
+	// used is the amount of the resource used by the tasks
+	used float64
+}
+
+var victimsFnForDefragmentation = func(tasks []*api.TaskInfo) []*api.TaskInfo {
+	config := NewDefragmentationConf()
+	parseStrategyParams(Defragmentation, config)
+	resourceName := v1.ResourceName(config.ResourceName)
+
+	unplaced := unplacedGangTasks(resourceName)
+	if len(unplaced) == 0 {
+		klog.V(4).Infof("No pending tasks of gang jobs need nodes freed of %s", resourceName)
+		return nil
+	}
+
+	candidates := freeableNodes(tasks, resourceName, config.SmallJobMaxMinAvailable)
+	victims := make([]*api.TaskInfo, 0)
+	freed := 0
+	for _, candidate := range candidates {
+		if freed >= config.MaxNodesToFree || len(unplaced) == 0 {
+			break
+		}
+
+		idle := candidate.node.FutureIdle()
+		for _, task := range candidate.tasks {
+			idle.Add(task.Resreq)
+		}
+		remaining := make([]*api.TaskInfo, 0, len(unplaced))
+		for _, task := range unplaced {
+			if task.InitResreq.LessEqual(idle, api.Zero) {
+				idle.Sub(task.InitResreq)
+			} else {
+				remaining = append(remaining, task)
+			}
+		}
+		if len(remaining) == len(unplaced) {
+			continue
+		}
+		if !budgets.allow(candidate.tasks...) {
+			klog.V(4).Infof("Node %s is not freed for the disruption budgets of its tasks", candidate.node.Name)
+			continue
+		}
+
+		klog.V(3).Infof("Free node %s of %s by evicting %d tasks for %d pending tasks of gang jobs",
+			candidate.node.Name, resourceName, len(candidate.tasks), len(unplaced)-len(remaining))
+		victims = append(victims, candidate.tasks...)
+		unplaced = remaining
+		freed++
+	}
+	klog.V(3).Infof("Victims of strategy %s: %d", Defragmentation, len(victims))
+	return victims
+}
+
+// unplacedGangTasks returns the pending tasks requesting the resource which the starving gang jobs need to be ready
+// and fit no node with the future idle resources now.
+func unplacedGangTasks(resourceName v1.ResourceName) []*api.TaskInfo {
+	idle := make(map[string]*api.Resource, len(Session.Nodes))
+	for name, node := range Session.Nodes {
+		if node.Ready() {
+			idle[name] = node.FutureIdle()
+		}
+	}
+	nodeNames := make([]string, 0, len(idle))
+	for name := range idle {
+		nodeNames = append(nodeNames, name)
+	}
+	sort.Strings(nodeNames)
+
+	jobs := make([]*api.JobInfo, 0)
+	for _, job := range Session.Jobs {
+		if job.MinAvailable > 1 && !job.IsPending() && job.IsStarving() {
+			jobs = append(jobs, job)
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return Session.JobOrderFn(jobs[i], jobs[j])
+	})
+
+	unplaced := make([]*api.TaskInfo, 0)
+	for _, job := range jobs {
+		pending := make([]*api.TaskInfo, 0, len(job.TaskStatusIndex[api.Pending]))
+		for _, task := range job.TaskStatusIndex[api.Pending] {
+			if task.InitResreq.Get(resourceName) > 0 {
+				pending = append(pending, task)
+			}
+		}
+		sort.Slice(pending, func(i, j int) bool {
+			return Session.TaskOrderFn(pending[i], pending[j])
+		})
+		if needed := int(job.MinAvailable - job.ReadyTaskNum() - job.WaitingTaskNum()); len(pending) > needed {
+			pending = pending[:needed]
+		}
+
+		for _, task := range pending {
+			placed := false
+			for _, name := range nodeNames {
+				if task.InitResreq.LessEqual(idle[name], api.Zero) {
+					idle[name].Sub(task.InitResreq)
+					placed = true
+					break
+				}
+			}
+			if !placed {
+				unplaced = append(unplaced, task)
+			}
+		}
+	}
+	return unplaced
+}
+
+// freeableNodes returns the nodes with the resource whose tasks using the resource are all running tasks of small jobs,
+// the nodes with the least resource used first.
+func freeableNodes(tasks []*api.TaskInfo, resourceName v1.ResourceName, smallJobMaxMinAvailable int32) []*freeableNode {
+	running := make(map[api.TaskID]bool, len(tasks))
+	for _, task := range tasks {
+		running[task.UID] = true
+	}
+
+	nodes := make([]*freeableNode, 0)
+	for _, node := range Session.Nodes {
+		if !node.Ready() || node.Allocatable.Get(resourceName) == 0 {
+			continue
+		}
+		candidate := &freeableNode{node: node}
+		freeable := true
+		for _, task := range node.Tasks {
+			if task.Resreq.Get(resourceName) == 0 || !api.AllocatedStatus(task.Status) {
+				continue
+			}
+			job, found := Session.Jobs[task.Job]
+			if !found || job.MinAvailable > smallJobMaxMinAvailable || !running[task.UID] {
+				freeable = false
+				break
+			}
+			candidate.tasks = append(candidate.tasks, task)
+			candidate.used += task.Resreq.Get(resourceName)
+		}
+		if freeable && len(candidate.tasks) > 0 {
+			sortTasks(candidate.tasks)
+			nodes = append(nodes, candidate)
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].used != nodes[j].used {
+			return nodes[i].used < nodes[j].used
+		}
+		return nodes[i].node.Name < nodes[j].node.Name
+	})
+	return nodes
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2026 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rescheduling
+
+import (
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/features"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+)
+
+// defaultJobEvictNum is the number of tasks allowed to be evicted from a job without disruption budget in one run.
+const defaultJobEvictNum = 1
+
+// disruptionBudgets tracks the evictions allowed by the PodDisruptionBudgets and the disruption budgets of the jobs,
+// which are shared by all the strategies in one run, so that the victims of all strategies together respect them.
+type disruptionBudgets struct {
+	jobs        map[api.JobID]*api.JobInfo
+	jobsAllowed map[api.JobID]int
+
+	pdbs        []*policyv1.PodDisruptionBudget
+	pdbsAllowed []int32
+
+	// approved is the tasks already allowed to be evicted, which are not counted again.
+	approved map[api.TaskID]bool
+}
+
+// budgets is the disruption budgets of the current session
+var budgets *disruptionBudgets
+
+func newDisruptionBudgets(jobs map[api.JobID]*api.JobInfo, pdbs []*policyv1.PodDisruptionBudget) *disruptionBudgets {
+	db := &disruptionBudgets{
+		jobs:        jobs,
+		jobsAllowed: make(map[api.JobID]int),
+		pdbs:        pdbs,
+		pdbsAllowed: make([]int32, len(pdbs)),
+		approved:    make(map[api.TaskID]bool),
+	}
+	for i, pdb := range pdbs {
+		db.pdbsAllowed[i] = pdb.Status.DisruptionsAllowed
+	}
+	return db
+}
+
+// newSessionDisruptionBudgets returns the disruption budgets of the jobs and the PodDisruptionBudgets of the session.
+func newSessionDisruptionBudgets(ssn *framework.Session) *disruptionBudgets {
+	var pdbs []*policyv1.PodDisruptionBudget
+	if factory := ssn.InformerFactory(); factory != nil && utilfeature.DefaultFeatureGate.Enabled(features.PodDisruptionBudgetsSupport) {
+		var err error
+		if pdbs, err = factory.Policy().V1().PodDisruptionBudgets().Lister().List(labels.Everything()); err != nil {
+			klog.Errorf("Failed to list pdbs for rescheduling: %v", err)
+		}
+	}
+	return newDisruptionBudgets(ssn.Jobs, pdbs)
+}
+
+// allow returns whether the tasks are allowed to be evicted all together, and takes them from the budgets if so.
+func (db *disruptionBudgets) allow(tasks ...*api.TaskInfo) bool {
+	if db == nil {
+		return true
+	}
+
+	jobsTaken := make(map[api.JobID]int)
+	pdbsTaken := make([]int32, len(db.pdbs))
+	for _, task := range tasks {
+		if db.approved[task.UID] {
+			continue
+		}
+		jobsTaken[task.Job]++
+		if db.jobAllowed(task.Job) < jobsTaken[task.Job] {
+			klog.V(4).Infof("The eviction of task <%s/%s> violates the disruption budget of job <%s>", task.Namespace, task.Name, task.Job)
+			return false
+		}
+		for _, i := range db.matchedPDBs(task) {
+			pdbsTaken[i]++
+			if db.pdbsAllowed[i] < pdbsTaken[i] {
+				klog.V(4).Infof("The eviction of task <%s/%s> violates the pdb <%s/%s>", task.Namespace, task.Name, db.pdbs[i].Namespace, db.pdbs[i].Name)
+				return false
+			}
+		}
+	}
+
+	for job, taken := range jobsTaken {
+		db.jobsAllowed[job] -= taken
+	}
+	for i, taken := range pdbsTaken {
+		db.pdbsAllowed[i] -= taken
+	}
+	for _, task := range tasks {
+		db.approved[task.UID] = true
+	}
+	return true
+}
+
+// filter returns the tasks allowed to be evicted one by one in order.
+func (db *disruptionBudgets) filter(tasks []*api.TaskInfo) []*api.TaskInfo {
+	victims := make([]*api.TaskInfo, 0, len(tasks))
+	for _, task := range tasks {
+		if db.allow(task) {
+			victims = append(victims, task)
+		}
+	}
+	return victims
+}
+
+// jobAllowed returns the number of tasks allowed to be evicted from the job.
+func (db *disruptionBudgets) jobAllowed(id api.JobID) int {
+	if allowed, found := db.jobsAllowed[id]; found {
+		return allowed
+	}
+
+	allowed := 0
+	if job, found := db.jobs[id]; found {
+		allowed = maxEvictNum(job)
+	}
+	db.jobsAllowed[id] = allowed
+	return allowed
+}
+
+// matchedPDBs returns the indexes of the PodDisruptionBudgets covering the pod of the task.
+func (db *disruptionBudgets) matchedPDBs(task *api.TaskInfo) []int {
+	pod := task.Pod
+	if pod == nil || len(pod.Labels) == 0 {
+		return nil
+	}
+
+	var matched []int
+	for i, pdb := range db.pdbs {
+		if pdb.Namespace != pod.Namespace {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		// A PDB with a nil or empty selector matches nothing.
+		if err != nil || selector.Empty() || !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		// The pods in DisruptedPods have been processed by the API server already.
+		if _, exist := pdb.Status.DisruptedPods[pod.Name]; exist {
+			continue
+		}
+		matched = append(matched, i)
+	}
+	return matched
+}
+
+// maxEvictNum returns the number of tasks allowed to be evicted from the job according to its disruption budget.
+func maxEvictNum(job *api.JobInfo) int {
+	runningTaskNum := len(job.TaskStatusIndex[api.Running])
+	if job.Budget.MaxUnavailable != "" {
+		maxUnavailable := parseIntStr(job.Budget.MaxUnavailable, len(job.Tasks))
+		finalTaskNum := len(job.TaskStatusIndex[api.Succeeded]) + len(job.TaskStatusIndex[api.Failed])
+		unavailable := len(job.Tasks) - finalTaskNum - runningTaskNum
+		if unavailable >= maxUnavailable {
+			return 0
+		}
+		return maxUnavailable - unavailable
+	}
+
+	if job.Budget.MinAvailable != "" {
+		minAvailable := parseIntStr(job.Budget.MinAvailable, len(job.Tasks))
+		if runningTaskNum >= minAvailable {
+			return runningTaskNum - minAvailable
+		}
+		return 0
+	}
+
+	return defaultJobEvictNum
+}
+
+func parseIntStr(input string, taskNum int) int {
+	value := intstr.Parse(input)
+	if value.Type == intstr.Int {
+		return value.IntValue()
+	}
+	result, err := intstr.GetScaledValueFromIntOrPercent(&value, taskNum, true)
+	if err != nil {
+		klog.Warningf("Failed to parse disruption budget %s: %v", input, err)
+		return 0
+	}
+	return result
+}
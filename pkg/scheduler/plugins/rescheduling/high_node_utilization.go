@@ -0,0 +1,182 @@
+/*
+Copyright 2026 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rescheduling
+
+import (
+	"sort"
+
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// HighNodeUtilization is the strategy draining the underutilized nodes, whose tasks all fit the other nodes,
+// so that the tasks are consolidated and the drained nodes are able to be scaled down.
+const HighNodeUtilization = "highNodeUtilization"
+
+// HighNodeUtilizationConf is the configuration of the highNodeUtilization strategy
+type HighNodeUtilizationConf struct {
+	// Thresholds are the utilization in percentage below which in all resources the nodes are underutilized
+	Thresholds map[string]float64
+	// NumberOfNodes is the number of underutilized nodes the strategy only works above
+	NumberOfNodes int
+}
+
+// NewHighNodeUtilizationConf returns the pointer of HighNodeUtilizationConf object with default value
+func NewHighNodeUtilizationConf() *HighNodeUtilizationConf {
+	return &HighNodeUtilizationConf{
+		Thresholds: map[string]float64{"cpu": 20, "memory": 20, "pods": 20},
+	}
+}
+
+// parse converts the config map to struct object
+func (hnuc *HighNodeUtilizationConf) parse(configs map[string]interface{}) {
+	if thresholds, ok := parseThresholds(configs["thresholds"]); ok {
+		for rName, threshold := range thresholds {
+			hnuc.Thresholds[rName] = threshold
+		}
+	}
+	if numberOfNodes, ok := configs["numberOfNodes"]; ok {
+		hnuc.NumberOfNodes = int(toFloat64(numberOfNodes))
+	}
+}
+
+var victimsFnForHnu = func(tasks []*api.TaskInfo) []*api.TaskInfo {
+	config := NewHighNodeUtilizationConf()
+	if params, ok := RegisteredStrategyConfigs[HighNodeUtilization].(map[string]interface{}); ok {
+		config.parse(params)
+	}
+
+	underutilized := make([]*NodeUtilization, 0)
+	targets := make(map[string]*api.Resource)
+	for _, usage := range getNodeUtilization() {
+		node, found := Session.Nodes[usage.nodeInfo.Name]
+		if !found || !node.Ready() || usage.nodeInfo.Spec.Unschedulable {
+			continue
+		}
+		if isUnderutilized(usage, config.Thresholds) {
+			underutilized = append(underutilized, usage)
+		} else {
+			targets[node.Name] = node.FutureIdle()
+		}
+	}
+	if len(underutilized) <= config.NumberOfNodes || len(targets) == 0 {
+		klog.V(4).Infof("%d underutilized nodes and %d target nodes, no nodes to drain", len(underutilized), len(targets))
+		return nil
+	}
+	sort.Slice(underutilized, func(i, j int) bool {
+		si, sj := getScoreForNode(i, underutilized), getScoreForNode(j, underutilized)
+		if si != sj {
+			return si < sj
+		}
+		return underutilized[i].nodeInfo.Name < underutilized[j].nodeInfo.Name
+	})
+	targetNames := make([]string, 0, len(targets))
+	for name := range targets {
+		targetNames = append(targetNames, name)
+	}
+	sort.Strings(targetNames)
+
+	running := make(map[api.TaskID]bool, len(tasks))
+	for _, task := range tasks {
+		running[task.UID] = true
+	}
+
+	victims := make([]*api.TaskInfo, 0)
+	for _, usage := range underutilized {
+		node := Session.Nodes[usage.nodeInfo.Name]
+		drained, placed := drainNode(node, running, targetNames, targets)
+		if drained == nil {
+			klog.V(4).Infof("Node %s could not be drained as not all its tasks fit the other nodes", node.Name)
+			continue
+		}
+		if !budgets.allow(drained...) {
+			klog.V(4).Infof("Node %s is not drained for the disruption budgets of its tasks", node.Name)
+			continue
+		}
+		for name, idle := range placed {
+			targets[name] = idle
+		}
+		klog.V(3).Infof("Drain node %s by evicting %d tasks", node.Name, len(drained))
+		victims = append(victims, drained...)
+	}
+	klog.V(3).Infof("Victims of strategy %s: %d", HighNodeUtilization, len(victims))
+	return victims
+}
+
+// isUnderutilized checks whether the utilization of all the resources with thresholds is below the thresholds
+func isUnderutilized(usage *NodeUtilization, thresholds map[string]float64) bool {
+	for rName, usagePercent := range usage.utilization {
+		if threshold, ok := thresholds[string(rName)]; ok && usagePercent >= threshold {
+			return false
+		}
+	}
+	return true
+}
+
+// drainNode places the tasks on the node to the target nodes first-fit, and returns the tasks to evict and
+// the idle resources of the target nodes after the placement, nil if any task is not evictable or fits no target.
+func drainNode(node *api.NodeInfo, running map[api.TaskID]bool, targetNames []string, targets map[string]*api.Resource) ([]*api.TaskInfo, map[string]*api.Resource) {
+	tasks := make([]*api.TaskInfo, 0, len(node.Tasks))
+	for _, task := range node.Tasks {
+		if isDaemonSetPod(task) {
+			continue
+		}
+		if !running[task.UID] {
+			return nil, nil
+		}
+		tasks = append(tasks, task)
+	}
+	if len(tasks) == 0 {
+		return nil, nil
+	}
+	sortTasks(tasks)
+
+	placed := make(map[string]*api.Resource)
+	for _, task := range tasks {
+		fit := false
+		for _, name := range targetNames {
+			idle, found := placed[name]
+			if !found {
+				idle = targets[name]
+			}
+			if !matchesNode(task, Session.Nodes[name]) || !task.InitResreq.LessEqual(idle, api.Zero) {
+				continue
+			}
+			placed[name] = idle.Clone().Sub(task.InitResreq)
+			fit = true
+			break
+		}
+		if !fit {
+			return nil, nil
+		}
+	}
+	return tasks, placed
+}
+
+// isDaemonSetPod checks whether the pod of the task is owned by a DaemonSet, which is not evicted to drain a node
+func isDaemonSetPod(task *api.TaskInfo) bool {
+	if task.Pod == nil {
+		return false
+	}
+	for _, owner := range task.Pod.OwnerReferences {
+		if owner.Kind == "DaemonSet" {
+			return true
+		}
+	}
+	return false
+}
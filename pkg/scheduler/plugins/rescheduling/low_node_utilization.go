@@ -17,8 +17,6 @@ limitations under the License.
 package rescheduling
 
 import (
-	"reflect"
-
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/klog/v2"
@@ -58,51 +56,67 @@ func (lnuc *LowNodeUtilizationConf) parse(configs map[string]interface{}) {
 	if len(configs) == 0 {
 		return
 	}
-	lowThresholdsConfigs, ok := configs["thresholds"]
-	if ok {
-		lowConfigs, ok := lowThresholdsConfigs.(map[interface{}]interface{})
-		if !ok {
-			klog.Warningln("Assert lowThresholdsConfigs to map error, abort the configuration parse.")
-			return
-		}
-		config := make(map[string]int)
-		for k, v := range lowConfigs {
-			config[k.(string)] = v.(int)
-		}
-		parseThreshold(config, lnuc, "Thresholds")
-	}
-	targetThresholdsConfigs, ok := configs["targetThresholds"]
-	if ok {
-		targetConfigs, ok := targetThresholdsConfigs.(map[interface{}]interface{})
-		if !ok {
-			klog.Warningln("Assert targetThresholdsConfigs to map error, abort the configuration parse.")
-			return
+	if thresholds, ok := parseThresholds(configs["thresholds"]); ok {
+		for rName, threshold := range thresholds {
+			lnuc.Thresholds[rName] = threshold
 		}
-		config := make(map[string]int)
-		for k, v := range targetConfigs {
-			config[k.(string)] = v.(int)
+	}
+	if targetThresholds, ok := parseThresholds(configs["targetThresholds"]); ok {
+		for rName, threshold := range targetThresholds {
+			lnuc.TargetThresholds[rName] = threshold
 		}
-		parseThreshold(config, lnuc, "TargetThresholds")
 	}
 }
 
-func parseThreshold(thresholdsConfig map[string]int, lnuc *LowNodeUtilizationConf, param string) {
-	if len(thresholdsConfig) > 0 {
-		configValue := reflect.ValueOf(lnuc).Elem().FieldByName(param)
-		config := configValue.Interface().(map[string]float64)
-
-		cpuThreshold, ok := thresholdsConfig["cpu"]
-		if ok {
-			config["cpu"] = float64(cpuThreshold)
+// parseThresholds converts the thresholds of the resources in percentage, e.g. cpu, memory, pods and
+// scalar resources like nvidia.com/gpu, to a map. The "pod" key is taken as "pods" for compatibility.
+func parseThresholds(config interface{}) (map[string]float64, bool) {
+	if config == nil {
+		return nil, false
+	}
+
+	thresholds := make(map[string]float64)
+	switch configs := config.(type) {
+	case map[interface{}]interface{}:
+		for k, v := range configs {
+			rName, ok := k.(string)
+			if !ok {
+				klog.Warningf("Invalid resource name %v of thresholds, abort the configuration parse.", k)
+				return nil, false
+			}
+			thresholds[rName] = toFloat64(v)
 		}
-		memoryThreshold, ok := thresholdsConfig["memory"]
-		if ok {
-			config["memory"] = float64(memoryThreshold)
+	case map[string]interface{}:
+		for rName, v := range configs {
+			thresholds[rName] = toFloat64(v)
 		}
-		podThreshold, ok := thresholdsConfig["pod"]
-		if ok {
-			config["pod"] = float64(podThreshold)
+	case map[string]float64:
+		for rName, v := range configs {
+			thresholds[rName] = v
 		}
+	default:
+		klog.Warningf("Assert thresholds %v to map error, abort the configuration parse.", config)
+		return nil, false
+	}
+
+	if threshold, ok := thresholds["pod"]; ok {
+		delete(thresholds, "pod")
+		thresholds[string(v1.ResourcePods)] = threshold
+	}
+	return thresholds, true
+}
+
+func toFloat64(value interface{}) float64 {
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case float64:
+		return v
+	default:
+		klog.Warningf("Invalid threshold %v, use 0 instead", value)
+		return 0
 	}
 }
 
@@ -199,7 +213,7 @@ func isContinueEvictPods(usage *NodeUtilization, totalAllocatableResource map[v1
 	}
 
 	for _, amount := range totalAllocatableResource {
-		if amount.CmpInt64(0) == 0 {
+		if amount.Sign() <= 0 {
 			return false
 		}
 	}
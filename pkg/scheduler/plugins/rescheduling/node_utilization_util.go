@@ -18,6 +18,7 @@ package rescheduling
 
 import (
 	"sort"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -54,34 +55,50 @@ func groupNodesByUtilization(nodeUtilizationList []*NodeUtilization, lowThreshol
 	return lowNodes, highNodes
 }
 
-// getNodeUtilization returns all node resource utilization list
+// getNodeUtilization returns all node resource utilization list. The utilization of cpu and memory comes from the
+// metrics in metrics mode unless the metrics of the node are stale, and from the requests of the tasks otherwise;
+// the utilization of pods and scalar resources always comes from the requests of the tasks.
 func getNodeUtilization() []*NodeUtilization {
 	nodeUtilizationList := make([]*NodeUtilization, 0)
+	now := time.Now()
 	for _, nodeInfo := range Session.Nodes {
 		nodeUtilization := &NodeUtilization{
-			nodeInfo: nodeInfo.Node,
-			utilization: map[v1.ResourceName]float64{
-				v1.ResourceCPU:    nodeInfo.ResourceUsage.CPUUsageAvg[MetricsPeriod],
-				v1.ResourceMemory: nodeInfo.ResourceUsage.MEMUsageAvg[MetricsPeriod],
-			},
-			pods: nodeInfo.Pods(),
+			nodeInfo:    nodeInfo.Node,
+			utilization: getRequestUtilization(nodeInfo),
+			pods:        nodeInfo.Pods(),
+		}
+		if UtilizationMode == MetricsUtilizationMode {
+			if usage := nodeInfo.ResourceUsage; usage != nil && !usage.MetricsTime.IsZero() && now.Sub(usage.MetricsTime) <= MetricsActiveTime {
+				nodeUtilization.utilization[v1.ResourceCPU] = usage.CPUUsageAvg[MetricsPeriod]
+				nodeUtilization.utilization[v1.ResourceMemory] = usage.MEMUsageAvg[MetricsPeriod]
+			} else {
+				klog.V(4).Infof("The metrics of node %s are stale, use the utilization by requests", nodeInfo.Name)
+			}
 		}
 		nodeUtilizationList = append(nodeUtilizationList, nodeUtilization)
-		klog.V(4).Infof("node: %s, cpu: %v, memory: %v\n", nodeUtilization.nodeInfo.Name, nodeUtilization.utilization[v1.ResourceCPU], nodeUtilization.utilization[v1.ResourceMemory])
+		klog.V(4).Infof("node: %s, utilization: %v\n", nodeUtilization.nodeInfo.Name, nodeUtilization.utilization)
 	}
 	return nodeUtilizationList
 }
 
+// getRequestUtilization returns the utilization of the resources the node has by the requests of the tasks on the node
+func getRequestUtilization(nodeInfo *api.NodeInfo) map[v1.ResourceName]float64 {
+	utilization := make(map[v1.ResourceName]float64)
+	for _, rName := range nodeInfo.Allocatable.ResourceNames() {
+		if allocatable := nodeInfo.Allocatable.Get(rName); allocatable > 0 {
+			utilization[rName] = nodeInfo.Used.Get(rName) * 100 / allocatable
+		}
+	}
+	return utilization
+}
+
 // evictPodsFromSourceNodes evict pods from source nodes to target nodes according to priority and QoS
 func evictPodsFromSourceNodes(sourceNodes, targetNodes []*NodeUtilization, tasks []*api.TaskInfo, evictionCon isContinueEviction, config interface{}) []*api.TaskInfo {
-	resourceNames := []v1.ResourceName{
-		v1.ResourceCPU,
-		v1.ResourceMemory,
-	}
 	utilizationConfig := parseArgToConfig(config)
-	totalAllocatableResource := map[v1.ResourceName]*resource.Quantity{
-		v1.ResourceCPU:    {},
-		v1.ResourceMemory: {},
+	resourceNames := thresholdResourceNames(utilizationConfig.TargetThresholds)
+	totalAllocatableResource := make(map[v1.ResourceName]*resource.Quantity, len(resourceNames))
+	for _, rName := range resourceNames {
+		totalAllocatableResource[rName] = &resource.Quantity{}
 	}
 	for _, node := range targetNodes {
 		nodeCapacity := getNodeCapacity(node.nodeInfo)
@@ -111,6 +128,22 @@ func evictPodsFromSourceNodes(sourceNodes, targetNodes []*NodeUtilization, tasks
 	return victims
 }
 
+// thresholdResourceNames returns cpu, memory and the other resources with thresholds in name order
+func thresholdResourceNames(thresholds map[string]float64) []v1.ResourceName {
+	resourceNames := []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory}
+	others := make([]string, 0, len(thresholds))
+	for rName := range thresholds {
+		if rName != string(v1.ResourceCPU) && rName != string(v1.ResourceMemory) {
+			others = append(others, rName)
+		}
+	}
+	sort.Strings(others)
+	for _, rName := range others {
+		resourceNames = append(resourceNames, v1.ResourceName(rName))
+	}
+	return resourceNames
+}
+
 // parseArgToConfig returns a nodeUtilizationConfig object from parameters
 // TODO: It is just for lowNodeUtilization now, which should be abstracted as a common function.
 func parseArgToConfig(config interface{}) *LowNodeUtilizationConf {
@@ -169,12 +202,13 @@ func evict(pods []*v1.Pod, utilization *NodeUtilization, totalAllocatableResourc
 		}
 		for _, task := range tasks {
 			if task.Pod.Name == pod.Name {
-				usedCPU := *resource.NewMilliQuantity(int64(task.Resreq.MilliCPU), resource.DecimalSI)
-				usedMem := *resource.NewQuantity(int64(task.Resreq.Memory), resource.BinarySI)
-				totalAllocatableResource[v1.ResourceCPU].Sub(usedCPU)
-				totalAllocatableResource[v1.ResourceMemory].Sub(usedMem)
-				utilization.utilization[v1.ResourceCPU] -= convertQuanToPercent(v1.ResourceCPU, &usedCPU, utilization.nodeInfo.Status.Capacity)
-				utilization.utilization[v1.ResourceMemory] -= convertQuanToPercent(v1.ResourceMemory, &usedMem, utilization.nodeInfo.Status.Capacity)
+				for rName, total := range totalAllocatableResource {
+					used := taskRequest(task, rName)
+					total.Sub(*used)
+					if _, found := utilization.utilization[rName]; found {
+						utilization.utilization[rName] -= convertQuanToPercent(rName, used, utilization.nodeInfo.Status.Capacity)
+					}
+				}
 				klog.V(4).Infof("totalAllocatableResource: %v\n", totalAllocatableResource)
 				klog.V(4).Infof("node: %s, utilization: %v\n", utilization.nodeInfo.Name, utilization.utilization)
 				victims = append(victims, task)
@@ -194,6 +228,20 @@ func getNodeCapacity(node *v1.Node) v1.ResourceList {
 	return nodeCapacity
 }
 
+// taskRequest returns the amount of the resource requested by the task
+func taskRequest(task *api.TaskInfo, rName v1.ResourceName) *resource.Quantity {
+	switch rName {
+	case v1.ResourceCPU:
+		return resource.NewMilliQuantity(int64(task.Resreq.MilliCPU), resource.DecimalSI)
+	case v1.ResourceMemory:
+		return resource.NewQuantity(int64(task.Resreq.Memory), resource.BinarySI)
+	case v1.ResourcePods:
+		return resource.NewQuantity(int64(task.Resreq.Get(rName)), resource.DecimalSI)
+	default:
+		return resource.NewMilliQuantity(int64(task.Resreq.Get(rName)), resource.DecimalSI)
+	}
+}
+
 // convertPercentToQuan converts resource percentage to amount
 func convertPercentToQuan(rName v1.ResourceName, percent float64, nodeCapacity v1.ResourceList) *resource.Quantity {
 	var amount *resource.Quantity
@@ -201,6 +249,9 @@ func convertPercentToQuan(rName v1.ResourceName, percent float64, nodeCapacity v
 		amount = resource.NewMilliQuantity(int64(percent*float64(nodeCapacity.Cpu().MilliValue())*0.01), resource.DecimalSI)
 	} else if rName == v1.ResourceMemory {
 		amount = resource.NewQuantity(int64(percent*float64(nodeCapacity.Memory().Value())*0.01), resource.BinarySI)
+	} else {
+		capacity := nodeCapacity[rName]
+		amount = resource.NewMilliQuantity(int64(percent*float64(capacity.MilliValue())*0.01), resource.DecimalSI)
 	}
 	return amount
 }
@@ -212,6 +263,8 @@ func convertQuanToPercent(rName v1.ResourceName, amount *resource.Quantity, node
 		percent = amount.AsApproximateFloat64() * 100 / nodeCapacity.Cpu().AsApproximateFloat64()
 	} else if rName == v1.ResourceMemory {
 		percent = amount.AsApproximateFloat64() * 100 / nodeCapacity.Memory().AsApproximateFloat64()
+	} else if capacity, found := nodeCapacity[rName]; found && !capacity.IsZero() {
+		percent = amount.AsApproximateFloat64() * 100 / capacity.AsApproximateFloat64()
 	}
 	return percent
 }
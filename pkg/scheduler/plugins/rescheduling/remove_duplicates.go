@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rescheduling
+
+import (
+	"sort"
+
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// RemoveDuplicates is the strategy evicting the duplicates of the same task of a job on one node, so that the tasks
+// spread over the nodes they fit: the tasks on a node beyond the average number over those nodes are evicted.
+const RemoveDuplicates = "removeDuplicates"
+
+// duplicateKey identifies the tasks of the same job and task role.
+type duplicateKey struct {
+	job  api.JobID
+	role string
+}
+
+var victimsFnForRemoveDuplicates = func(tasks []*api.TaskInfo) []*api.TaskInfo {
+	groups := make(map[duplicateKey]map[string][]*api.TaskInfo)
+	for _, task := range tasks {
+		key := duplicateKey{job: task.Job, role: task.TaskRole}
+		if _, found := groups[key]; !found {
+			groups[key] = make(map[string][]*api.TaskInfo)
+		}
+		groups[key][task.NodeName] = append(groups[key][task.NodeName], task)
+	}
+
+	candidates := make([]*api.TaskInfo, 0)
+	for key, nodes := range groups {
+		total := 0
+		var sample *api.TaskInfo
+		for _, onNode := range nodes {
+			total += len(onNode)
+			sample = onNode[0]
+		}
+		if total == len(nodes) {
+			continue
+		}
+
+		// the nodes the task fits, including the ones it is running on
+		feasible := 0
+		for name, node := range Session.Nodes {
+			if _, found := nodes[name]; found || fitsNode(sample, node) {
+				feasible++
+			}
+		}
+		if feasible <= 1 {
+			continue
+		}
+		upper := (total + feasible - 1) / feasible
+
+		names := make([]string, 0, len(nodes))
+		for name := range nodes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			onNode := nodes[name]
+			if len(onNode) <= upper {
+				continue
+			}
+			sortTasks(onNode)
+			klog.V(4).Infof("%d tasks of job <%s> role <%s> on node %s, the upper average is %d", len(onNode), key.job, key.role, name, upper)
+			candidates = append(candidates, onNode[:len(onNode)-upper]...)
+		}
+	}
+
+	sortTasks(candidates)
+	victims := budgets.filter(candidates)
+	klog.V(3).Infof("Victims of strategy %s: %d", RemoveDuplicates, len(victims))
+	return victims
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2026 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rescheduling
+
+import (
+	"k8s.io/component-helpers/scheduling/corev1/nodeaffinity"
+	"k8s.io/klog/v2"
+	k8sframework "k8s.io/kubernetes/pkg/scheduler/framework"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+const (
+	// RemovePodsViolatingNodeAffinity is the strategy evicting the pods whose required node affinity or
+	// node selector is not matched by their nodes any more, e.g. after the labels of the nodes change.
+	RemovePodsViolatingNodeAffinity = "removePodsViolatingNodeAffinity"
+	// RemovePodsViolatingInterPodAntiAffinity is the strategy evicting the pods whose required inter-pod
+	// anti-affinity is violated by the pods in the same topology domain.
+	RemovePodsViolatingInterPodAntiAffinity = "removePodsViolatingInterPodAntiAffinity"
+)
+
+// NodeFitConf is the configuration of the strategies which only evict the pods able to be placed on other nodes
+type NodeFitConf struct {
+	NodeFit bool `mapstructure:"nodeFit"`
+}
+
+var victimsFnForNodeAffinity = func(tasks []*api.TaskInfo) []*api.TaskInfo {
+	config := NodeFitConf{NodeFit: true}
+	parseStrategyParams(RemovePodsViolatingNodeAffinity, &config)
+
+	candidates := make([]*api.TaskInfo, 0)
+	for _, task := range tasks {
+		node, found := Session.Nodes[task.NodeName]
+		if !found || node.Node == nil {
+			continue
+		}
+		if match, _ := nodeaffinity.GetRequiredNodeAffinity(task.Pod).Match(node.Node); match {
+			continue
+		}
+		if config.NodeFit && !fitsOtherNode(task) {
+			klog.V(4).Infof("Task <%s/%s> violates the node affinity of node %s but fits no other node", task.Namespace, task.Name, task.NodeName)
+			continue
+		}
+		candidates = append(candidates, task)
+	}
+
+	sortTasks(candidates)
+	victims := budgets.filter(candidates)
+	klog.V(3).Infof("Victims of strategy %s: %d", RemovePodsViolatingNodeAffinity, len(victims))
+	return victims
+}
+
+var victimsFnForInterPodAntiAffinity = func(tasks []*api.TaskInfo) []*api.TaskInfo {
+	config := NodeFitConf{NodeFit: true}
+	parseStrategyParams(RemovePodsViolatingInterPodAntiAffinity, &config)
+
+	// the pods running on each node, from which the victims are removed once selected
+	podsOnNode := make(map[string]map[string]*api.TaskInfo)
+	for _, task := range tasks {
+		if _, found := podsOnNode[task.NodeName]; !found {
+			podsOnNode[task.NodeName] = make(map[string]*api.TaskInfo)
+		}
+		podsOnNode[task.NodeName][string(task.UID)] = task
+	}
+
+	candidates := make([]*api.TaskInfo, 0, len(tasks))
+	candidates = append(candidates, tasks...)
+	sortTasks(candidates)
+
+	victims := make([]*api.TaskInfo, 0)
+	for _, task := range candidates {
+		affinity := task.Pod.Spec.Affinity
+		if affinity == nil || affinity.PodAntiAffinity == nil || len(affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution) == 0 {
+			continue
+		}
+		node, found := Session.Nodes[task.NodeName]
+		if !found || node.Node == nil {
+			continue
+		}
+		podInfo, err := k8sframework.NewPodInfo(task.Pod)
+		if err != nil {
+			klog.V(4).Infof("Failed to parse the anti-affinity of task <%s/%s>: %v", task.Namespace, task.Name, err)
+			continue
+		}
+		if !violatesAntiAffinity(task, node, podInfo.RequiredAntiAffinityTerms, podsOnNode) {
+			continue
+		}
+		if config.NodeFit && !fitsOtherNode(task) {
+			klog.V(4).Infof("Task <%s/%s> violates its anti-affinity on node %s but fits no other node", task.Namespace, task.Name, task.NodeName)
+			continue
+		}
+		if !budgets.allow(task) {
+			continue
+		}
+		delete(podsOnNode[task.NodeName], string(task.UID))
+		victims = append(victims, task)
+	}
+	klog.V(3).Infof("Victims of strategy %s: %d", RemovePodsViolatingInterPodAntiAffinity, len(victims))
+	return victims
+}
+
+// violatesAntiAffinity checks whether any other pod in the same topology domain as the node matches the anti-affinity terms.
+func violatesAntiAffinity(task *api.TaskInfo, node *api.NodeInfo, terms []k8sframework.AffinityTerm, podsOnNode map[string]map[string]*api.TaskInfo) bool {
+	for _, term := range terms {
+		domain, found := node.Node.Labels[term.TopologyKey]
+		if !found {
+			continue
+		}
+		for name, pods := range podsOnNode {
+			other, found := Session.Nodes[name]
+			if !found || other.Node == nil || other.Node.Labels[term.TopologyKey] != domain {
+				continue
+			}
+			for _, pod := range pods {
+				if pod.UID != task.UID && term.Matches(pod.Pod, nil) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -36,8 +36,24 @@ const (
 	DefaultMetricsPeriod = "5m"
 	// DefaultStrategy indicates the default strategy rescheduling plugin making use of
 	DefaultStrategy = "lowNodeUtilization"
+	// DefaultMetricsActiveTime indicates the default time the metrics of a node are taken as active
+	DefaultMetricsActiveTime = 5 * time.Minute
+
+	// MetricsUtilizationMode takes the cpu and memory utilization from the metrics, and from the requests if the metrics are stale
+	MetricsUtilizationMode = "metrics"
+	// RequestUtilizationMode takes the utilization from the requests of the tasks on the nodes
+	RequestUtilizationMode = "request"
 )
 
+// ArgumentsSchema is the schema of the arguments of rescheduling plugin
+var ArgumentsSchema = framework.ArgumentsSchema{
+	"interval":          framework.DurationArgument,
+	"metricsPeriod":     framework.StringArgument,
+	"utilizationMode":   framework.StringArgument,
+	"metricsActiveTime": framework.DurationArgument,
+	"strategies":        framework.ListArgument,
+}
+
 var (
 	// Session contains all the data in session object which will be used for all the rescheduling package
 	Session *framework.Session
@@ -50,15 +66,29 @@ var (
 
 	// MetricsPeriod indicates the metrics period will be used during this plugin. 5 minutes by default.
 	MetricsPeriod string
+
+	// UtilizationMode indicates where the utilization of the nodes comes from, metrics or request. metrics by default.
+	UtilizationMode string
+
+	// MetricsActiveTime indicates the metrics of a node updated longer ago are stale. 5 minutes by default.
+	MetricsActiveTime time.Duration
 )
 
 func init() {
 	RegisteredStrategyConfigs = make(map[string]interface{})
 	VictimFn = make(map[string]api.VictimTasksFn)
 	MetricsPeriod = "5m"
+	UtilizationMode = MetricsUtilizationMode
+	MetricsActiveTime = DefaultMetricsActiveTime
 
 	// register victim functions for all strategies here
 	VictimFn["lowNodeUtilization"] = victimsFnForLnu
+	VictimFn[RemovePodsViolatingNodeAffinity] = victimsFnForNodeAffinity
+	VictimFn[RemovePodsViolatingInterPodAntiAffinity] = victimsFnForInterPodAntiAffinity
+	VictimFn[RemoveDuplicates] = victimsFnForRemoveDuplicates
+	VictimFn[RemovePodsViolatingTopologySpreadConstraint] = victimsFnForTopologySpread
+	VictimFn[Defragmentation] = victimsFnForDefragmentation
+	VictimFn[HighNodeUtilization] = victimsFnForHnu
 }
 
 type reschedulingPlugin struct {
@@ -99,6 +129,9 @@ func (rp *reschedulingPlugin) OnSessionOpen(ssn *framework.Session) {
 		return
 	}
 
+	// The victims of the strategies except lowNodeUtilization respect the disruption budgets all together.
+	budgets = newSessionDisruptionBudgets(ssn)
+
 	// Get all strategies and register the victim functions for each strategy.
 	victimFns := make([]api.VictimTasksFn, 0)
 	for _, strategy := range configs.strategies {
@@ -112,6 +145,7 @@ func (rp *reschedulingPlugin) OnSessionOpen(ssn *framework.Session) {
 
 func (rp *reschedulingPlugin) OnSessionClose(ssn *framework.Session) {
 	Session = nil
+	budgets = nil
 	for k := range RegisteredStrategyConfigs {
 		delete(RegisteredStrategyConfigs, k)
 	}
@@ -162,6 +196,23 @@ func (rc *Configs) parseArguments(arguments framework.Arguments) {
 	if MetricsPeriod == "" {
 		MetricsPeriod = DefaultMetricsPeriod
 	}
+	UtilizationMode = MetricsUtilizationMode
+	if modeArg, ok := arguments["utilizationMode"].(string); ok {
+		switch modeArg {
+		case MetricsUtilizationMode, RequestUtilizationMode:
+			UtilizationMode = modeArg
+		default:
+			klog.Warningf("Invalid utilization mode %s, use %s by default.", modeArg, MetricsUtilizationMode)
+		}
+	}
+	MetricsActiveTime = DefaultMetricsActiveTime
+	if activeTimeArg, ok := arguments["metricsActiveTime"].(string); ok {
+		if activeTime, err := time.ParseDuration(activeTimeArg); err != nil || activeTime <= 0 {
+			klog.Warningf("Invalid metrics active time %s, use %v by default.", activeTimeArg, DefaultMetricsActiveTime)
+		} else {
+			MetricsActiveTime = activeTime
+		}
+	}
 	strategies, ok := arguments["strategies"]
 	if ok {
 		strategyArray, _ := strategies.([]interface{})
@@ -0,0 +1,334 @@
+/*
+Copyright 2026 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rescheduling
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/uthelper"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func buildNode(name string, labels map[string]string) *v1.Node {
+	return util.BuildNode(name, api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}, {Name: "nvidia.com/gpu", Value: "4"}}...), labels)
+}
+
+func buildGPUPod(name, nodeName string, phase v1.PodPhase, gpu, groupName string) *v1.Pod {
+	return util.BuildPod("c1", name, nodeName, phase, api.BuildResourceList("100m", "100Mi", []api.ScalarResource{{Name: "nvidia.com/gpu", Value: gpu}}...), groupName, nil, nil)
+}
+
+func TestStrategies(t *testing.T) {
+	affinityPod := util.BuildPod("c1", "p1", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg1", nil, map[string]string{"zone": "b"})
+	unfitAffinityPod := util.BuildPod("c1", "p2", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg2", nil, map[string]string{"zone": "c"})
+	matchedAffinityPod := util.BuildPod("c1", "p3", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg3", nil, map[string]string{"zone": "a"})
+
+	antiAffinityPod := util.BuildPod("c1", "p1", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil)
+	antiAffinityPod.Spec.Affinity = &v1.Affinity{PodAntiAffinity: &v1.PodAntiAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: []v1.PodAffinityTerm{{
+			LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			TopologyKey:   "zone",
+		}},
+	}}
+
+	spreadPod := func(name string) *v1.Pod {
+		pod := util.BuildPod("c1", name, "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg-"+name, map[string]string{"app": "db"}, nil)
+		pod.Spec.TopologySpreadConstraints = []v1.TopologySpreadConstraint{{
+			MaxSkew:           1,
+			TopologyKey:       "zone",
+			WhenUnsatisfiable: v1.DoNotSchedule,
+			LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+		}}
+		return pod
+	}
+
+	tests := []struct {
+		name      string
+		strategy  string
+		nodes     []*v1.Node
+		podGroups []*schedulingv1beta1.PodGroup
+		pods      []*v1.Pod
+		expected  []string
+	}{
+		{
+			name:     "evict the pods violating node affinity which fit other nodes",
+			strategy: RemovePodsViolatingNodeAffinity,
+			nodes:    []*v1.Node{buildNode("n1", map[string]string{"zone": "a"}), buildNode("n2", map[string]string{"zone": "b"})},
+			podGroups: []*schedulingv1beta1.PodGroup{
+				util.BuildPodGroup("pg1", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning),
+				util.BuildPodGroup("pg2", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning),
+				util.BuildPodGroup("pg3", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning),
+			},
+			pods:     []*v1.Pod{affinityPod, unfitAffinityPod, matchedAffinityPod},
+			expected: []string{"p1"},
+		},
+		{
+			name:     "evict the pods violating inter-pod anti-affinity",
+			strategy: RemovePodsViolatingInterPodAntiAffinity,
+			nodes:    []*v1.Node{buildNode("n1", map[string]string{"zone": "a"}), buildNode("n2", map[string]string{"zone": "b"})},
+			podGroups: []*schedulingv1beta1.PodGroup{
+				util.BuildPodGroup("pg1", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning),
+				util.BuildPodGroup("pg2", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning),
+			},
+			pods: []*v1.Pod{
+				antiAffinityPod,
+				util.BuildPod("c1", "p2", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg2", map[string]string{"app": "web"}, nil),
+			},
+			expected: []string{"p1"},
+		},
+		{
+			name:     "evict the duplicates beyond the upper average",
+			strategy: RemoveDuplicates,
+			nodes:    []*v1.Node{buildNode("n1", nil), buildNode("n2", nil)},
+			podGroups: []*schedulingv1beta1.PodGroup{
+				util.BuildPodGroupWithAnno("pg1", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning, map[string]string{schedulingv1beta1.JDBMaxUnavailable: "2"}),
+			},
+			pods: []*v1.Pod{
+				util.BuildPod("c1", "p1", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil),
+				util.BuildPod("c1", "p2", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil),
+				util.BuildPod("c1", "p3", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil),
+				util.BuildPod("c1", "p4", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil),
+			},
+			expected: []string{"p1", "p2"},
+		},
+		{
+			name:     "evict the duplicates within the job disruption budget",
+			strategy: RemoveDuplicates,
+			nodes:    []*v1.Node{buildNode("n1", nil), buildNode("n2", nil)},
+			podGroups: []*schedulingv1beta1.PodGroup{
+				util.BuildPodGroupWithAnno("pg1", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning, map[string]string{schedulingv1beta1.JDBMinAvailable: "3"}),
+			},
+			pods: []*v1.Pod{
+				util.BuildPod("c1", "p1", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil),
+				util.BuildPod("c1", "p2", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil),
+				util.BuildPod("c1", "p3", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil),
+				util.BuildPod("c1", "p4", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg1", nil, nil),
+			},
+			expected: []string{"p1"},
+		},
+		{
+			name:     "evict the pods from the domain with the most pods until the skew is satisfied",
+			strategy: RemovePodsViolatingTopologySpreadConstraint,
+			nodes:    []*v1.Node{buildNode("n1", map[string]string{"zone": "a"}), buildNode("n2", map[string]string{"zone": "b"})},
+			podGroups: []*schedulingv1beta1.PodGroup{
+				util.BuildPodGroup("pg-p1", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning),
+				util.BuildPodGroup("pg-p2", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning),
+				util.BuildPodGroup("pg-p3", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning),
+				util.BuildPodGroup("pg-p4", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning),
+			},
+			pods:     []*v1.Pod{spreadPod("p1"), spreadPod("p2"), spreadPod("p3"), spreadPod("p4")},
+			expected: []string{"p1", "p2"},
+		},
+		{
+			name:     "evict the small jobs from the node with the least gpu used for the pending gang",
+			strategy: Defragmentation,
+			nodes:    []*v1.Node{buildNode("n1", nil), buildNode("n2", nil), buildNode("n3", nil)},
+			podGroups: []*schedulingv1beta1.PodGroup{
+				util.BuildPodGroup("pg1", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning),
+				util.BuildPodGroup("pg2", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning),
+				util.BuildPodGroup("pg3", "c1", "q1", 2, nil, schedulingv1beta1.PodGroupRunning),
+				util.BuildPodGroup("pg-gang", "c1", "q1", 2, nil, schedulingv1beta1.PodGroupInqueue),
+			},
+			pods: []*v1.Pod{
+				buildGPUPod("p1", "n1", v1.PodRunning, "2", "pg1"),
+				buildGPUPod("p2", "n2", v1.PodRunning, "1", "pg2"),
+				buildGPUPod("p3-0", "n3", v1.PodRunning, "1", "pg3"),
+				buildGPUPod("p3-1", "n3", v1.PodRunning, "1", "pg3"),
+				buildGPUPod("gang-0", "", v1.PodPending, "4", "pg-gang"),
+				buildGPUPod("gang-1", "", v1.PodPending, "4", "pg-gang"),
+			},
+			expected: []string{"p2"},
+		},
+		{
+			name:     "drain the underutilized node whose tasks fit the other nodes",
+			strategy: HighNodeUtilization,
+			nodes:    []*v1.Node{buildNode("n1", nil), buildNode("n2", nil)},
+			podGroups: []*schedulingv1beta1.PodGroup{
+				util.BuildPodGroup("pg1", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning),
+				util.BuildPodGroup("pg2", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning),
+				util.BuildPodGroup("pg3", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning),
+			},
+			pods: []*v1.Pod{
+				util.BuildPod("c1", "p1", "n1", v1.PodRunning, api.BuildResourceList("500m", "1Gi"), "pg1", nil, nil),
+				util.BuildPod("c1", "p2", "n2", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg2", nil, nil),
+				util.BuildPod("c1", "p3", "n2", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg3", nil, nil),
+			},
+			expected: []string{"p1"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ut := uthelper.TestCommonStruct{
+				Nodes:     test.nodes,
+				Queues:    []*schedulingv1beta1.Queue{util.BuildQueue("q1", 1, nil)},
+				PodGroups: test.podGroups,
+				Pods:      test.pods,
+			}
+			ssn := ut.RegisterSession(nil, nil)
+			defer ut.Close()
+
+			Session = ssn
+			budgets = newDisruptionBudgets(ssn.Jobs, nil)
+			defer func() {
+				Session = nil
+				budgets = nil
+			}()
+
+			tasks := make([]*api.TaskInfo, 0)
+			for _, job := range ssn.Jobs {
+				for _, task := range job.TaskStatusIndex[api.Running] {
+					tasks = append(tasks, task)
+				}
+			}
+			victims := make([]string, 0)
+			for _, victim := range VictimFn[test.strategy](tasks) {
+				victims = append(victims, victim.Name)
+			}
+			sort.Strings(victims)
+			assert.Equal(t, test.expected, victims)
+		})
+	}
+}
+
+func TestNodeUtilization(t *testing.T) {
+	ut := uthelper.TestCommonStruct{
+		Nodes:     []*v1.Node{buildNode("n1", nil), buildNode("n2", nil)},
+		Queues:    []*schedulingv1beta1.Queue{util.BuildQueue("q1", 1, nil)},
+		PodGroups: []*schedulingv1beta1.PodGroup{util.BuildPodGroup("pg1", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning)},
+		Pods:      []*v1.Pod{buildGPUPod("p1", "n1", v1.PodRunning, "2", "pg1")},
+	}
+	ssn := ut.RegisterSession(nil, nil)
+	defer ut.Close()
+	Session = ssn
+	defer func() {
+		Session = nil
+		UtilizationMode = MetricsUtilizationMode
+	}()
+
+	ssn.Nodes["n1"].ResourceUsage = &api.NodeUsage{
+		MetricsTime: time.Now(),
+		CPUUsageAvg: map[string]float64{MetricsPeriod: 60},
+		MEMUsageAvg: map[string]float64{MetricsPeriod: 70},
+	}
+	ssn.Nodes["n2"].ResourceUsage = &api.NodeUsage{
+		MetricsTime: time.Now().Add(-time.Hour),
+		CPUUsageAvg: map[string]float64{MetricsPeriod: 60},
+		MEMUsageAvg: map[string]float64{MetricsPeriod: 70},
+	}
+
+	utilization := func() map[string]map[v1.ResourceName]float64 {
+		result := make(map[string]map[v1.ResourceName]float64)
+		for _, usage := range getNodeUtilization() {
+			result[usage.nodeInfo.Name] = usage.utilization
+		}
+		return result
+	}
+
+	UtilizationMode = MetricsUtilizationMode
+	result := utilization()
+	assert.Equal(t, 60.0, result["n1"][v1.ResourceCPU], "the cpu utilization comes from the active metrics")
+	assert.Equal(t, 70.0, result["n1"][v1.ResourceMemory], "the memory utilization comes from the active metrics")
+	assert.Equal(t, 50.0, result["n1"]["nvidia.com/gpu"], "the gpu utilization comes from the requests")
+	assert.Equal(t, 0.0, result["n2"][v1.ResourceCPU], "the cpu utilization comes from the requests as the metrics are stale")
+
+	UtilizationMode = RequestUtilizationMode
+	result = utilization()
+	assert.Equal(t, 2.5, result["n1"][v1.ResourceCPU], "the cpu utilization comes from the requests")
+	assert.Equal(t, 10.0, result["n1"][v1.ResourcePods], "the pods utilization comes from the requests")
+}
+
+func TestLowNodeUtilizationWithScalarResources(t *testing.T) {
+	ut := uthelper.TestCommonStruct{
+		Nodes:  []*v1.Node{buildNode("n1", nil), buildNode("n2", nil)},
+		Queues: []*schedulingv1beta1.Queue{util.BuildQueue("q1", 1, nil)},
+		PodGroups: []*schedulingv1beta1.PodGroup{
+			util.BuildPodGroup("pg1", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning),
+		},
+		Pods: []*v1.Pod{
+			buildGPUPod("p1", "n1", v1.PodRunning, "1", "pg1"),
+			buildGPUPod("p2", "n1", v1.PodRunning, "1", "pg1"),
+			buildGPUPod("p3", "n1", v1.PodRunning, "1", "pg1"),
+			buildGPUPod("p4", "n1", v1.PodRunning, "1", "pg1"),
+		},
+	}
+	ssn := ut.RegisterSession(nil, nil)
+	defer ut.Close()
+	Session = ssn
+	UtilizationMode = RequestUtilizationMode
+	RegisteredStrategyConfigs[DefaultStrategy] = map[string]interface{}{
+		"thresholds":       map[interface{}]interface{}{"cpu": 20, "memory": 20, "nvidia.com/gpu": 20},
+		"targetThresholds": map[interface{}]interface{}{"cpu": 50, "memory": 50, "nvidia.com/gpu": 50.0},
+	}
+	defer func() {
+		Session = nil
+		UtilizationMode = MetricsUtilizationMode
+		delete(RegisteredStrategyConfigs, DefaultStrategy)
+	}()
+
+	tasks := make([]*api.TaskInfo, 0)
+	for _, task := range ssn.Jobs["c1/pg1"].Tasks {
+		tasks = append(tasks, task)
+	}
+	victims := victimsFnForLnu(tasks)
+	assert.Equal(t, 2, len(victims), "the tasks are evicted until the gpu utilization is under the target threshold")
+}
+
+func TestParseThresholds(t *testing.T) {
+	thresholds, ok := parseThresholds(map[interface{}]interface{}{"cpu": 20, "pod": 30, "nvidia.com/gpu": 12.5})
+	assert.True(t, ok)
+	assert.Equal(t, map[string]float64{"cpu": 20, "pods": 30, "nvidia.com/gpu": 12.5}, thresholds)
+
+	_, ok = parseThresholds("invalid")
+	assert.False(t, ok)
+}
+
+func TestDisruptionBudgets(t *testing.T) {
+	job := api.NewJobInfo("c1/pg1")
+	job.Budget = api.NewDisruptionBudget("", "")
+	tasks := make([]*api.TaskInfo, 0)
+	for _, name := range []string{"p1", "p2", "p3"} {
+		task := api.NewTaskInfo(util.BuildPod("c1", name, "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg1", map[string]string{"app": "web"}, nil))
+		task.Job = job.UID
+		job.AddTaskInfo(task)
+		tasks = append(tasks, task)
+	}
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "c1", Name: "pdb1"},
+		Spec:       policyv1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}},
+		Status:     policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: 1},
+	}
+
+	job.Budget = api.NewDisruptionBudget("", "2")
+	db := newDisruptionBudgets(map[api.JobID]*api.JobInfo{job.UID: job}, []*policyv1.PodDisruptionBudget{pdb})
+	assert.False(t, db.allow(tasks[0], tasks[1]), "two evictions violate the pdb")
+	assert.True(t, db.allow(tasks[0]), "one eviction is allowed by the pdb and the job budget")
+	assert.True(t, db.allow(tasks[0]), "an approved task is not counted again")
+	assert.False(t, db.allow(tasks[1]), "the pdb allows no more eviction")
+
+	job.Budget = api.NewDisruptionBudget("2", "")
+	db = newDisruptionBudgets(map[api.JobID]*api.JobInfo{job.UID: job}, nil)
+	assert.Equal(t, tasks[:1], db.filter(tasks), "one of the three running tasks is allowed to be evicted by min available 2")
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2026 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rescheduling
+
+import (
+	"sort"
+
+	"github.com/mitchellh/mapstructure"
+	v1 "k8s.io/api/core/v1"
+	corev1helpers "k8s.io/component-helpers/scheduling/corev1"
+	"k8s.io/component-helpers/scheduling/corev1/nodeaffinity"
+	"k8s.io/klog/v2"
+	v1qos "k8s.io/kubernetes/pkg/apis/core/v1/helper/qos"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// parseStrategyParams decodes the registered parameters of the strategy into params, the fields of params
+// are kept as they are if the parameters are not given or invalid.
+func parseStrategyParams(strategy string, params interface{}) {
+	config, ok := RegisteredStrategyConfigs[strategy].(map[string]interface{})
+	if !ok || len(config) == 0 {
+		return
+	}
+	if err := mapstructure.WeakDecode(config, params); err != nil {
+		klog.Warningf("Failed to parse the parameters of strategy %s: %v", strategy, err)
+	}
+}
+
+// sortTasks sorts the tasks in eviction order, from low priority to high priority, and from low QoS to high QoS
+// if the priority is the same, the tasks with the same priority and QoS are in name order.
+func sortTasks(tasks []*api.TaskInfo) {
+	qosRank := map[v1.PodQOSClass]int{v1.PodQOSBestEffort: 0, v1.PodQOSBurstable: 1, v1.PodQOSGuaranteed: 2}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if tasks[i].Priority != tasks[j].Priority {
+			return tasks[i].Priority < tasks[j].Priority
+		}
+		if qi, qj := qosRank[v1qos.GetPodQOS(tasks[i].Pod)], qosRank[v1qos.GetPodQOS(tasks[j].Pod)]; qi != qj {
+			return qi < qj
+		}
+		if tasks[i].Namespace != tasks[j].Namespace {
+			return tasks[i].Namespace < tasks[j].Namespace
+		}
+		return tasks[i].Name < tasks[j].Name
+	})
+}
+
+// fitsNode checks whether the task could be placed on the node, regarding the node affinity and
+// the taints of the node and the future idle resources of the node.
+func fitsNode(task *api.TaskInfo, node *api.NodeInfo) bool {
+	return matchesNode(task, node) && task.InitResreq.LessEqual(node.FutureIdle(), api.Zero)
+}
+
+// matchesNode checks whether the node is schedulable and matches the node affinity and tolerations of the task.
+func matchesNode(task *api.TaskInfo, node *api.NodeInfo) bool {
+	if node.Node == nil || !node.Ready() || node.Node.Spec.Unschedulable {
+		return false
+	}
+	if match, _ := nodeaffinity.GetRequiredNodeAffinity(task.Pod).Match(node.Node); !match {
+		return false
+	}
+	_, untolerated := corev1helpers.FindMatchingUntoleratedTaint(node.Node.Spec.Taints, task.Pod.Spec.Tolerations, func(t *v1.Taint) bool {
+		return t.Effect == v1.TaintEffectNoSchedule || t.Effect == v1.TaintEffectNoExecute
+	})
+	return !untolerated
+}
+
+// fitsOtherNode checks whether the task could be placed on any node other than the one it is running on.
+func fitsOtherNode(task *api.TaskInfo) bool {
+	for name, node := range Session.Nodes {
+		if name != task.NodeName && fitsNode(task, node) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2026 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rescheduling
+
+import (
+	"fmt"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+// RemovePodsViolatingTopologySpreadConstraint is the strategy evicting the pods from the topology domains with
+// the most matching pods, until the skew of the topology spread constraints of the pods is within the max skew.
+const RemovePodsViolatingTopologySpreadConstraint = "removePodsViolatingTopologySpreadConstraint"
+
+// TopologySpreadConf is the configuration of the topology spread strategy
+type TopologySpreadConf struct {
+	// IncludeSoftConstraints also rebalances the constraints with ScheduleAnyway
+	IncludeSoftConstraints bool `mapstructure:"includeSoftConstraints"`
+}
+
+// spreadConstraint is a topology spread constraint of the pods in a namespace.
+type spreadConstraint struct {
+	namespace   string
+	topologyKey string
+	maxSkew     int32
+	selector    labels.Selector
+}
+
+var victimsFnForTopologySpread = func(tasks []*api.TaskInfo) []*api.TaskInfo {
+	config := TopologySpreadConf{}
+	parseStrategyParams(RemovePodsViolatingTopologySpreadConstraint, &config)
+
+	constraints := make(map[string]*spreadConstraint)
+	for _, task := range tasks {
+		for _, c := range task.Pod.Spec.TopologySpreadConstraints {
+			if c.WhenUnsatisfiable != v1.DoNotSchedule && !config.IncludeSoftConstraints {
+				continue
+			}
+			selector, err := metav1.LabelSelectorAsSelector(c.LabelSelector)
+			if err != nil {
+				klog.V(4).Infof("Invalid label selector of the topology spread constraint of task <%s/%s>: %v", task.Namespace, task.Name, err)
+				continue
+			}
+			key := fmt.Sprintf("%s/%s/%d/%s", task.Namespace, c.TopologyKey, c.MaxSkew, selector.String())
+			constraints[key] = &spreadConstraint{
+				namespace:   task.Namespace,
+				topologyKey: c.TopologyKey,
+				maxSkew:     c.MaxSkew,
+				selector:    selector,
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(constraints))
+	for key := range constraints {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	evicted := make(map[api.TaskID]bool)
+	victims := make([]*api.TaskInfo, 0)
+	for _, key := range keys {
+		victims = append(victims, balanceDomains(constraints[key], tasks, evicted)...)
+	}
+	klog.V(3).Infof("Victims of strategy %s: %d", RemovePodsViolatingTopologySpreadConstraint, len(victims))
+	return victims
+}
+
+// balanceDomains evicts the matching pods from the domain with the most pods while the skew exceeds the max skew,
+// each eviction is taken as moving the pod to the domain with the least pods.
+func balanceDomains(c *spreadConstraint, tasks []*api.TaskInfo, evicted map[api.TaskID]bool) []*api.TaskInfo {
+	domainOfNode := make(map[string]string)
+	pods := make(map[string][]*api.TaskInfo)
+	for name, node := range Session.Nodes {
+		if node.Node == nil || !node.Ready() {
+			continue
+		}
+		if domain, found := node.Node.Labels[c.topologyKey]; found {
+			domainOfNode[name] = domain
+			pods[domain] = pods[domain]
+		}
+	}
+	if len(pods) <= 1 {
+		return nil
+	}
+
+	counts := make(map[string]int32, len(pods))
+	for _, task := range tasks {
+		domain, found := domainOfNode[task.NodeName]
+		if !found || evicted[task.UID] || task.Namespace != c.namespace || !c.selector.Matches(labels.Set(task.Pod.Labels)) {
+			continue
+		}
+		pods[domain] = append(pods[domain], task)
+		counts[domain]++
+	}
+
+	domains := make([]string, 0, len(pods))
+	for domain := range pods {
+		domains = append(domains, domain)
+		sortTasks(pods[domain])
+	}
+
+	victims := make([]*api.TaskInfo, 0)
+	for {
+		sort.Slice(domains, func(i, j int) bool {
+			if counts[domains[i]] != counts[domains[j]] {
+				return counts[domains[i]] < counts[domains[j]]
+			}
+			return domains[i] < domains[j]
+		})
+		low, high := domains[0], domains[len(domains)-1]
+		if counts[high]-counts[low] <= c.maxSkew {
+			return victims
+		}
+
+		var victim *api.TaskInfo
+		for len(pods[high]) > 0 && victim == nil {
+			task := pods[high][0]
+			pods[high] = pods[high][1:]
+			if budgets.allow(task) {
+				victim = task
+			}
+		}
+		if victim == nil {
+			klog.V(4).Infof("No pods could be evicted from domain %s=%s to rebalance", c.topologyKey, high)
+			return victims
+		}
+		evicted[victim.UID] = true
+		victims = append(victims, victim)
+		counts[high]--
+		counts[low]++
+	}
+}
@@ -0,0 +1,426 @@
+/*
+Copyright 2026 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reservation
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/component-helpers/scheduling/corev1/nodeaffinity"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/metrics"
+)
+
+const (
+	// PluginName indicates name of volcano scheduler plugin.
+	PluginName = "reservation"
+	// JobWaitingTime is the global waiting time of jobs, the same argument as the one of the sla plugin.
+	// A gang job waiting longer than it gets nodes reserved, the waiting time of a job can also be
+	// given by the sla-waiting-time annotation of the job.
+	JobWaitingTime = "sla-waiting-time"
+	// ReservationTTL is the maximum duration the nodes are reserved for a job, after which the nodes are
+	// released and the job is not elected again for another TTL. Defaults to 30m.
+	ReservationTTL = "reservation.ttl"
+	// MaxJobsPerQueue is the maximum number of jobs with reserved nodes in one queue. Defaults to 1.
+	MaxJobsPerQueue = "reservation.maxJobsPerQueue"
+
+	defaultReservationTTL  = 30 * time.Minute
+	defaultMaxJobsPerQueue = 1
+)
+
+// ArgumentsSchema is the schema of the arguments of reservation plugin
+var ArgumentsSchema = framework.ArgumentsSchema{
+	JobWaitingTime:  framework.DurationArgument,
+	ReservationTTL:  framework.DurationArgument,
+	MaxJobsPerQueue: framework.IntArgument,
+}
+
+// reservation is the set of nodes locked for a starving gang job.
+type reservation struct {
+	job        api.JobID
+	queue      api.QueueID
+	nodes      sets.Set[string]
+	reservedAt time.Time
+}
+
+// reservationState holds the reservations living across the sessions of a scheduler,
+// while the plugin is built for each session.
+type reservationState struct {
+	sync.Mutex
+	reservations map[api.JobID]*reservation
+	// expiredAt records when the reservation of a job was released because of the TTL.
+	expiredAt map[api.JobID]time.Time
+}
+
+func newReservationState() interface{} {
+	return &reservationState{
+		reservations: map[api.JobID]*reservation{},
+		expiredAt:    map[api.JobID]time.Time{},
+	}
+}
+
+/*
+   actions: "enqueue, allocate, backfill"
+   tiers:
+   - plugins:
+     - name: reservation
+       arguments:
+         sla-waiting-time: 1h
+         reservation.ttl: 30m
+         reservation.maxJobsPerQueue: 1
+*/
+
+type reservationPlugin struct {
+	jobWaitingTime  *time.Duration
+	ttl             time.Duration
+	maxJobsPerQueue int
+
+	// state is shared by the sessions of the same scheduler cache
+	state *reservationState
+	// lockedNodes maps the reserved nodes to the jobs they are reserved for in this session.
+	lockedNodes map[string]api.JobID
+}
+
+// New function returns reservation plugin object.
+func New(arguments framework.Arguments) framework.Plugin {
+	rp := &reservationPlugin{
+		ttl:             defaultReservationTTL,
+		maxJobsPerQueue: defaultMaxJobsPerQueue,
+		lockedNodes:     map[string]api.JobID{},
+	}
+
+	if value, ok := arguments[JobWaitingTime].(string); ok {
+		if jwt, err := time.ParseDuration(value); err != nil || jwt <= 0 {
+			klog.Warningf("Invalid global waiting time setting: %s in reservation plugin.", value)
+		} else {
+			rp.jobWaitingTime = &jwt
+		}
+	}
+	if value, ok := arguments[ReservationTTL].(string); ok {
+		if ttl, err := time.ParseDuration(value); err != nil || ttl <= 0 {
+			klog.Warningf("Invalid reservation TTL setting: %s in reservation plugin, use default %v.", value, defaultReservationTTL)
+		} else {
+			rp.ttl = ttl
+		}
+	}
+	arguments.GetInt(&rp.maxJobsPerQueue, MaxJobsPerQueue)
+
+	return rp
+}
+
+func (rp *reservationPlugin) Name() string {
+	return PluginName
+}
+
+func (rp *reservationPlugin) OnSessionOpen(ssn *framework.Session) {
+	klog.V(4).Infof("Enter reservation plugin ...")
+	defer klog.V(4).Infof("Leaving reservation plugin.")
+
+	rp.state = ssn.PluginState(rp.Name(), newReservationState).(*reservationState)
+
+	ssn.AddTargetJobFn(rp.Name(), func(jobs []*api.JobInfo) *api.JobInfo {
+		return rp.targetJob(ssn, jobs)
+	})
+
+	ssn.AddReservedNodesFn(rp.Name(), func() {
+		rp.reserveNodes(ssn)
+	})
+
+	ssn.AddPredicateFn(rp.Name(), func(task *api.TaskInfo, node *api.NodeInfo) error {
+		owner, found := rp.lockedNodes[node.Name]
+		if !found || owner == task.Job {
+			return nil
+		}
+		return api.NewFitErrWithStatus(task, node, &api.Status{
+			Code:   api.UnschedulableAndUnresolvable,
+			Reason: fmt.Sprintf("node is reserved for job <%s>", owner),
+			Plugin: rp.Name(),
+		})
+	})
+}
+
+func (rp *reservationPlugin) OnSessionClose(ssn *framework.Session) {}
+
+// targetJob returns the first starving job in job order, which is a gang job still pending
+// after its waiting time and not released because of the TTL recently.
+func (rp *reservationPlugin) targetJob(ssn *framework.Session, jobs []*api.JobInfo) *api.JobInfo {
+	var target *api.JobInfo
+	for _, job := range jobs {
+		if !rp.isStarving(job) {
+			continue
+		}
+		if target == nil || ssn.JobOrderFn(job, target) {
+			target = job
+		}
+	}
+	return target
+}
+
+func (rp *reservationPlugin) isStarving(job *api.JobInfo) bool {
+	if job.PodGroup == nil || job.MinAvailable <= 1 || job.IsReady() || len(job.TaskStatusIndex[api.Pending]) == 0 {
+		return false
+	}
+
+	jwt := job.WaitingTime
+	if jwt == nil {
+		jwt = rp.jobWaitingTime
+	}
+	if jwt == nil || time.Since(job.CreationTimestamp.Time) < *jwt {
+		return false
+	}
+
+	if expired, found := rp.state.expiredAt[job.UID]; found && time.Since(expired) < rp.ttl {
+		return false
+	}
+	return true
+}
+
+// reserveNodes releases the reservations of the jobs which are ready, deleted or out of the TTL, extends
+// the other reservations with the nodes freed since the last session, then reserves nodes for the target
+// job of each queue up to the per queue limit.
+func (rp *reservationPlugin) reserveNodes(ssn *framework.Session) {
+	state := rp.state
+	state.Lock()
+	defer state.Unlock()
+
+	if ssn.DryRun() {
+		rp.lockReservedNodes(ssn)
+		return
+	}
+
+	// the jobs scheduled by other profiles are not in ssn.Jobs, their reservations are kept
+	allJobs := map[api.JobID]*api.JobInfo{}
+	for _, job := range ssn.AllJobs() {
+		allJobs[job.UID] = job
+	}
+
+	reservedJobs := map[api.QueueID]int{}
+	for id, r := range state.reservations {
+		job, found := allJobs[id]
+		switch {
+		case !found || job.IsReady():
+			klog.V(3).Infof("Release the nodes %v reserved for job <%s>", sets.List(r.nodes), id)
+			delete(state.reservations, id)
+			continue
+		case time.Since(r.reservedAt) > rp.ttl:
+			klog.V(3).Infof("Release the nodes %v reserved for job <%s> as the reservation expired", sets.List(r.nodes), id)
+			delete(state.reservations, id)
+			state.expiredAt[id] = time.Now()
+			metrics.RegisterQueueReservationExpired(string(r.queue))
+			continue
+		}
+		for name := range r.nodes {
+			if _, found := ssn.Nodes[name]; !found {
+				r.nodes.Delete(name)
+				continue
+			}
+			rp.lockedNodes[name] = id
+		}
+		reservedJobs[r.queue]++
+	}
+	for id, expired := range state.expiredAt {
+		if time.Since(expired) >= rp.ttl {
+			delete(state.expiredAt, id)
+		}
+	}
+
+	reserved := make([]api.JobID, 0, len(state.reservations))
+	for id := range state.reservations {
+		if _, found := ssn.Jobs[id]; found {
+			reserved = append(reserved, id)
+		}
+	}
+	sort.Slice(reserved, func(i, j int) bool {
+		return reserved[i] < reserved[j]
+	})
+	for _, id := range reserved {
+		r := state.reservations[id]
+		nodes := rp.selectNodes(ssn, ssn.Jobs[id], r.nodes)
+		for name := range nodes {
+			r.nodes.Insert(name)
+			rp.lockedNodes[name] = id
+		}
+		if nodes.Len() != 0 {
+			klog.V(3).Infof("Reserve more nodes %v for job <%s>", sets.List(nodes), id)
+		}
+	}
+
+	candidates := map[api.QueueID][]*api.JobInfo{}
+	queues := make([]api.QueueID, 0, len(ssn.Queues))
+	for _, job := range ssn.Jobs {
+		if _, found := state.reservations[job.UID]; !found {
+			candidates[job.Queue] = append(candidates[job.Queue], job)
+		}
+	}
+	for queue := range candidates {
+		queues = append(queues, queue)
+	}
+	sort.Slice(queues, func(i, j int) bool {
+		return queues[i] < queues[j]
+	})
+	for _, queue := range queues {
+		jobs := candidates[queue]
+		for reservedJobs[queue] < rp.maxJobsPerQueue {
+			target := ssn.TargetJob(jobs)
+			if target == nil {
+				break
+			}
+			jobs = removeJob(jobs, target)
+
+			nodes := rp.selectNodes(ssn, target, nil)
+			if nodes.Len() == 0 {
+				klog.V(3).Infof("No nodes could be reserved for the pending tasks of job <%s>", target.UID)
+				continue
+			}
+			state.reservations[target.UID] = &reservation{
+				job:        target.UID,
+				queue:      queue,
+				nodes:      nodes,
+				reservedAt: time.Now(),
+			}
+			for name := range nodes {
+				rp.lockedNodes[name] = target.UID
+			}
+			reservedJobs[queue]++
+			klog.V(3).Infof("Reserve nodes %v for job <%s> of queue <%s>", sets.List(nodes), target.UID, queue)
+		}
+	}
+
+	rp.updateMetrics(ssn)
+}
+
+// lockReservedNodes locks the nodes of the reservations still in effect without changing them, it's used by
+// a dry-run session which must not release, extend or make reservations.
+func (rp *reservationPlugin) lockReservedNodes(ssn *framework.Session) {
+	allJobs := map[api.JobID]*api.JobInfo{}
+	for _, job := range ssn.AllJobs() {
+		allJobs[job.UID] = job
+	}
+	for id, r := range rp.state.reservations {
+		if job, found := allJobs[id]; !found || job.IsReady() || time.Since(r.reservedAt) > rp.ttl {
+			continue
+		}
+		for name := range r.nodes {
+			if _, found := ssn.Nodes[name]; found {
+				rp.lockedNodes[name] = id
+			}
+		}
+	}
+}
+
+// selectNodes places the pending tasks the gang job needs at least onto the free resources of the nodes
+// already reserved for it, then onto the unreserved nodes with more free resources first, and returns
+// the unreserved nodes holding some of the tasks. The tasks not fitting anywhere wait for more nodes
+// to be freed and reserved in the following sessions.
+func (rp *reservationPlugin) selectNodes(ssn *framework.Session, job *api.JobInfo, reserved sets.Set[string]) sets.Set[string] {
+	needed := int(job.MinAvailable - job.ReadyTaskNum() - job.WaitingTaskNum())
+	tasks := make([]*api.TaskInfo, 0, len(job.TaskStatusIndex[api.Pending]))
+	for _, task := range job.TaskStatusIndex[api.Pending] {
+		tasks = append(tasks, task)
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return ssn.TaskOrderFn(tasks[i], tasks[j])
+	})
+	if len(tasks) > needed {
+		tasks = tasks[:needed]
+	}
+
+	ownNodes := make([]*api.NodeInfo, 0, reserved.Len())
+	for _, name := range sets.List(reserved) {
+		ownNodes = append(ownNodes, ssn.Nodes[name])
+	}
+	nodes := make([]*api.NodeInfo, 0, len(ssn.Nodes))
+	for _, node := range ssn.Nodes {
+		if _, found := rp.lockedNodes[node.Name]; found || !node.Ready() {
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		li, ri := nodes[i].FutureIdle(), nodes[j].FutureIdle()
+		if li.MilliCPU != ri.MilliCPU {
+			return li.MilliCPU > ri.MilliCPU
+		}
+		if li.Memory != ri.Memory {
+			return li.Memory > ri.Memory
+		}
+		return nodes[i].Name < nodes[j].Name
+	})
+	nodes = append(ownNodes, nodes...)
+
+	remaining := make(map[string]*api.Resource, len(nodes))
+	selected := sets.New[string]()
+	for _, task := range tasks {
+		affinity := nodeaffinity.GetRequiredNodeAffinity(task.Pod)
+		for _, node := range nodes {
+			if match, _ := affinity.Match(node.Node); !match {
+				continue
+			}
+			if _, found := remaining[node.Name]; !found {
+				remaining[node.Name] = node.FutureIdle()
+			}
+			if task.InitResreq.LessEqual(remaining[node.Name], api.Zero) {
+				remaining[node.Name].Sub(task.InitResreq)
+				if !reserved.Has(node.Name) {
+					selected.Insert(node.Name)
+				}
+				break
+			}
+		}
+	}
+	return selected
+}
+
+func (rp *reservationPlugin) updateMetrics(ssn *framework.Session) {
+	type queueReservation struct {
+		jobs, nodes int
+		idle        *api.Resource
+	}
+	queues := map[api.QueueID]*queueReservation{}
+	for id := range ssn.Queues {
+		queues[id] = &queueReservation{idle: api.EmptyResource()}
+	}
+	for _, r := range rp.state.reservations {
+		q, found := queues[r.queue]
+		if !found {
+			continue
+		}
+		q.jobs++
+		q.nodes += r.nodes.Len()
+		for name := range r.nodes {
+			q.idle.Add(ssn.Nodes[name].Idle)
+		}
+	}
+	for id, q := range queues {
+		metrics.UpdateQueueReservation(string(id), q.jobs, q.nodes, q.idle.MilliCPU, q.idle.Memory)
+	}
+}
+
+func removeJob(jobs []*api.JobInfo, target *api.JobInfo) []*api.JobInfo {
+	for i, job := range jobs {
+		if job == target {
+			return append(jobs[:i], jobs[i+1:]...)
+		}
+	}
+	return jobs
+}
@@ -0,0 +1,313 @@
+/*
+Copyright 2026 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reservation
+
+import (
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func newCache(podGroups []*schedulingv1beta1.PodGroup, pods []*v1.Pod) *cache.SchedulerCache {
+	sc := cache.NewDefaultMockSchedulerCache("volcano")
+	for _, name := range []string{"n1", "n2", "n3"} {
+		sc.AddOrUpdateNode(util.BuildNode(name, api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil))
+	}
+	sc.AddQueueV1beta1(util.BuildQueue("q1", 1, nil))
+	for _, pg := range podGroups {
+		sc.AddPodGroupV1beta1(pg)
+	}
+	for _, pod := range pods {
+		sc.AddPod(pod)
+	}
+	return sc
+}
+
+func newTiers(arguments framework.Arguments) []conf.Tier {
+	trueValue := true
+	return []conf.Tier{
+		{
+			Plugins: []conf.PluginOption{
+				{
+					Name:                 PluginName,
+					EnabledPredicate:     &trueValue,
+					EnabledTargetJob:     &trueValue,
+					EnabledReservedNodes: &trueValue,
+					Arguments:            arguments,
+				},
+			},
+		},
+	}
+}
+
+func openSession(sc cache.Cache, arguments framework.Arguments, filter framework.JobFilter) *framework.Session {
+	framework.RegisterPluginBuilder(PluginName, New)
+	return framework.OpenFilteredSession(sc, newTiers(arguments), nil, filter)
+}
+
+func stateOf(sc cache.Cache) *reservationState {
+	return sc.PluginState(PluginName, newReservationState).(*reservationState)
+}
+
+// checkLockedNodes checks the nodes reserved for other jobs reject the task.
+func checkLockedNodes(t *testing.T, ssn *framework.Session, task *api.TaskInfo, locked sets.Set[string]) {
+	for name, node := range ssn.Nodes {
+		if err := ssn.PredicateFn(task, node); locked.Has(name) != (err != nil) {
+			t.Errorf("expected node %s locked %v for task %s, got predicate error %v", name, locked.Has(name), task.Name, err)
+		}
+	}
+}
+
+func TestReservation(t *testing.T) {
+	defer framework.CleanupPluginBuilders()
+
+	running := util.BuildPodGroup("pg-run", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning)
+	starving1 := util.BuildPodGroup("pg1", "c1", "q1", 3, nil, schedulingv1beta1.PodGroupInqueue)
+	starving2 := util.BuildPodGroup("pg2", "c1", "q1", 2, nil, schedulingv1beta1.PodGroupInqueue)
+	fresh := util.BuildPodGroup("pg3", "c1", "q1", 2, nil, schedulingv1beta1.PodGroupInqueue)
+	fresh.CreationTimestamp = metav1.Now()
+	single := util.BuildPodGroup("pg4", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue)
+
+	// free resources: n1 3cpu/7Gi, n2 3cpu/4Gi, n3 1cpu/7Gi
+	pods := []*v1.Pod{
+		util.BuildPod("c1", "r1", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg-run", nil, nil),
+		util.BuildPod("c1", "r2", "n2", v1.PodRunning, api.BuildResourceList("1", "4Gi"), "pg-run", nil, nil),
+		util.BuildPod("c1", "r3", "n3", v1.PodRunning, api.BuildResourceList("3", "1Gi"), "pg-run", nil, nil),
+		util.BuildPod("c1", "p1-0", "", v1.PodPending, api.BuildResourceList("1", "6Gi"), "pg1", nil, nil),
+		util.BuildPod("c1", "p1-1", "", v1.PodPending, api.BuildResourceList("1", "6Gi"), "pg1", nil, nil),
+		util.BuildPod("c1", "p1-2", "", v1.PodPending, api.BuildResourceList("1", "6Gi"), "pg1", nil, nil),
+		util.BuildPod("c1", "p2-0", "", v1.PodPending, api.BuildResourceList("3", "1Gi"), "pg2", nil, nil),
+		util.BuildPod("c1", "p2-1", "", v1.PodPending, api.BuildResourceList("3", "1Gi"), "pg2", nil, nil),
+		util.BuildPod("c1", "p3-0", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg3", nil, nil),
+		util.BuildPod("c1", "p3-1", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg3", nil, nil),
+		util.BuildPod("c1", "p4-0", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg4", nil, nil),
+	}
+	podGroups := []*schedulingv1beta1.PodGroup{running, starving1, starving2, fresh, single}
+
+	tests := []struct {
+		name      string
+		arguments framework.Arguments
+		// expected maps the jobs with reserved nodes to the reserved nodes
+		expected map[api.JobID]sets.Set[string]
+	}{
+		{
+			name:      "one job is reserved per queue by default",
+			arguments: framework.Arguments{JobWaitingTime: "1m"},
+			expected:  map[api.JobID]sets.Set[string]{"c1/pg1": sets.New("n1", "n3")},
+		},
+		{
+			name:      "more jobs are reserved with a larger per queue limit",
+			arguments: framework.Arguments{JobWaitingTime: "1m", MaxJobsPerQueue: 3},
+			expected:  map[api.JobID]sets.Set[string]{"c1/pg1": sets.New("n1", "n3"), "c1/pg2": sets.New("n2")},
+		},
+		{
+			name:      "no job is reserved without waiting time",
+			arguments: framework.Arguments{},
+			expected:  map[api.JobID]sets.Set[string]{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sc := newCache(podGroups, pods)
+			ssn := openSession(sc, test.arguments, nil)
+			defer framework.CloseSession(ssn)
+
+			reservations := stateOf(sc).reservations
+			if len(reservations) != len(test.expected) {
+				t.Fatalf("expected %d reservations, got %d", len(test.expected), len(reservations))
+			}
+			locked := sets.New[string]()
+			for job, nodes := range test.expected {
+				r, found := reservations[job]
+				if !found || !r.nodes.Equal(nodes) {
+					t.Fatalf("expected nodes %v reserved for job %s, got %v", sets.List(nodes), job, r)
+				}
+				locked = locked.Union(nodes)
+			}
+
+			checkLockedNodes(t, ssn, ssn.Jobs["c1/pg4"].Tasks["c1-p4-0"], locked)
+			for job, nodes := range test.expected {
+				for _, task := range ssn.Jobs[job].Tasks {
+					for name := range nodes {
+						if err := ssn.PredicateFn(task, ssn.Nodes[name]); err != nil {
+							t.Errorf("expected node %s available for job %s, got %v", name, job, err)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestReservationTTL(t *testing.T) {
+	defer framework.CleanupPluginBuilders()
+
+	podGroups := []*schedulingv1beta1.PodGroup{
+		util.BuildPodGroup("pg-run", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning),
+		util.BuildPodGroup("pg1", "c1", "q1", 2, nil, schedulingv1beta1.PodGroupInqueue),
+	}
+	pods := []*v1.Pod{
+		util.BuildPod("c1", "r1", "n1", v1.PodRunning, api.BuildResourceList("3", "1Gi"), "pg-run", nil, nil),
+		util.BuildPod("c1", "p1-0", "", v1.PodPending, api.BuildResourceList("2", "1Gi"), "pg1", nil, nil),
+		util.BuildPod("c1", "p1-1", "", v1.PodPending, api.BuildResourceList("2", "1Gi"), "pg1", nil, nil),
+	}
+	arguments := framework.Arguments{JobWaitingTime: "1m", ReservationTTL: "10m"}
+	sc := newCache(podGroups, pods)
+	state := stateOf(sc)
+
+	framework.CloseSession(openSession(sc, arguments, nil))
+	if _, found := state.reservations["c1/pg1"]; !found {
+		t.Fatalf("expected nodes reserved for job c1/pg1")
+	}
+
+	// the reservation is kept by the next session within the TTL
+	framework.CloseSession(openSession(sc, arguments, nil))
+	r, found := state.reservations["c1/pg1"]
+	if !found {
+		t.Fatalf("expected the reservation of job c1/pg1 kept within the TTL")
+	}
+
+	// the reservation is released after the TTL and the job is not elected again
+	r.reservedAt = time.Now().Add(-time.Hour)
+	ssn := openSession(sc, arguments, nil)
+	defer framework.CloseSession(ssn)
+	if len(state.reservations) != 0 {
+		t.Errorf("expected the reservation released after the TTL, got %v", state.reservations)
+	}
+	if _, found := state.expiredAt["c1/pg1"]; !found {
+		t.Errorf("expected job c1/pg1 recorded as expired")
+	}
+	checkLockedNodes(t, ssn, ssn.Jobs["c1/pg-run"].Tasks["c1-r1"], sets.New[string]())
+}
+
+func TestReservationAcrossSessions(t *testing.T) {
+	defer framework.CleanupPluginBuilders()
+
+	podGroups := []*schedulingv1beta1.PodGroup{
+		util.BuildPodGroup("pg-run", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning),
+		util.BuildPodGroup("pg1", "c1", "q1", 2, nil, schedulingv1beta1.PodGroupInqueue),
+		util.BuildPodGroup("pg2", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue),
+	}
+	// free resources: n1 3cpu, n2 1cpu, n3 1cpu
+	r2 := util.BuildPod("c1", "r2", "n2", v1.PodRunning, api.BuildResourceList("3", "1Gi"), "pg-run", nil, nil)
+	pods := []*v1.Pod{
+		util.BuildPod("c1", "r1", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg-run", nil, nil),
+		r2,
+		util.BuildPod("c1", "r3", "n3", v1.PodRunning, api.BuildResourceList("3", "1Gi"), "pg-run", nil, nil),
+		util.BuildPod("c1", "p1-0", "", v1.PodPending, api.BuildResourceList("3", "1Gi"), "pg1", nil, nil),
+		util.BuildPod("c1", "p1-1", "", v1.PodPending, api.BuildResourceList("3", "1Gi"), "pg1", nil, nil),
+		util.BuildPod("c1", "p2-0", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg2", nil, nil),
+	}
+	arguments := framework.Arguments{JobWaitingTime: "1m"}
+	sc := newCache(podGroups, pods)
+	state := stateOf(sc)
+
+	// only n1 has enough free resources for a task of job c1/pg1
+	framework.CloseSession(openSession(sc, arguments, nil))
+	if r, found := state.reservations["c1/pg1"]; !found || !r.nodes.Equal(sets.New("n1")) {
+		t.Fatalf("expected node n1 reserved for job c1/pg1, got %v", r)
+	}
+
+	// the reservation is kept by a session not scheduling the job, and its nodes are still locked
+	ssn := openSession(sc, arguments, func(job *api.JobInfo) bool { return job.Name != "pg1" })
+	if _, found := state.reservations["c1/pg1"]; !found {
+		t.Errorf("expected the reservation of job c1/pg1 kept by a session not scheduling it")
+	}
+	checkLockedNodes(t, ssn, ssn.Jobs["c1/pg2"].Tasks["c1-p2-0"], sets.New("n1"))
+	framework.CloseSession(ssn)
+
+	// the reservation grows with the nodes freed later
+	sc.DeletePod(r2)
+	framework.CloseSession(openSession(sc, arguments, nil))
+	if r, found := state.reservations["c1/pg1"]; !found || !r.nodes.Equal(sets.New("n1", "n2")) {
+		t.Errorf("expected nodes n1 and n2 reserved for job c1/pg1, got %v", r)
+	}
+
+	// the reservations are kept per scheduler cache
+	other := newCache(podGroups, pods)
+	framework.CloseSession(openSession(other, framework.Arguments{}, nil))
+	if len(stateOf(other).reservations) != 0 {
+		t.Errorf("expected no reservation in another scheduler cache, got %v", stateOf(other).reservations)
+	}
+	if len(state.reservations) != 1 {
+		t.Errorf("expected the reservations of scheduler cache not changed by another one, got %v", state.reservations)
+	}
+}
+
+func TestReservationExplain(t *testing.T) {
+	defer framework.CleanupPluginBuilders()
+
+	podGroups := []*schedulingv1beta1.PodGroup{
+		util.BuildPodGroup("pg-run", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupRunning),
+		util.BuildPodGroup("pg1", "c1", "q1", 2, nil, schedulingv1beta1.PodGroupInqueue),
+		util.BuildPodGroup("pg2", "c1", "q1", 1, nil, schedulingv1beta1.PodGroupInqueue),
+	}
+	// free resources: n1 3cpu, n2 1cpu, n3 1cpu
+	r2 := util.BuildPod("c1", "r2", "n2", v1.PodRunning, api.BuildResourceList("3", "1Gi"), "pg-run", nil, nil)
+	pods := []*v1.Pod{
+		util.BuildPod("c1", "r1", "n1", v1.PodRunning, api.BuildResourceList("1", "1Gi"), "pg-run", nil, nil),
+		r2,
+		util.BuildPod("c1", "r3", "n3", v1.PodRunning, api.BuildResourceList("3", "1Gi"), "pg-run", nil, nil),
+		util.BuildPod("c1", "p1-0", "", v1.PodPending, api.BuildResourceList("3", "1Gi"), "pg1", nil, nil),
+		util.BuildPod("c1", "p1-1", "", v1.PodPending, api.BuildResourceList("3", "1Gi"), "pg1", nil, nil),
+		util.BuildPod("c1", "p2-0", "", v1.PodPending, api.BuildResourceList("1", "1Gi"), "pg2", nil, nil),
+	}
+	arguments := framework.Arguments{JobWaitingTime: "1m"}
+	framework.RegisterPluginBuilder(PluginName, New)
+	sc := newCache(podGroups, pods)
+	state := stateOf(sc)
+
+	// explaining a job makes no reservation for the starving job
+	if _, err := framework.Explain(sc, newTiers(arguments), nil, nil, "c1", "pg1"); err != nil {
+		t.Fatalf("failed to explain job c1/pg1: %v", err)
+	}
+	if len(state.reservations) != 0 {
+		t.Fatalf("expected no reservation made by explaining a job, got %v", state.reservations)
+	}
+
+	framework.CloseSession(openSession(sc, arguments, nil))
+	if r, found := state.reservations["c1/pg1"]; !found || !r.nodes.Equal(sets.New("n1")) {
+		t.Fatalf("expected node n1 reserved for job c1/pg1, got %v", r)
+	}
+
+	// explaining a job doesn't extend the reservation with the freed nodes, but the reserved nodes are explained
+	sc.DeletePod(r2)
+	explanation, err := framework.Explain(sc, newTiers(arguments), nil, nil, "c1", "pg2")
+	if err != nil {
+		t.Fatalf("failed to explain job c1/pg2: %v", err)
+	}
+	if r := state.reservations["c1/pg1"]; !r.nodes.Equal(sets.New("n1")) {
+		t.Errorf("expected the reservation of job c1/pg1 not extended by explaining a job, got %v", sets.List(r.nodes))
+	}
+	if len(explanation.Tasks) != 1 {
+		t.Fatalf("expected 1 pending task of job c1/pg2 explained, got %d", len(explanation.Tasks))
+	}
+	if _, found := explanation.Tasks[0].PredicateFailures["n1"][PluginName]; !found {
+		t.Errorf("expected node n1 reserved for job c1/pg1 in the explanation, got %v", explanation.Tasks[0].PredicateFailures)
+	}
+}
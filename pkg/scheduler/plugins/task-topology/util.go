@@ -40,6 +40,11 @@ const (
 	TaskOrderAnnotations = "volcano.sh/task-topology-task-order"
 )
 
+// ArgumentsSchema is the schema of the arguments of task-topology plugin
+var ArgumentsSchema = framework.ArgumentsSchema{
+	PluginWeight: framework.IntArgument,
+}
+
 // TaskTopology is struct used to save affinity infos of a job read from job plugin or annotations
 type TaskTopology struct {
 	Affinity     [][]string `json:"affinity,omitempty"`
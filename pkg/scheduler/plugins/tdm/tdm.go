@@ -54,6 +54,12 @@ var lastEvictAt time.Time
          tdm.evict.period: 1m
 */
 
+// ArgumentsSchema is the schema of the arguments of tdm plugin
+var ArgumentsSchema = framework.ArgumentsSchema{
+	revocableZoneLabelPrefix + "*": framework.StringArgument,
+	evictPeriodLabel:               framework.DurationArgument,
+}
+
 type tdmPlugin struct {
 	revocableZone map[string]string
 	// evictPeriod
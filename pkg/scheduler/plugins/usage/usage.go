@@ -17,6 +17,7 @@ limitations under the License.
 package usage
 
 import (
+	"fmt"
 	"time"
 
 	"volcano.sh/volcano/pkg/scheduler/metrics/source"
@@ -35,8 +36,26 @@ const (
 	MetricsActiveTime     = 5 * time.Minute
 	NodeUsageCPUExtend    = "the CPU load of the node exceeds the upper limit."
 	NodeUsageMemoryExtend = "the memory load of the node exceeds the upper limit."
+
+	// The keys of the thresholds of the extended metrics, which are not filtered on unless configured.
+	loadThreshold            = "load"
+	networkReceiveThreshold  = "networkReceive"
+	networkTransmitThreshold = "networkTransmit"
+	diskReadThreshold        = "diskRead"
+	diskWriteThreshold       = "diskWrite"
 )
 
+// extendedThresholdNames are the keys of the thresholds of the extended metrics in the order they are checked.
+var extendedThresholdNames = []string{loadThreshold, networkReceiveThreshold, networkTransmitThreshold, diskReadThreshold, diskWriteThreshold}
+
+// ArgumentsSchema is the schema of the arguments of usage plugin
+var ArgumentsSchema = framework.ArgumentsSchema{
+	"usage.weight":   framework.IntArgument,
+	"cpu.weight":     framework.IntArgument,
+	"memory.weight":  framework.IntArgument,
+	thresholdSection: framework.MapArgument,
+}
+
 /*
    actions: "enqueue, allocate, backfill"
    tiers:
@@ -50,6 +69,8 @@ const (
          thresholds:
            cpu: 80
            mem: 80
+           load: 16                 # Optional, the 1 minute load average
+           networkReceive: 104857600 # Optional, bytes per second, as networkTransmit, diskRead and diskWrite
 */
 
 const AVG string = "average"
@@ -62,20 +83,23 @@ type usagePlugin struct {
 	usageType       string
 	cpuThresholds   float64
 	memThresholds   float64
-	period          string
+	// extendedThresholds are the thresholds of the extended metrics like load, network and disk throughput
+	extendedThresholds map[string]float64
+	period             string
 }
 
 // New function returns usagePlugin object
 func New(args framework.Arguments) framework.Plugin {
 	var plugin = &usagePlugin{
-		pluginArguments: args,
-		usageWeight:     5,
-		cpuWeight:       1,
-		memoryWeight:    1,
-		usageType:       AVG,
-		cpuThresholds:   80,
-		memThresholds:   80,
-		period:          source.NODE_METRICS_PERIOD,
+		pluginArguments:    args,
+		usageWeight:        5,
+		cpuWeight:          1,
+		memoryWeight:       1,
+		usageType:          AVG,
+		cpuThresholds:      80,
+		memThresholds:      80,
+		extendedThresholds: map[string]float64{},
+		period:             source.NODE_METRICS_PERIOD,
 	}
 	args.GetInt(&plugin.usageWeight, "usage.weight")
 	args.GetInt(&plugin.cpuWeight, "cpu.weight")
@@ -94,12 +118,23 @@ func New(args framework.Arguments) framework.Plugin {
 	}
 	for resourceName, threshold := range thresholdArgs {
 		resource, _ := resourceName.(string)
-		value, _ := threshold.(int)
+		var value float64
+		switch v := threshold.(type) {
+		case int:
+			value = float64(v)
+		case float64:
+			value = v
+		default:
+			klog.Errorf("Failed to convert the threshold of %s, threshold value is %v", resource, threshold)
+			continue
+		}
 		switch resource {
 		case "cpu":
-			plugin.cpuThresholds = float64(value)
+			plugin.cpuThresholds = value
 		case "mem":
-			plugin.memThresholds = float64(value)
+			plugin.memThresholds = value
+		case loadThreshold, networkReceiveThreshold, networkTransmitThreshold, diskReadThreshold, diskWriteThreshold:
+			plugin.extendedThresholds[resource] = value
 		}
 	}
 
@@ -150,6 +185,19 @@ func (up *usagePlugin) OnSessionOpen(ssn *framework.Session) {
 			predicateStatus = append(predicateStatus, usageStatus)
 			return api.NewFitErrWithStatus(task, node, predicateStatus...)
 		}
+		for _, name := range extendedThresholdNames {
+			threshold, found := up.extendedThresholds[name]
+			if !found {
+				continue
+			}
+			if usage := extendedUsage(node.ResourceUsage, name)[up.period]; usage > threshold {
+				klog.V(3).Infof("Node %s %s usage %f exceeds the threshold %f", node.Name, name, usage, threshold)
+				usageStatus.Code = api.UnschedulableAndUnresolvable
+				usageStatus.Reason = fmt.Sprintf("the %s of the node exceeds the upper limit.", name)
+				predicateStatus = append(predicateStatus, usageStatus)
+				return api.NewFitErrWithStatus(task, node, predicateStatus...)
+			}
+		}
 
 		klog.V(4).Infof("Usage plugin filter for task %s/%s on node %s pass.", task.Namespace, task.Name, node.Name)
 		return nil
@@ -187,4 +235,21 @@ func (up *usagePlugin) OnSessionOpen(ssn *framework.Session) {
 	ssn.AddNodeOrderFn(up.Name(), nodeOrderFn)
 }
 
+// extendedUsage returns the usage of the extended metric of the threshold name.
+func extendedUsage(usage *api.NodeUsage, name string) map[string]float64 {
+	switch name {
+	case loadThreshold:
+		return usage.LoadAvg
+	case networkReceiveThreshold:
+		return usage.NetworkReceiveAvg
+	case networkTransmitThreshold:
+		return usage.NetworkTransmitAvg
+	case diskReadThreshold:
+		return usage.DiskReadAvg
+	case diskWriteThreshold:
+		return usage.DiskWriteAvg
+	}
+	return nil
+}
+
 func (up *usagePlugin) OnSessionClose(ssn *framework.Session) {}
@@ -70,6 +70,7 @@ func TestUsage_predicateFn(t *testing.T) {
 	n3 := util.BuildNode("n3", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string))
 	n4 := util.BuildNode("n4", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string))
 	n5 := util.BuildNode("n5", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string))
+	n6 := util.BuildNode("n6", api.BuildResourceList("4", "8Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), make(map[string]string))
 
 	nodesUsage := make(map[string]*api.NodeUsage)
 	timeNow := time.Now()
@@ -90,6 +91,11 @@ func TestUsage_predicateFn(t *testing.T) {
 	// However, the metric time is in the initial state, and the usage function is invalid.
 	// The node can schedule pods.
 	nodesUsage[n5.Name] = buildNodeUsage(map[string]float64{source.NODE_METRICS_PERIOD: 90}, map[string]float64{source.NODE_METRICS_PERIOD: 81}, time.Time{})
+	// The CPU usage and memory usage do not exceed the upper limit, but the load average and disk write throughput are high.
+	// The node cannot be scheduled only if the thresholds of the load average or disk write throughput are configured.
+	nodesUsage[n6.Name] = buildNodeUsage(map[string]float64{source.NODE_METRICS_PERIOD: 50}, map[string]float64{source.NODE_METRICS_PERIOD: 50}, timeNow)
+	nodesUsage[n6.Name].LoadAvg = map[string]float64{source.NODE_METRICS_PERIOD: 20}
+	nodesUsage[n6.Name].DiskWriteAvg = map[string]float64{source.NODE_METRICS_PERIOD: 200 * 1024 * 1024}
 
 	pg1 := util.BuildPodGroup("pg1", "c1", "q1", 0, nil, "")
 
@@ -101,6 +107,83 @@ func TestUsage_predicateFn(t *testing.T) {
 		arguments     framework.Arguments
 		expected      predicateResult
 	}{
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:      "The node can be scheduled, because of the thresholds of the extended metrics are not configured.",
+				PodGroups: []*schedulingv1.PodGroup{pg1},
+				Queues:    []*schedulingv1.Queue{queue1},
+				Pods:      []*v1.Pod{p1, p2},
+				Nodes:     []*v1.Node{n6},
+			},
+			nodesUsageMap: nodesUsage,
+			arguments: framework.Arguments{
+				"thresholds": map[interface{}]interface{}{
+					"cpu": 80,
+					"mem": 80,
+				},
+			},
+			expected: predicateResult{
+				predicateStatus: []*api.Status{
+					{
+						Code:   api.Success,
+						Reason: "",
+					},
+				},
+				err: nil,
+			},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:      "The node cannot be scheduled, because of the load average of the node exceeds the upper limit.",
+				PodGroups: []*schedulingv1.PodGroup{pg1},
+				Queues:    []*schedulingv1.Queue{queue1},
+				Pods:      []*v1.Pod{p1, p2},
+				Nodes:     []*v1.Node{n6},
+			},
+			nodesUsageMap: nodesUsage,
+			arguments: framework.Arguments{
+				"thresholds": map[interface{}]interface{}{
+					"cpu":       80,
+					"mem":       80,
+					"load":      16.5,
+					"diskWrite": 100 * 1024 * 1024,
+				},
+			},
+			expected: predicateResult{
+				predicateStatus: []*api.Status{
+					{
+						Code:   api.UnschedulableAndUnresolvable,
+						Reason: "the load of the node exceeds the upper limit.",
+					},
+				},
+				err: fmt.Errorf("plugin %s predicates failed, because of %s", PluginName, "the load of the node exceeds the upper limit."),
+			},
+		},
+		{
+			TestCommonStruct: uthelper.TestCommonStruct{
+				Name:      "The node cannot be scheduled, because of the disk write throughput of the node exceeds the upper limit.",
+				PodGroups: []*schedulingv1.PodGroup{pg1},
+				Queues:    []*schedulingv1.Queue{queue1},
+				Pods:      []*v1.Pod{p1, p2},
+				Nodes:     []*v1.Node{n6},
+			},
+			nodesUsageMap: nodesUsage,
+			arguments: framework.Arguments{
+				"thresholds": map[interface{}]interface{}{
+					"load":      32,
+					"diskWrite": 100 * 1024 * 1024,
+				},
+			},
+			expected: predicateResult{
+				predicateStatus: []*api.Status{
+					{
+						Code:   api.UnschedulableAndUnresolvable,
+						Reason: "the diskWrite of the node exceeds the upper limit.",
+					},
+				},
+				err: fmt.Errorf("plugin %s predicates failed, because of %s", PluginName, "the diskWrite of the node exceeds the upper limit."),
+			},
+		},
 		{
 			TestCommonStruct: uthelper.TestCommonStruct{
 				Name:      "The node cannot be scheduled, because of the CPU load of the node exceeds the upper limit.",
@@ -0,0 +1,49 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package jsoncodec registers a gRPC codec encoding messages as json, it is used by the
+// plugins talking to out-of-process services over gRPC, so that those services can be
+// written in any language without sharing generated code with vc-scheduler.
+package jsoncodec
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc/encoding"
+)
+
+// Name is the content-subtype of gRPC calls encoded by the codec,
+// i.e. the content-type is "application/grpc+json".
+const Name = "json"
+
+func init() {
+	encoding.RegisterCodec(codec{})
+}
+
+// codec encodes gRPC messages as json.
+type codec struct{}
+
+func (codec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (codec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (codec) Name() string {
+	return Name
+}
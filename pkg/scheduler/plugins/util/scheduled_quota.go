@@ -0,0 +1,320 @@
+/*
+Copyright 2026 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	// the timezones of quota windows are loaded even if the image of the scheduler has no tzdata
+	_ "time/tzdata"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/metrics"
+)
+
+const (
+	// ScheduledQuotasAnnotationKey is the annotation of queue with the quota windows overriding the deserved
+	// and capability of the queue on a schedule, e.g.
+	// [{"name": "night", "window": "22:00-06:00", "capability": {"nvidia.com/gpu": "16"}, "gracePeriod": "10m"}]
+	ScheduledQuotasAnnotationKey = "volcano.sh/scheduled-quotas"
+	// ActiveQuotaWindowAnnotationKey is the annotation of queue with the name of its active quota window, it's
+	// written by the scheduler and removed when no quota window of the queue is active.
+	ActiveQuotaWindowAnnotationKey = "volcano.sh/active-quota-window"
+
+	// quotaWindowLayout is the layout of the start and end of quota windows, the same as the revocable zones of tdm
+	quotaWindowLayout = "15:04"
+	// maxGracePeriod is the max grace period of quota windows
+	maxGracePeriod = 24 * time.Hour
+)
+
+// QuotaWindow overrides the deserved and capability of a queue in a daily time window
+type QuotaWindow struct {
+	Name string `json:"name"`
+	// Window is the daily time window in the timezone of the window, e.g. 22:00-06:00. The window ends on the next
+	// day if its end is not after its start, and it lasts the whole day if its end is equal to its start.
+	Window string `json:"window"`
+	// Timezone is the IANA name of the timezone of the window and its weekdays, e.g. Asia/Shanghai or UTC;
+	// the local timezone of the scheduler is used if empty.
+	Timezone string `json:"timezone,omitempty"`
+	// Weekdays are the days the window starts on, e.g. ["Sat", "Sun"]; the window starts every day if empty.
+	Weekdays []string `json:"weekdays,omitempty"`
+	// Deserved overrides the deserved of the queue by resource name in the window.
+	Deserved v1.ResourceList `json:"deserved,omitempty"`
+	// Capability overrides the capability of the queue by resource name in the window.
+	Capability v1.ResourceList `json:"capability,omitempty"`
+	// GracePeriod is the duration after the window closes, during which the resources of the queue beyond its
+	// deserved out of the window are not reclaimed, e.g. 10m.
+	GracePeriod string `json:"gracePeriod,omitempty"`
+
+	start, end  time.Time
+	location    *time.Location
+	weekdays    map[time.Weekday]bool
+	gracePeriod time.Duration
+}
+
+// ParseQuotaWindows parses and validates the quota windows of a queue
+func ParseQuotaWindows(value string) ([]*QuotaWindow, error) {
+	var windows []*QuotaWindow
+	if err := json.Unmarshal([]byte(value), &windows); err != nil {
+		return nil, err
+	}
+
+	names := map[string]bool{}
+	for _, w := range windows {
+		if w.Name == "" {
+			return nil, fmt.Errorf("the name of quota window %s is empty", w.Window)
+		}
+		if names[w.Name] {
+			return nil, fmt.Errorf("duplicated quota window %s", w.Name)
+		}
+		names[w.Name] = true
+
+		window := strings.Split(strings.TrimSpace(w.Window), "-")
+		if len(window) != 2 {
+			return nil, fmt.Errorf("quota window %s: window %s format error", w.Name, w.Window)
+		}
+		var err error
+		if w.start, err = time.Parse(quotaWindowLayout, strings.TrimSpace(window[0])); err != nil {
+			return nil, fmt.Errorf("quota window %s: %v", w.Name, err)
+		}
+		if w.end, err = time.Parse(quotaWindowLayout, strings.TrimSpace(window[1])); err != nil {
+			return nil, fmt.Errorf("quota window %s: %v", w.Name, err)
+		}
+
+		if w.Timezone != "" {
+			if w.location, err = time.LoadLocation(w.Timezone); err != nil {
+				return nil, fmt.Errorf("quota window %s: %v", w.Name, err)
+			}
+		}
+
+		if len(w.Weekdays) != 0 {
+			w.weekdays = map[time.Weekday]bool{}
+		}
+		for _, day := range w.Weekdays {
+			weekday, found := parseWeekday(day)
+			if !found {
+				return nil, fmt.Errorf("quota window %s: unknown weekday %s", w.Name, day)
+			}
+			w.weekdays[weekday] = true
+		}
+
+		if w.GracePeriod != "" {
+			if w.gracePeriod, err = time.ParseDuration(w.GracePeriod); err != nil {
+				return nil, fmt.Errorf("quota window %s: %v", w.Name, err)
+			}
+			if w.gracePeriod < 0 || w.gracePeriod > maxGracePeriod {
+				return nil, fmt.Errorf("quota window %s: grace period %s is out of [0, %s]", w.Name, w.GracePeriod, maxGracePeriod)
+			}
+		}
+	}
+	return windows, nil
+}
+
+func parseWeekday(day string) (time.Weekday, bool) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if strings.EqualFold(day, weekday.String()) || strings.EqualFold(day, weekday.String()[:3]) {
+			return weekday, true
+		}
+	}
+	return time.Sunday, false
+}
+
+// span returns the time span of the window starting on the day of now plus days
+func (w *QuotaWindow) span(now time.Time, days int) (start, end time.Time) {
+	start = time.Date(now.Year(), now.Month(), now.Day()+days, w.start.Hour(), w.start.Minute(), 0, 0, now.Location())
+	end = time.Date(now.Year(), now.Month(), now.Day()+days, w.end.Hour(), w.end.Minute(), 0, 0, now.Location())
+	if !end.After(start) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return start, end
+}
+
+// status returns whether the window is active at now, and whether it's in its grace period after it closes.
+func (w *QuotaWindow) status(now time.Time) (active, grace bool) {
+	if w.location != nil {
+		now = now.In(w.location)
+	}
+	// a window lasts at most one day and its grace period lasts at most one day,
+	// so only the windows starting on the last two days and today matter.
+	for days := -2; days <= 0; days++ {
+		start, end := w.span(now, days)
+		if w.weekdays != nil && !w.weekdays[start.Weekday()] {
+			continue
+		}
+		if !now.Before(start) && now.Before(end) {
+			return true, false
+		}
+		if !now.Before(end) && now.Before(end.Add(w.gracePeriod)) {
+			grace = true
+		}
+	}
+	return false, grace
+}
+
+// ActiveQuotaWindow returns the first active quota window of the queue at now, and whether any quota window
+// of the queue is in its grace period.
+func ActiveQuotaWindow(queue *api.QueueInfo, now time.Time) (*QuotaWindow, bool, error) {
+	value, found := queue.Queue.Annotations[ScheduledQuotasAnnotationKey]
+	if !found {
+		return nil, false, nil
+	}
+	windows, err := ParseQuotaWindows(value)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var activeWindow *QuotaWindow
+	grace := false
+	for _, w := range windows {
+		active, inGrace := w.status(now)
+		if active && activeWindow == nil {
+			activeWindow = w
+		}
+		grace = grace || inGrace
+	}
+	return activeWindow, grace, nil
+}
+
+// scheduledQuotasStateName is the name of the state of the scheduled quotas kept by the scheduler cache
+const scheduledQuotasStateName = "scheduled-quotas"
+
+// scheduledQuotasState is the active quota windows of the queues in the last session of a scheduler
+type scheduledQuotasState struct {
+	sync.Mutex
+	// activeWindows are queue name -> window name
+	activeWindows map[string]string
+}
+
+func newScheduledQuotasState() interface{} {
+	return &scheduledQuotasState{activeWindows: map[string]string{}}
+}
+
+// ApplyScheduledQuotas overrides the deserved and capability of the queues in the session by their active quota
+// windows, and returns the queues whose closed quota windows are in their grace period. It's called by the plugins
+// using the deserved or capability of queues on session open, calling it more than once in a session is harmless.
+func ApplyScheduledQuotas(ssn *framework.Session, now time.Time) map[api.QueueID]bool {
+	state := ssn.PluginState(scheduledQuotasStateName, newScheduledQuotasState).(*scheduledQuotasState)
+	state.Lock()
+	defer state.Unlock()
+
+	graceQueues := map[api.QueueID]bool{}
+	for queueID, queue := range ssn.Queues {
+		window, grace, err := ActiveQuotaWindow(queue, now)
+		if err != nil {
+			klog.Errorf("Failed to parse annotation %s of queue <%s>, ignore it: %v", ScheduledQuotasAnnotationKey, queue.Name, err)
+		}
+		if grace && window == nil {
+			graceQueues[queueID] = true
+		}
+
+		windowName := ""
+		if window != nil {
+			windowName = window.Name
+			ssn.Queues[queueID] = overrideQueueQuota(queue, window)
+		}
+		// a dry-run session applies the windows but reports nothing
+		if ssn.DryRun() {
+			continue
+		}
+		if last := state.activeWindows[queue.Name]; last != windowName {
+			recordQuotaWindowChange(ssn, queue, last, window, grace)
+			metrics.UpdateQueueQuotaWindow(queue.Name, windowName)
+			state.activeWindows[queue.Name] = windowName
+		}
+		publishQuotaWindow(ssn, queue, windowName)
+	}
+	if ssn.DryRun() {
+		return graceQueues
+	}
+	for name := range state.activeWindows {
+		if _, found := ssn.Queues[api.QueueID(name)]; !found {
+			delete(state.activeWindows, name)
+		}
+	}
+	return graceQueues
+}
+
+// publishQuotaWindow writes the active quota window to the annotation of the queue if it differs from the one
+// in the cache, the annotation is removed if no window is active.
+func publishQuotaWindow(ssn *framework.Session, queue *api.QueueInfo, windowName string) {
+	if queue.Queue.Annotations[ActiveQuotaWindowAnnotationKey] == windowName || ssn.VCClient() == nil {
+		return
+	}
+	var value interface{}
+	if windowName != "" {
+		value = windowName
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]interface{}{ActiveQuotaWindowAnnotationKey: value},
+		},
+	})
+	if err != nil {
+		klog.Errorf("Failed to marshal the active quota window of queue <%s>: %v", queue.Name, err)
+		return
+	}
+	if _, err := ssn.VCClient().SchedulingV1beta1().Queues().Patch(context.TODO(), queue.Name,
+		types.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		klog.Errorf("Failed to update the active quota window of queue <%s>: %v", queue.Name, err)
+	}
+}
+
+func overrideQueueQuota(queue *api.QueueInfo, window *QuotaWindow) *api.QueueInfo {
+	overridden := queue.Clone()
+	overridden.Queue = queue.Queue.DeepCopy()
+	spec := &overridden.Queue.Spec
+	if len(window.Deserved) != 0 && spec.Deserved == nil {
+		spec.Deserved = v1.ResourceList{}
+	}
+	for name, quantity := range window.Deserved {
+		spec.Deserved[name] = quantity
+	}
+	if len(window.Capability) != 0 && spec.Capability == nil {
+		spec.Capability = v1.ResourceList{}
+	}
+	for name, quantity := range window.Capability {
+		spec.Capability[name] = quantity
+	}
+	return overridden
+}
+
+func recordQuotaWindowChange(ssn *framework.Session, queue *api.QueueInfo, last string, window *QuotaWindow, grace bool) {
+	if last != "" {
+		msg := fmt.Sprintf("Scheduled quota window %s is closed", last)
+		if grace {
+			msg += ", the resources beyond the deserved will be reclaimed after the grace period"
+		}
+		klog.V(3).Infof("Queue <%s>: %s", queue.Name, msg)
+		ssn.RecordQueueEvent(queue, v1.EventTypeNormal, "QuotaWindowClosed", msg)
+	}
+	if window != nil {
+		msg := fmt.Sprintf("Scheduled quota window %s is active, deserved <%v>, capability <%v>",
+			window.Name, api.NewResource(window.Deserved), api.NewResource(window.Capability))
+		klog.V(3).Infof("Queue <%s>: %s", queue.Name, msg)
+		ssn.RecordQueueEvent(queue, v1.EventTypeNormal, "QuotaWindowOpened", msg)
+	}
+}
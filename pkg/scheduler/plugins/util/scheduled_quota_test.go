@@ -0,0 +1,266 @@
+/*
+Copyright 2026 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"volcano.sh/apis/pkg/apis/scheduling"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestParseQuotaWindows(t *testing.T) {
+	cases := []struct {
+		name      string
+		value     string
+		expectErr string
+	}{
+		{
+			name:  "valid windows",
+			value: `[{"name": "night", "window": "22:00-06:00", "gracePeriod": "10m"}, {"name": "weekend", "window": "00:00-00:00", "weekdays": ["Sat", "sunday"]}]`,
+		},
+		{
+			name:      "invalid json",
+			value:     `{"name": "night"}`,
+			expectErr: "json: cannot unmarshal object",
+		},
+		{
+			name:      "duplicated window",
+			value:     `[{"name": "night", "window": "22:00-06:00"}, {"name": "night", "window": "20:00-22:00"}]`,
+			expectErr: "duplicated quota window night",
+		},
+		{
+			name:      "invalid window",
+			value:     `[{"name": "night", "window": "22:00"}]`,
+			expectErr: "quota window night: window 22:00 format error",
+		},
+		{
+			name:      "unknown weekday",
+			value:     `[{"name": "night", "window": "22:00-06:00", "weekdays": ["Someday"]}]`,
+			expectErr: "quota window night: unknown weekday Someday",
+		},
+		{
+			name:      "unknown timezone",
+			value:     `[{"name": "night", "window": "22:00-06:00", "timezone": "Mars/Olympus"}]`,
+			expectErr: "quota window night: unknown time zone Mars/Olympus",
+		},
+		{
+			name:      "grace period too long",
+			value:     `[{"name": "night", "window": "22:00-06:00", "gracePeriod": "48h"}]`,
+			expectErr: "quota window night: grace period 48h is out of [0, 24h0m0s]",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := ParseQuotaWindows(c.value)
+			if c.expectErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), c.expectErr)
+			}
+		})
+	}
+}
+
+func TestActiveQuotaWindow(t *testing.T) {
+	queue := api.NewQueueInfo(&scheduling.Queue{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "q1",
+			Annotations: map[string]string{ScheduledQuotasAnnotationKey: `[
+				{"name": "night", "window": "22:00-06:00", "gracePeriod": "1h"},
+				{"name": "weekend", "window": "00:00-00:00", "weekdays": ["Sat", "Sun"]}
+			]`},
+		},
+	})
+
+	// 2026-10-14 is a Wednesday
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, time.October, day, hour, minute, 0, 0, time.Local)
+	}
+	cases := []struct {
+		name         string
+		now          time.Time
+		expectWindow string
+		expectGrace  bool
+	}{
+		{
+			name:         "before midnight in the overnight window",
+			now:          at(14, 23, 0),
+			expectWindow: "night",
+		},
+		{
+			name:         "after midnight in the overnight window",
+			now:          at(15, 5, 59),
+			expectWindow: "night",
+		},
+		{
+			name:         "in the grace period of the overnight window",
+			now:          at(15, 6, 30),
+			expectWindow: "",
+			expectGrace:  true,
+		},
+		{
+			name:         "out of all the windows",
+			now:          at(15, 7, 30),
+			expectWindow: "",
+		},
+		{
+			name:         "in the window of weekdays",
+			now:          at(17, 12, 0),
+			expectWindow: "weekend",
+		},
+		{
+			name:         "the first active window wins",
+			now:          at(18, 23, 59),
+			expectWindow: "night",
+		},
+		{
+			name:         "the window of weekdays is closed",
+			now:          at(19, 12, 0),
+			expectWindow: "",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			window, grace, err := ActiveQuotaWindow(queue, c.now)
+			assert.NoError(t, err)
+			name := ""
+			if window != nil {
+				name = window.Name
+			}
+			assert.Equal(t, c.expectWindow, name)
+			assert.Equal(t, c.expectGrace, grace)
+		})
+	}
+}
+
+func TestQuotaWindowTimezone(t *testing.T) {
+	queue := api.NewQueueInfo(&scheduling.Queue{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "q1",
+			Annotations: map[string]string{ScheduledQuotasAnnotationKey: `[
+				{"name": "tokyo-morning", "window": "09:00-10:00", "weekdays": ["Thu"], "timezone": "Asia/Tokyo"}
+			]`},
+		},
+	})
+
+	// 2026-10-15 09:30 in Tokyo is 2026-10-15 00:30 in UTC, a Thursday in both
+	cases := map[time.Time]string{
+		time.Date(2026, time.October, 15, 0, 30, 0, 0, time.UTC): "tokyo-morning",
+		time.Date(2026, time.October, 15, 9, 30, 0, 0, time.UTC): "",
+		// 2026-10-14 22:30 in UTC is 2026-10-15 07:30 in Tokyo, before the window
+		time.Date(2026, time.October, 14, 22, 30, 0, 0, time.UTC): "",
+	}
+	for now, expectWindow := range cases {
+		window, _, err := ActiveQuotaWindow(queue, now)
+		assert.NoError(t, err)
+		name := ""
+		if window != nil {
+			name = window.Name
+		}
+		assert.Equal(t, expectWindow, name, now.String())
+	}
+}
+
+// scheduledQuotasPlugin applies the scheduled quotas on session open as the capacity and proportion plugins do
+type scheduledQuotasPlugin struct{}
+
+func (sp *scheduledQuotasPlugin) Name() string { return "scheduled-quotas" }
+
+func (sp *scheduledQuotasPlugin) OnSessionOpen(ssn *framework.Session) {
+	ApplyScheduledQuotas(ssn, time.Now())
+}
+
+func (sp *scheduledQuotasPlugin) OnSessionClose(ssn *framework.Session) {}
+
+func TestApplyScheduledQuotas(t *testing.T) {
+	framework.RegisterPluginBuilder("scheduled-quotas", func(framework.Arguments) framework.Plugin { return &scheduledQuotasPlugin{} })
+	defer framework.CleanupPluginBuilders()
+
+	queue := util.BuildQueue("q1", 1, nil)
+	queue.Annotations = map[string]string{ScheduledQuotasAnnotationKey: `[{"name": "all-day", "window": "00:00-00:00"}]`}
+
+	newCache := func() (*cache.SchedulerCache, *record.FakeRecorder) {
+		recorder := record.NewFakeRecorder(10)
+		sc := cache.NewCustomMockSchedulerCache("mock-scheduler", util.NewFakeBinder(0), util.NewFakeEvictor(0),
+			&util.FakeStatusUpdater{}, nil, nil, recorder)
+		sc.AddQueueV1beta1(queue)
+		_, err := sc.VCClient().SchedulingV1beta1().Queues().Create(context.TODO(), queue, metav1.CreateOptions{})
+		assert.NoError(t, err)
+		return sc, recorder
+	}
+	apply := func(sc *cache.SchedulerCache) {
+		ssn := framework.OpenSession(sc, nil, nil)
+		defer framework.CloseSession(ssn)
+		ApplyScheduledQuotas(ssn, time.Now())
+	}
+
+	sc1, recorder1 := newCache()
+	// explaining a job applies the windows, but neither records nor publishes them. The job is not found,
+	// but the plugins are opened before
+	tiers := []conf.Tier{{Plugins: []conf.PluginOption{{Name: "scheduled-quotas"}}}}
+	_, err := framework.Explain(sc1, tiers, nil, nil, "c1", "pg1")
+	assert.Error(t, err)
+	assert.Len(t, recorder1.Events, 0, "no window is recorded by explaining a job")
+	q, err := sc1.VCClient().SchedulingV1beta1().Queues().Get(context.TODO(), "q1", metav1.GetOptions{})
+	if assert.NoError(t, err) {
+		_, found := q.Annotations[ActiveQuotaWindowAnnotationKey]
+		assert.False(t, found, "no window is published by explaining a job")
+	}
+
+	apply(sc1)
+	assert.Len(t, recorder1.Events, 1, "the opened window is recorded")
+	q, err = sc1.VCClient().SchedulingV1beta1().Queues().Get(context.TODO(), "q1", metav1.GetOptions{})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "all-day", q.Annotations[ActiveQuotaWindowAnnotationKey])
+	}
+	apply(sc1)
+	assert.Len(t, recorder1.Events, 1, "the window stays open")
+
+	// the active windows are kept per scheduler
+	sc2, recorder2 := newCache()
+	apply(sc2)
+	assert.Len(t, recorder2.Events, 1, "the opened window is recorded by the other scheduler")
+
+	// the annotation is removed once no window is active
+	closed := queue.DeepCopy()
+	closed.Annotations = map[string]string{ActiveQuotaWindowAnnotationKey: "all-day"}
+	closed.ResourceVersion = "2"
+	sc2.UpdateQueueV1beta1(queue, closed)
+	apply(sc2)
+	q, err = sc2.VCClient().SchedulingV1beta1().Queues().Get(context.TODO(), "q1", metav1.GetOptions{})
+	if assert.NoError(t, err) {
+		_, found := q.Annotations[ActiveQuotaWindowAnnotationKey]
+		assert.False(t, found)
+	}
+	assert.Len(t, recorder2.Events, 2, "the closed window is recorded")
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2026 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"fmt"
+	"slices"
+
+	"k8s.io/klog/v2"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+)
+
+// profile is the actions, plugins and configurations of the sessions scheduling the jobs of its scheduler names.
+type profile struct {
+	// schedulerNames are the scheduler names of the pods scheduled by the profile
+	schedulerNames []string
+	// isDefault means the profile also schedules the jobs without any pod, e.g. the podgroups of vcjobs not enqueued yet
+	isDefault      bool
+	actions        []framework.Action
+	plugins        []conf.Tier
+	configurations []conf.Configuration
+}
+
+// jobFilter returns the filter of the jobs scheduled by the profile, it is nil if the profile schedules all the jobs.
+func (p *profile) jobFilter(profiles []*profile) framework.JobFilter {
+	if len(profiles) == 1 {
+		return nil
+	}
+	return func(job *api.JobInfo) bool {
+		for _, task := range job.Tasks {
+			if task.Pod != nil {
+				return slices.Contains(p.schedulerNames, task.Pod.Spec.SchedulerName)
+			}
+		}
+		return p.isDefault
+	}
+}
+
+// getSchedulerConf returns the names of the actions and plugins of the profile
+func (p *profile) getSchedulerConf() (actions []string, plugins []string) {
+	for _, action := range p.actions {
+		actions = append(actions, action.Name())
+	}
+	for _, tier := range p.plugins {
+		for _, plugin := range tier.Plugins {
+			plugins = append(plugins, plugin.Name)
+		}
+	}
+	return
+}
+
+// unmarshalSchedulerProfiles unmarshals the scheduler configuration into the profiles of the scheduler names and
+// the metrics configuration. The scheduler names without a profile share the actions, tiers and configurations of
+// the scheduler, and the profile of the first scheduler name is the default one.
+func unmarshalSchedulerProfiles(confStr string, schedulerNames []string) ([]*profile, map[string]string, error) {
+	schedulerConf, err := unmarshalSchedulerConfiguration(confStr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var profiles []*profile
+	for _, p := range schedulerConf.Profiles {
+		if p.SchedulerName == "" {
+			return nil, nil, fmt.Errorf("schedulerName of profile is mandatory")
+		}
+		if slices.ContainsFunc(profiles, func(pr *profile) bool { return pr.schedulerNames[0] == p.SchedulerName }) {
+			return nil, nil, fmt.Errorf("duplicated profile of scheduler %s", p.SchedulerName)
+		}
+		if !slices.Contains(schedulerNames, p.SchedulerName) {
+			klog.Warningf("Ignore the profile of scheduler %s, which is not in the scheduler names %v", p.SchedulerName, schedulerNames)
+			continue
+		}
+
+		pr := &profile{
+			schedulerNames: []string{p.SchedulerName},
+			actions:        parseActions(schedulerConf.Actions),
+			plugins:        schedulerConf.Tiers,
+			configurations: schedulerConf.Configurations,
+		}
+		if p.Actions != "" {
+			pr.actions = parseActions(p.Actions)
+		}
+		if len(p.Tiers) > 0 {
+			pr.plugins = p.Tiers
+		}
+		if len(p.Configurations) > 0 {
+			pr.configurations = p.Configurations
+		}
+		profiles = append(profiles, pr)
+	}
+
+	shared := &profile{
+		actions:        parseActions(schedulerConf.Actions),
+		plugins:        schedulerConf.Tiers,
+		configurations: schedulerConf.Configurations,
+	}
+	for _, name := range schedulerNames {
+		if !slices.ContainsFunc(profiles, func(pr *profile) bool { return pr.schedulerNames[0] == name }) {
+			shared.schedulerNames = append(shared.schedulerNames, name)
+		}
+	}
+	if len(shared.schedulerNames) > 0 || len(profiles) == 0 {
+		profiles = append([]*profile{shared}, profiles...)
+	}
+
+	for _, p := range profiles {
+		p.isDefault = len(schedulerNames) == 0 || slices.Contains(p.schedulerNames, schedulerNames[0])
+	}
+
+	return profiles, schedulerConf.MetricsConfiguration, nil
+}
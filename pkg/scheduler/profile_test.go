@@ -0,0 +1,156 @@
+/*
+Copyright 2026 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+func TestUnmarshalSchedulerProfiles(t *testing.T) {
+	configuration := `
+actions: "enqueue, allocate, backfill"
+tiers:
+- plugins:
+  - name: priority
+  - name: gang
+configurations:
+- name: allocate
+  arguments:
+    predicateErrorCacheEnable: false
+profiles:
+- schedulerName: online
+  actions: "allocate, preempt"
+  tiers:
+  - plugins:
+    - name: priority
+    - name: binpack
+- schedulerName: batch
+  configurations:
+  - name: enqueue
+    arguments:
+      overcommit-factor: 2
+- schedulerName: unknown
+`
+	profiles, _, err := unmarshalSchedulerProfiles(configuration, []string{"volcano", "batch", "online", "extra"})
+	if !assert.NoError(t, err) || !assert.Len(t, profiles, 3) {
+		return
+	}
+
+	names := func(p *profile) ([]string, []string, []string) {
+		actions, plugins := p.getSchedulerConf()
+		var configurations []string
+		for _, c := range p.configurations {
+			configurations = append(configurations, c.Name)
+		}
+		return actions, plugins, configurations
+	}
+
+	// the scheduler names without a profile share the actions, tiers and configurations of the scheduler
+	assert.Equal(t, []string{"volcano", "extra"}, profiles[0].schedulerNames)
+	assert.True(t, profiles[0].isDefault)
+	actions, plugins, configurations := names(profiles[0])
+	assert.Equal(t, []string{"enqueue", "allocate", "backfill"}, actions)
+	assert.Equal(t, []string{"priority", "gang"}, plugins)
+	assert.Equal(t, []string{"allocate"}, configurations)
+
+	assert.Equal(t, []string{"online"}, profiles[1].schedulerNames)
+	assert.False(t, profiles[1].isDefault)
+	actions, plugins, configurations = names(profiles[1])
+	assert.Equal(t, []string{"allocate", "preempt"}, actions)
+	assert.Equal(t, []string{"priority", "binpack"}, plugins)
+	assert.Equal(t, []string{"allocate"}, configurations)
+	assert.NotNil(t, profiles[1].plugins[0].Plugins[1].EnabledNodeOrder, "default settings of profile plugins should be set")
+
+	assert.Equal(t, []string{"batch"}, profiles[2].schedulerNames)
+	actions, plugins, configurations = names(profiles[2])
+	assert.Equal(t, []string{"enqueue", "allocate", "backfill"}, actions)
+	assert.Equal(t, []string{"priority", "gang"}, plugins)
+	assert.Equal(t, []string{"enqueue"}, configurations)
+
+	// the profile of the first scheduler name is the default one even if it is not shared
+	profiles, _, err = unmarshalSchedulerProfiles(configuration, []string{"online", "batch"})
+	if assert.NoError(t, err) && assert.Len(t, profiles, 2) {
+		assert.Equal(t, []string{"online"}, profiles[0].schedulerNames)
+		assert.True(t, profiles[0].isDefault)
+		assert.False(t, profiles[1].isDefault)
+	}
+
+	for name, configuration := range map[string]string{
+		"missing scheduler name": `
+profiles:
+- actions: "allocate"
+`,
+		"duplicated profile": `
+profiles:
+- schedulerName: online
+- schedulerName: online
+`,
+		"conflicting plugins": `
+profiles:
+- schedulerName: online
+  tiers:
+  - plugins:
+    - name: drf
+      enableHierarchy: true
+    - name: proportion
+`,
+	} {
+		if _, _, err := unmarshalSchedulerProfiles(configuration, []string{"volcano", "online"}); err == nil {
+			t.Errorf("%s: expected error, got nil", name)
+		}
+	}
+}
+
+func TestProfileJobFilter(t *testing.T) {
+	newJob := func(schedulerName string) *api.JobInfo {
+		job := api.NewJobInfo("job")
+		if schedulerName != "" {
+			pod := util.BuildPod("ns", "pod", "", v1.PodPending, api.BuildResourceList("1", "1G"), "pg", nil, nil)
+			pod.Spec.SchedulerName = schedulerName
+			job.AddTaskInfo(api.NewTaskInfo(pod))
+		}
+		return job
+	}
+
+	shared := &profile{schedulerNames: []string{"volcano", "extra"}, isDefault: true}
+	online := &profile{schedulerNames: []string{"online"}}
+
+	assert.Nil(t, shared.jobFilter([]*profile{shared}), "the only profile schedules all the jobs")
+
+	profiles := []*profile{shared, online}
+	for _, tc := range []struct {
+		schedulerName string
+		shared        bool
+		online        bool
+	}{
+		{schedulerName: "volcano", shared: true},
+		{schedulerName: "extra", shared: true},
+		{schedulerName: "online", online: true},
+		// the jobs without any pod are scheduled by the default profile
+		{schedulerName: "", shared: true},
+	} {
+		job := newJob(tc.schedulerName)
+		assert.Equal(t, tc.shared, shared.jobFilter(profiles)(job), "shared profile, scheduler %q", tc.schedulerName)
+		assert.Equal(t, tc.online, online.jobFilter(profiles)(job), "online profile, scheduler %q", tc.schedulerName)
+	}
+}
@@ -17,14 +17,19 @@ limitations under the License.
 package scheduler
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/fsnotify/fsnotify"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/rest"
 	"k8s.io/klog/v2"
@@ -42,17 +47,31 @@ import (
 // It attempts to find nodes that can accommodate these pods and writes the binding information back to the API server.
 type Scheduler struct {
 	cache          schedcache.Cache
+	schedulerNames []string
 	schedulerConf  string
-	fileWatcher    filewatcher.FileWatcher
-	schedulePeriod time.Duration
-	once           sync.Once
-
-	mutex          sync.Mutex
-	actions        []framework.Action
-	plugins        []conf.Tier
-	configurations []conf.Configuration
-	metricsConf    map[string]string
-	dumper         schedcache.Dumper
+	// schedulerConfigMap is the ConfigMap mounted as schedulerConf, the events of refusing to load
+	// an invalid scheduler configuration are recorded on it if it's not nil
+	schedulerConfigMap *v1.ConfigMap
+	fileWatcher        filewatcher.FileWatcher
+	schedulePeriod     time.Duration
+	once               sync.Once
+
+	// eventDriven enables starting a scheduling cycle early when the cache is triggered by events,
+	// debouncePeriod and minSchedulePeriod limit how often the event triggered cycles run.
+	eventDriven       bool
+	debouncePeriod    time.Duration
+	minSchedulePeriod time.Duration
+
+	// sessionMutex serializes the scheduling sessions and the dry-run sessions opened for explain
+	sessionMutex sync.Mutex
+
+	mutex sync.Mutex
+	// profiles are scheduled one after another in each scheduling cycle, the default profile first
+	profiles    []*profile
+	metricsConf map[string]string
+	dumper      schedcache.Dumper
+	// traces keeps the scheduling traces of the latest sessions, it is nil if trace is not enabled
+	traces *framework.TraceBuffer
 }
 
 // NewScheduler returns a Scheduler
@@ -69,11 +88,30 @@ func NewScheduler(config *rest.Config, opt *options.ServerOption) (*Scheduler, e
 
 	cache := schedcache.New(config, opt.SchedulerNames, opt.DefaultQueue, opt.NodeSelector, opt.NodeWorkerThreads, opt.IgnoredCSIProvisioners)
 	scheduler := &Scheduler{
-		schedulerConf:  opt.SchedulerConf,
-		fileWatcher:    watcher,
-		cache:          cache,
-		schedulePeriod: opt.SchedulePeriod,
-		dumper:         schedcache.Dumper{Cache: cache, RootDir: opt.CacheDumpFileDir},
+		schedulerNames:    opt.SchedulerNames,
+		schedulerConf:     opt.SchedulerConf,
+		fileWatcher:       watcher,
+		cache:             cache,
+		schedulePeriod:    opt.SchedulePeriod,
+		eventDriven:       opt.EnableEventDrivenScheduling,
+		debouncePeriod:    opt.ScheduleDebouncePeriod,
+		minSchedulePeriod: opt.MinSchedulePeriod,
+		dumper:            schedcache.Dumper{Cache: cache, RootDir: opt.CacheDumpFileDir},
+	}
+	if opt.EnableSchedulingTrace {
+		scheduler.traces = framework.NewTraceBuffer(opt.SchedulingTraceSessions)
+	}
+	if opt.SchedulerConfigMap != "" {
+		namespace, name, found := strings.Cut(opt.SchedulerConfigMap, "/")
+		if !found || namespace == "" || name == "" {
+			return nil, fmt.Errorf("invalid scheduler configmap %s, it should be <namespace>/<name>", opt.SchedulerConfigMap)
+		}
+		scheduler.schedulerConfigMap = &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	}
+	// the initial configuration is not validated strictly, so that an existing configuration with e.g. unknown keys
+	// doesn't prevent the scheduler from starting after an upgrade
+	if err := scheduler.loadSchedulerConf(false); err != nil {
+		return nil, err
 	}
 
 	return scheduler, nil
@@ -82,19 +120,63 @@ func NewScheduler(config *rest.Config, opt *options.ServerOption) (*Scheduler, e
 // Run initializes and starts the Scheduler. It loads the configuration,
 // initializes the cache, and begins the scheduling process.
 func (pc *Scheduler) Run(stopCh <-chan struct{}) {
-	pc.loadSchedulerConf()
 	go pc.watchSchedulerConf(stopCh)
 	// Start cache for policy.
 	pc.cache.SetMetricsConf(pc.metricsConf)
 	pc.cache.Run(stopCh)
 	klog.V(2).Infof("Scheduler completes Initialization and start to run")
-	go wait.Until(pc.runOnce, pc.schedulePeriod, stopCh)
+	if pc.eventDriven {
+		go pc.runLoop(pc.cache.ScheduleTrigger(), pc.runOnce, stopCh)
+	} else {
+		go wait.Until(pc.runOnce, pc.schedulePeriod, stopCh)
+	}
 	if options.ServerOpts.EnableCacheDumper {
 		pc.dumper.ListenForSignal(stopCh)
 	}
 	go runSchedulerSocket()
 }
 
+// runLoop calls run every schedule period like wait.Until, and also when the trigger is signaled. A triggered
+// run waits for the debounce period to merge the following triggers, and starts at least minSchedulePeriod
+// after the end of the last run. The triggers arriving while running cause one more run after it.
+func (pc *Scheduler) runLoop(trigger <-chan struct{}, run func(), stopCh <-chan struct{}) {
+	var lastEnd time.Time
+	period := time.NewTimer(0)
+	defer period.Stop()
+
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-period.C:
+		case <-trigger:
+			delay := pc.debouncePeriod
+			if wait := pc.minSchedulePeriod - time.Since(lastEnd); wait > delay {
+				delay = wait
+			}
+			debounce := time.NewTimer(delay)
+			select {
+			case <-stopCh:
+				debounce.Stop()
+				return
+			case <-period.C:
+				debounce.Stop()
+			case <-debounce.C:
+				period.Stop()
+			}
+			// the triggers arriving while debouncing are served by this run
+			select {
+			case <-trigger:
+			default:
+			}
+		}
+
+		run()
+		lastEnd = time.Now()
+		period.Reset(pc.schedulePeriod)
+	}
+}
+
 // runOnce executes a single scheduling cycle. This function is called periodically
 // as defined by the Scheduler's schedule period.
 func (pc *Scheduler) runOnce() {
@@ -103,40 +185,110 @@ func (pc *Scheduler) runOnce() {
 	defer klog.V(4).Infof("End scheduling ...")
 
 	pc.mutex.Lock()
-	actions := pc.actions
-	plugins := pc.plugins
-	configurations := pc.configurations
+	profiles := pc.profiles
 	pc.mutex.Unlock()
 
+	pc.sessionMutex.Lock()
+	defer pc.sessionMutex.Unlock()
+
+	// The sessions of profiles share the cache and run one after another, so that the tasks
+	// scheduled by a profile occupy the nodes seen by the sessions of the following profiles.
+	for _, p := range profiles {
+		pc.runProfile(p, profiles)
+	}
+	metrics.UpdateE2eDuration(metrics.Duration(scheduleStartTime))
+}
+
+// runProfile opens a session scheduling the jobs of the profile and executes the actions of the profile.
+func (pc *Scheduler) runProfile(p *profile, profiles []*profile) {
 	// Load ConfigMap to check which action is enabled.
 	conf.EnabledActionMap = make(map[string]bool)
-	for _, action := range actions {
+	for _, action := range p.actions {
 		conf.EnabledActionMap[action.Name()] = true
 	}
 
-	ssn := framework.OpenSession(pc.cache, plugins, configurations)
+	ssn := framework.OpenFilteredSession(pc.cache, p.plugins, p.configurations, p.jobFilter(profiles))
+	if pc.traces != nil {
+		ssn.EnableTrace()
+	}
 	defer func() {
 		framework.CloseSession(ssn)
-		metrics.UpdateE2eDuration(metrics.Duration(scheduleStartTime))
+		if pc.traces != nil {
+			pc.traces.Add(ssn.Trace())
+		}
 	}()
 
-	for _, action := range actions {
+	for _, action := range p.actions {
 		actionStartTime := time.Now()
 		action.Execute(ssn)
 		metrics.UpdateActionDuration(action.Name(), metrics.Duration(actionStartTime))
 	}
 }
 
-func (pc *Scheduler) loadSchedulerConf() {
+// TraceHandler returns the http handler serving the scheduling traces of the latest sessions,
+// it is nil if scheduling trace is not enabled.
+func (pc *Scheduler) TraceHandler() http.Handler {
+	if pc.traces == nil {
+		return nil
+	}
+	return pc.traces
+}
+
+// ExplainHandler returns the http handler explaining why the podgroup given by the query parameters
+// namespace and name is pending, which is computed by a dry-run session with the current configuration.
+// The podgroup is explained by the profile of the query parameter schedulerName if given, otherwise by
+// the first profile scheduling it.
+func (pc *Scheduler) ExplainHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		namespace, name := r.URL.Query().Get("namespace"), r.URL.Query().Get("name")
+		if namespace == "" {
+			namespace = "default"
+		}
+		if name == "" {
+			http.Error(w, "query parameter name is mandatory", http.StatusBadRequest)
+			return
+		}
+
+		schedulerName := r.URL.Query().Get("schedulerName")
+
+		pc.mutex.Lock()
+		profiles := pc.profiles
+		pc.mutex.Unlock()
+
+		var explanation *framework.JobExplanation
+		err := fmt.Errorf("no profile of scheduler %s", schedulerName)
+		pc.sessionMutex.Lock()
+		for _, p := range profiles {
+			if schedulerName != "" && !slices.Contains(p.schedulerNames, schedulerName) {
+				continue
+			}
+			explanation, err = framework.Explain(pc.cache, p.plugins, p.configurations, p.jobFilter(profiles), namespace, name)
+			if err == nil {
+				break
+			}
+		}
+		pc.sessionMutex.Unlock()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(explanation); err != nil {
+			klog.Errorf("Failed to encode explanation of <%s/%s>: %v", namespace, name, err)
+		}
+	})
+}
+
+// loadSchedulerConf loads the scheduler configuration, the previous configuration is kept and the error is returned
+// if the configuration can't be read or is invalid. If strict is false, the errors of the strict validation are only
+// reported and the configuration is loaded leniently.
+func (pc *Scheduler) loadSchedulerConf(strict bool) error {
 	klog.V(4).Infof("Start loadSchedulerConf ...")
-	defer func() {
-		actions, plugins := pc.getSchedulerConf()
-		klog.V(2).Infof("Successfully loaded Scheduler conf, actions: %v, plugins: %v", actions, plugins)
-	}()
 
 	var err error
 	pc.once.Do(func() {
-		pc.actions, pc.plugins, pc.configurations, pc.metricsConf, err = UnmarshalSchedulerConf(DefaultSchedulerConf)
+		pc.profiles, pc.metricsConf, err = unmarshalSchedulerProfiles(DefaultSchedulerConf, pc.schedulerNames)
 		if err != nil {
 			klog.Errorf("unmarshal Scheduler config %s failed: %v", DefaultSchedulerConf, err)
 			panic("invalid default configuration")
@@ -149,35 +301,43 @@ func (pc *Scheduler) loadSchedulerConf() {
 		if err != nil {
 			klog.Errorf("Failed to read the Scheduler config in '%s', using previous configuration: %v",
 				pc.schedulerConf, err)
-			return
+			return fmt.Errorf("failed to read scheduler config %s: %v", pc.schedulerConf, err)
 		}
 		config = strings.TrimSpace(string(confData))
 	}
 
-	actions, plugins, configurations, metricsConf, err := UnmarshalSchedulerConf(config)
+	if err := ValidateSchedulerConf(config, pc.schedulerNames); err != nil && !strict {
+		klog.Warningf("Scheduler config %s is invalid, loading it leniently: %v", config, err)
+		if pc.schedulerConfigMap != nil {
+			pc.cache.EventRecorder().Eventf(pc.schedulerConfigMap, v1.EventTypeWarning, "InvalidSchedulerConf",
+				"Scheduler config %s is invalid, loading it leniently: %v", pc.schedulerConf, err)
+		}
+	} else if err != nil {
+		klog.Errorf("Scheduler config %s is invalid, using previous configuration: %v", config, err)
+		metrics.RegisterSchedulerConfLoad(false)
+		if pc.schedulerConfigMap != nil {
+			pc.cache.EventRecorder().Eventf(pc.schedulerConfigMap, v1.EventTypeWarning, "InvalidSchedulerConf",
+				"Scheduler config %s is invalid, using previous configuration: %v", pc.schedulerConf, err)
+		}
+		return fmt.Errorf("scheduler config %s is invalid: %v", pc.schedulerConf, err)
+	}
+	profiles, metricsConf, err := unmarshalSchedulerProfiles(config, pc.schedulerNames)
 	if err != nil {
 		klog.Errorf("Scheduler config %s is invalid: %v", config, err)
-		return
+		metrics.RegisterSchedulerConfLoad(false)
+		return fmt.Errorf("scheduler config %s is invalid: %v", pc.schedulerConf, err)
 	}
+	metrics.RegisterSchedulerConfLoad(true)
 
 	pc.mutex.Lock()
-	pc.actions = actions
-	pc.plugins = plugins
-	pc.configurations = configurations
+	pc.profiles = profiles
 	pc.metricsConf = metricsConf
 	pc.mutex.Unlock()
-}
-
-func (pc *Scheduler) getSchedulerConf() (actions []string, plugins []string) {
-	for _, action := range pc.actions {
-		actions = append(actions, action.Name())
-	}
-	for _, tier := range pc.plugins {
-		for _, plugin := range tier.Plugins {
-			plugins = append(plugins, plugin.Name)
-		}
+	for _, p := range profiles {
+		actions, plugins := p.getSchedulerConf()
+		klog.V(2).Infof("Successfully loaded Scheduler conf of %v, actions: %v, plugins: %v", p.schedulerNames, actions, plugins)
 	}
-	return
+	return nil
 }
 
 func (pc *Scheduler) watchSchedulerConf(stopCh <-chan struct{}) {
@@ -194,7 +354,8 @@ func (pc *Scheduler) watchSchedulerConf(stopCh <-chan struct{}) {
 			}
 			klog.V(4).Infof("watch %s event: %v", pc.schedulerConf, event)
 			if event.Op&fsnotify.Write == fsnotify.Write || event.Op&fsnotify.Create == fsnotify.Create {
-				pc.loadSchedulerConf()
+				// the errors are reported by loadSchedulerConf, and the previous configuration is kept
+				_ = pc.loadSchedulerConf(true)
 				pc.cache.SetMetricsConf(pc.metricsConf)
 			}
 		case err, ok := <-errCh:
@@ -0,0 +1,67 @@
+/*
+Copyright 2026 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRunLoop(t *testing.T) {
+	pc := &Scheduler{
+		schedulePeriod:    time.Hour,
+		debouncePeriod:    20 * time.Millisecond,
+		minSchedulePeriod: 100 * time.Millisecond,
+	}
+	trigger := make(chan struct{}, 1)
+	runs := make(chan time.Time, 10)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	go pc.runLoop(trigger, func() { runs <- time.Now() }, stopCh)
+
+	// the first cycle runs at once like wait.Until
+	var last time.Time
+	select {
+	case last = <-runs:
+	case <-time.After(time.Second):
+		t.Fatalf("expected the first cycle to run at once")
+	}
+
+	// the triggers in a burst are merged into one cycle, which respects the minimum schedule period
+	for i := 0; i < 3; i++ {
+		select {
+		case trigger <- struct{}{}:
+		default:
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	select {
+	case now := <-runs:
+		if elapsed := now.Sub(last); elapsed < pc.minSchedulePeriod {
+			t.Errorf("expected the triggered cycle to run at least %v after the last one, got %v", pc.minSchedulePeriod, elapsed)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected the trigger to run a cycle before the schedule period")
+	}
+
+	select {
+	case <-runs:
+		t.Errorf("expected the triggers of a burst to be merged into one cycle")
+	case <-time.After(300 * time.Millisecond):
+	}
+}
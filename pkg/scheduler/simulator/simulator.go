@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"fmt"
+	"sort"
+
+	"volcano.sh/volcano/pkg/scheduler"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/uthelper"
+	"volcano.sh/volcano/pkg/scheduler/util"
+)
+
+// Result is the outcome of replaying a cluster snapshot through one scheduling session.
+type Result struct {
+	// Binds are the tasks dispatched to nodes: ns/podName -> nodeName
+	Binds map[string]string `json:"binds"`
+	// Pipelined are the tasks pipelined onto releasing resources: ns/podName -> nodeName
+	Pipelined map[string]string `json:"pipelined"`
+	// Evicted are the tasks evicted by preempt/reclaim/shuffle, sorted by ns/podName
+	Evicted []string `json:"evicted"`
+}
+
+// LoadClusterInfo reads a cluster snapshot file dumped by the scheduler cache dumper,
+// and rebuilds the api.ClusterInfo from it.
+func LoadClusterInfo(path string) (*api.ClusterInfo, error) {
+	cs, err := cache.LoadClusterSnapshot(path)
+	if err != nil {
+		return nil, err
+	}
+	return cs.ClusterInfo(), nil
+}
+
+// Simulate opens a session with the actions and tiers in schedulerConf against a fake
+// cache filled with the snapshot, executes the actions and returns the decisions made.
+// Nothing is sent to a real cluster. The nodes are selected deterministically, so that
+// two runs on the same snapshot make the same decisions.
+func Simulate(snapshot *api.ClusterInfo, schedulerConf string) (*Result, error) {
+	if schedulerConf == "" {
+		schedulerConf = scheduler.DefaultSchedulerConf
+	}
+	actions, tiers, configurations, _, err := scheduler.UnmarshalSchedulerConf(schedulerConf)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scheduler configuration: %v", err)
+	}
+	if len(actions) == 0 {
+		return nil, fmt.Errorf("no valid action found in scheduler configuration")
+	}
+
+	test, err := buildTestStruct(snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	util.SetDeterministicNodeSelection(true)
+	defer util.SetDeterministicNodeSelection(false)
+
+	ssn := test.RegisterSession(tiers, configurations)
+	defer test.CloseSession()
+
+	originalStatus := make(map[api.TaskID]api.TaskStatus)
+	for _, job := range ssn.Jobs {
+		for _, task := range job.Tasks {
+			originalStatus[task.UID] = task.Status
+		}
+	}
+
+	test.Run(actions)
+
+	result := &Result{
+		Binds:     map[string]string{},
+		Pipelined: map[string]string{},
+		Evicted:   []string{},
+	}
+	for _, job := range ssn.Jobs {
+		for _, task := range job.Tasks {
+			key := fmt.Sprintf("%s/%s", task.Namespace, task.Name)
+			switch task.Status {
+			case api.Binding:
+				if originalStatus[task.UID] != api.Binding {
+					result.Binds[key] = task.NodeName
+				}
+			case api.Pipelined:
+				result.Pipelined[key] = task.NodeName
+			case api.Releasing:
+				if originalStatus[task.UID] != api.Releasing {
+					result.Evicted = append(result.Evicted, key)
+				}
+			}
+		}
+	}
+	sort.Strings(result.Evicted)
+
+	return result, nil
+}
+
+// buildTestStruct extracts the kubernetes objects held by the snapshot, so that they
+// can be replayed into a mocked scheduler cache by uthelper.
+func buildTestStruct(snapshot *api.ClusterInfo) (*uthelper.TestCommonStruct, error) {
+	cs, err := cache.NewClusterSnapshot(snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	return &uthelper.TestCommonStruct{
+		Name:           "simulation",
+		Nodes:          cs.Nodes,
+		Pods:           cs.Pods,
+		PodGroups:      cs.PodGroups,
+		Queues:         cs.Queues,
+		PriClass:       cs.PriorityClasses,
+		ResourceQuotas: cs.ResourceQuotas,
+	}, nil
+}
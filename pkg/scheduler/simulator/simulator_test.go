@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+
+	schedulingv1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+	"volcano.sh/volcano/cmd/scheduler/app/options"
+	"volcano.sh/volcano/pkg/scheduler/api"
+	"volcano.sh/volcano/pkg/scheduler/cache"
+	"volcano.sh/volcano/pkg/scheduler/util"
+
+	_ "volcano.sh/volcano/pkg/scheduler/actions"
+	_ "volcano.sh/volcano/pkg/scheduler/plugins"
+)
+
+func TestMain(m *testing.M) {
+	options.Default()
+	os.Exit(m.Run())
+}
+
+func buildSnapshotFile(t *testing.T) string {
+	sc := cache.NewDefaultMockSchedulerCache("volcano")
+	sc.AddOrUpdateNode(util.BuildNode("n1", api.BuildResourceList("2", "4Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil))
+	sc.AddOrUpdateNode(util.BuildNode("n2", api.BuildResourceList("2", "4Gi", []api.ScalarResource{{Name: "pods", Value: "10"}}...), nil))
+	sc.AddQueueV1beta1(util.BuildQueue("q1", 1, nil))
+	sc.AddPodGroupV1beta1(util.BuildPodGroup("pg1", "ns1", "q1", 2, nil, schedulingv1.PodGroupInqueue))
+	sc.AddPod(util.BuildPod("ns1", "p1", "", v1.PodPending, api.BuildResourceList("2", "4Gi"), "pg1", nil, nil))
+	sc.AddPod(util.BuildPod("ns1", "p2", "", v1.PodPending, api.BuildResourceList("2", "4Gi"), "pg1", nil, nil))
+
+	cs, err := cache.NewClusterSnapshot(sc.Snapshot())
+	if err != nil {
+		t.Fatalf("failed to build snapshot: %v", err)
+	}
+	data, err := json.Marshal(cs)
+	if err != nil {
+		t.Fatalf("failed to encode snapshot: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("failed to write snapshot: %v", err)
+	}
+	return path
+}
+
+func TestSimulate(t *testing.T) {
+	path := buildSnapshotFile(t)
+
+	tests := []struct {
+		name       string
+		conf       string
+		expectBind int
+		expectErr  bool
+	}{
+		{
+			name:       "default configuration binds the whole gang",
+			conf:       "",
+			expectBind: 2,
+		},
+		{
+			name: "configuration without allocate binds nothing",
+			conf: `
+actions: "enqueue"
+tiers:
+- plugins:
+  - name: gang
+`,
+			expectBind: 0,
+		},
+		{
+			name:      "configuration without valid actions",
+			conf:      `actions: "unknown"`,
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			snapshot, err := LoadClusterInfo(path)
+			if err != nil {
+				t.Fatalf("failed to load snapshot: %v", err)
+			}
+			result, err := Simulate(snapshot, test.conf)
+			if (err != nil) != test.expectErr {
+				t.Fatalf("want error %v, got %v", test.expectErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if len(result.Binds) != test.expectBind {
+				t.Errorf("want %d binds, got %v", test.expectBind, result.Binds)
+			}
+		})
+	}
+}
+
+func TestSimulateIsRepeatable(t *testing.T) {
+	path := buildSnapshotFile(t)
+
+	// the gang fits both nodes with the same score, the nodes are picked by name
+	var results []*Result
+	for i := 0; i < 5; i++ {
+		snapshot, err := LoadClusterInfo(path)
+		if err != nil {
+			t.Fatalf("failed to load snapshot: %v", err)
+		}
+		result, err := Simulate(snapshot, "")
+		if err != nil {
+			t.Fatalf("simulate failed: %v", err)
+		}
+		results = append(results, result)
+	}
+	for _, result := range results[1:] {
+		if !reflect.DeepEqual(results[0], result) {
+			t.Errorf("simulation on the same snapshot differs: %v vs %v", results[0], result)
+		}
+	}
+	if want := map[string]string{"ns1/p1": "n1", "ns1/p2": "n2"}; !reflect.DeepEqual(results[0].Binds, want) {
+		t.Errorf("want binds %v, got %v", want, results[0].Binds)
+	}
+}
@@ -54,6 +54,8 @@ type TestCommonStruct struct {
 	Queues         []*vcapisv1.Queue
 	PriClass       []*schedulingv1.PriorityClass
 	ResourceQuotas []*v1.ResourceQuota
+	// JobFilter filters the jobs scheduled by the session like a scheduler profile, all the jobs are scheduled if it's nil
+	JobFilter framework.JobFilter
 
 	// ExpectBindMap the expected bind results.
 	// bind results: ns/podName -> nodeName
@@ -86,7 +88,7 @@ var _ Interface = &TestCommonStruct{}
 func (test *TestCommonStruct) RegisterSession(tiers []conf.Tier, config []conf.Configuration) *framework.Session {
 	schedulerCache := test.createSchedulerCache()
 	RegisterPlugins(test.Plugins)
-	test.ssn = framework.OpenSession(schedulerCache, tiers, config)
+	test.ssn = framework.OpenFilteredSession(schedulerCache, tiers, config, test.JobFilter)
 	schedulerCache.Run(test.stop)
 	return test.ssn
 }
@@ -147,8 +149,14 @@ func (test *TestCommonStruct) Run(actions []framework.Action) {
 
 // Close do release resource and clean up
 func (test *TestCommonStruct) Close() {
-	framework.CloseSession(test.ssn)
+	test.CloseSession()
 	framework.CleanupPluginBuilders()
+}
+
+// CloseSession closes the opened session and stops the mocked cache, but keeps the registered
+// plugin builders, so that the same process can open another session, e.g. in the simulator
+func (test *TestCommonStruct) CloseSession() {
+	framework.CloseSession(test.ssn)
 	close(test.stop)
 }
 
@@ -47,15 +47,37 @@ tiers:
 `
 
 func UnmarshalSchedulerConf(confStr string) ([]framework.Action, []conf.Tier, []conf.Configuration, map[string]string, error) {
-	var actions []framework.Action
+	schedulerConf, err := unmarshalSchedulerConfiguration(confStr)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	return parseActions(schedulerConf.Actions), schedulerConf.Tiers, schedulerConf.Configurations, schedulerConf.MetricsConfiguration, nil
+}
 
+// unmarshalSchedulerConfiguration unmarshals the scheduler configuration and sets default settings for the plugins
+// of both the scheduler and its profiles.
+func unmarshalSchedulerConfiguration(confStr string) (*conf.SchedulerConfiguration, error) {
 	schedulerConf := &conf.SchedulerConfiguration{}
 
 	if err := yaml.Unmarshal([]byte(confStr), schedulerConf); err != nil {
-		return nil, nil, nil, nil, err
+		return nil, err
 	}
-	// Set default settings for each plugin if not set
-	for i, tier := range schedulerConf.Tiers {
+	if err := applyTierDefaults(schedulerConf.Tiers); err != nil {
+		return nil, err
+	}
+	for _, profile := range schedulerConf.Profiles {
+		if err := applyTierDefaults(profile.Tiers); err != nil {
+			return nil, fmt.Errorf("profile %s: %v", profile.SchedulerName, err)
+		}
+	}
+
+	return schedulerConf, nil
+}
+
+// applyTierDefaults sets default settings for each plugin if not set
+func applyTierDefaults(tiers []conf.Tier) error {
+	for i, tier := range tiers {
 		// drf with hierarchy enabled
 		hdrf := false
 		// proportion enabled
@@ -69,14 +91,19 @@ func UnmarshalSchedulerConf(confStr string) ([]framework.Action, []conf.Tier, []
 			if tier.Plugins[j].Name == "proportion" {
 				proportion = true
 			}
-			plugins.ApplyPluginConfDefaults(&schedulerConf.Tiers[i].Plugins[j])
+			plugins.ApplyPluginConfDefaults(&tiers[i].Plugins[j])
 		}
 		if hdrf && proportion {
-			return nil, nil, nil, nil, fmt.Errorf("proportion and drf with hierarchy enabled conflicts")
+			return fmt.Errorf("proportion and drf with hierarchy enabled conflicts")
 		}
 	}
+	return nil
+}
 
-	actionNames := strings.Split(schedulerConf.Actions, ",")
+// parseActions returns the registered actions of the comma separated action names, the unknown actions are ignored
+func parseActions(actionsStr string) []framework.Action {
+	var actions []framework.Action
+	actionNames := strings.Split(actionsStr, ",")
 	for _, actionName := range actionNames {
 		if action, found := framework.GetAction(strings.TrimSpace(actionName)); found {
 			actions = append(actions, action)
@@ -84,8 +111,7 @@ func UnmarshalSchedulerConf(confStr string) ([]framework.Action, []conf.Tier, []
 			klog.Errorf("Failed to find Action %s, ignore it", actionName)
 		}
 	}
-
-	return actions, schedulerConf.Tiers, schedulerConf.Configurations, schedulerConf.MetricsConfiguration, nil
+	return actions
 }
 
 func runSchedulerSocket() {
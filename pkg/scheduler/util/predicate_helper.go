@@ -18,6 +18,10 @@ type PredicateHelper interface {
 
 type predicateHelper struct {
 	taskPredicateErrorCache map[string]map[string]error
+	// parallelism is the number of workers predicating nodes in parallel
+	parallelism int
+	// percentageOfNodesToFind overrides the percentage of nodes to find in options if it's greater than 0
+	percentageOfNodesToFind int32
 }
 
 // PredicateNodes returns the specified number of nodes that fit a task
@@ -37,6 +41,9 @@ func (ph *predicateHelper) PredicateNodes(task *api.TaskInfo, nodes []*api.NodeI
 		return make([]*api.NodeInfo, 0), fe
 	}
 	numNodesToFind := CalculateNumOfFeasibleNodesToFind(int32(allNodes))
+	if ph.percentageOfNodesToFind > 0 {
+		numNodesToFind = calculateNumOfFeasibleNodesToFind(int32(allNodes), ph.percentageOfNodesToFind)
+	}
 
 	//allocate enough space to avoid growing it
 	predicateNodes := make([]*api.NodeInfo, numNodesToFind)
@@ -50,13 +57,19 @@ func (ph *predicateHelper) PredicateNodes(task *api.TaskInfo, nodes []*api.NodeI
 		nodeErrorCache = map[string]error{}
 	}
 
+	// the nodes found depend on the order they are predicated in if not all the nodes are to be found
+	start, parallelism := lastProcessedNodeIndex, ph.parallelism
+	if deterministic.Load() {
+		start, parallelism = 0, 1
+	}
+
 	//create a context with cancellation
 	ctx, cancel := context.WithCancel(context.Background())
 
 	checkNode := func(index int) {
 		// Check the nodes starting from where is left off in the previous scheduling cycle,
 		// to make sure all nodes have the same chance of being examined across pods.
-		node := nodes[(lastProcessedNodeIndex+index)%allNodes]
+		node := nodes[(start+index)%allNodes]
 		atomic.AddInt32(&processedNodes, 1)
 		klog.V(4).Infof("Considering Task <%v/%v> on node <%v>: <%v> vs. <%v>",
 			task.Namespace, task.Name, node.Name, task.Resreq, node.Idle)
@@ -98,7 +111,7 @@ func (ph *predicateHelper) PredicateNodes(task *api.TaskInfo, nodes []*api.NodeI
 	}
 
 	//workqueue.ParallelizeUntil(context.TODO(), 16, len(nodes), checkNode)
-	workqueue.ParallelizeUntil(ctx, 16, allNodes, checkNode)
+	workqueue.ParallelizeUntil(ctx, parallelism, allNodes, checkNode)
 
 	//processedNodes := int(numFoundNodes) + len(filteredNodesStatuses) + len(failedPredicateMap)
 	lastProcessedNodeIndex = (lastProcessedNodeIndex + int(processedNodes)) % allNodes
@@ -111,5 +124,18 @@ func taskGroupID(task *api.TaskInfo) string {
 }
 
 func NewPredicateHelper() PredicateHelper {
-	return &predicateHelper{taskPredicateErrorCache: map[string]map[string]error{}}
+	return NewPredicateHelperWithOptions(DefaultParallelism, 0)
+}
+
+// NewPredicateHelperWithOptions returns the predicate helper predicating nodes with the given number
+// of workers, and stopping once the given percentage of nodes are found feasible if it's greater than 0.
+func NewPredicateHelperWithOptions(parallelism int, percentageOfNodesToFind int32) PredicateHelper {
+	if parallelism <= 0 {
+		parallelism = DefaultParallelism
+	}
+	return &predicateHelper{
+		taskPredicateErrorCache: map[string]map[string]error{},
+		parallelism:             parallelism,
+		percentageOfNodesToFind: percentageOfNodesToFind,
+	}
 }
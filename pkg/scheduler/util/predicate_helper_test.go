@@ -0,0 +1,90 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"volcano.sh/volcano/cmd/scheduler/app/options"
+	"volcano.sh/volcano/pkg/scheduler/api"
+)
+
+func TestPredicateNodesWithOptions(t *testing.T) {
+	options.ServerOpts = &options.ServerOption{
+		MinPercentageOfNodesToFind: 5,
+		MinNodesToFind:             100,
+		PercentageOfNodesToFind:    50,
+	}
+
+	var nodes []*api.NodeInfo
+	for i := 0; i < 400; i++ {
+		nodes = append(nodes, &api.NodeInfo{Name: fmt.Sprintf("n%d", i)})
+	}
+	task := &api.TaskInfo{Name: "t1", Job: "j1"}
+
+	tests := []struct {
+		name                    string
+		parallelism             int
+		percentageOfNodesToFind int32
+		wantNumNodes            int
+	}{
+		{
+			name:         "use percentage of nodes to find in options",
+			parallelism:  1,
+			wantNumNodes: 200,
+		},
+		{
+			name:                    "override percentage of nodes to find",
+			parallelism:             4,
+			percentageOfNodesToFind: 30,
+			wantNumNodes:            120,
+		},
+		{
+			name:                    "find all nodes",
+			parallelism:             32,
+			percentageOfNodesToFind: 100,
+			wantNumNodes:            400,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var running, maxRunning int32
+			fn := func(*api.TaskInfo, *api.NodeInfo) error {
+				current := atomic.AddInt32(&running, 1)
+				defer atomic.AddInt32(&running, -1)
+				for {
+					max := atomic.LoadInt32(&maxRunning)
+					if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+						break
+					}
+				}
+				return nil
+			}
+
+			ph := NewPredicateHelperWithOptions(tt.parallelism, tt.percentageOfNodesToFind)
+			predicateNodes, _ := ph.PredicateNodes(task, nodes, fn, false)
+			if len(predicateNodes) != tt.wantNumNodes {
+				t.Errorf("PredicateNodes() found %d nodes, want %d", len(predicateNodes), tt.wantNumNodes)
+			}
+			if int(maxRunning) > tt.parallelism {
+				t.Errorf("PredicateNodes() predicated %d nodes in parallel, want at most %d", maxRunning, tt.parallelism)
+			}
+		})
+	}
+}
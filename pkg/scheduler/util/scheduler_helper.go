@@ -23,6 +23,7 @@ import (
 	"math/rand"
 	"sort"
 	"sync"
+	"sync/atomic"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -37,20 +38,39 @@ import (
 const (
 	baselinePercentageOfNodesToFind = 50
 
+	// DefaultParallelism is the default number of workers predicating or scoring nodes in parallel
+	DefaultParallelism = 16
+
 	DefaultComponentName = "vc-scheduler"
 )
 
 var lastProcessedNodeIndex int
 
+// deterministic makes the selection of nodes depend on the nodes only: the nodes are predicated one by one from the
+// first one, and the node with the smallest name is picked among the nodes with the same highest score.
+var deterministic atomic.Bool
+
+// SetDeterministicNodeSelection enables or disables the deterministic selection of nodes, it's enabled by the simulator
+// so that two runs on the same snapshot make the same decisions.
+func SetDeterministicNodeSelection(enabled bool) {
+	deterministic.Store(enabled)
+}
+
 // CalculateNumOfFeasibleNodesToFind returns the number of feasible nodes that once found,
 // the scheduler stops its search for more feasible nodes.
 func CalculateNumOfFeasibleNodesToFind(numAllNodes int32) (numNodes int32) {
+	return calculateNumOfFeasibleNodesToFind(numAllNodes, options.ServerOpts.PercentageOfNodesToFind)
+}
+
+// calculateNumOfFeasibleNodesToFind behaves like CalculateNumOfFeasibleNodesToFind but with
+// the given percentage of nodes to find instead of the one in options.
+func calculateNumOfFeasibleNodesToFind(numAllNodes int32, percentageOfNodesToFind int32) (numNodes int32) {
 	opts := options.ServerOpts
-	if numAllNodes <= opts.MinNodesToFind || opts.PercentageOfNodesToFind >= 100 {
+	if numAllNodes <= opts.MinNodesToFind || percentageOfNodesToFind >= 100 {
 		return numAllNodes
 	}
 
-	adaptivePercentage := opts.PercentageOfNodesToFind
+	adaptivePercentage := percentageOfNodesToFind
 	if adaptivePercentage <= 0 {
 		adaptivePercentage = baselinePercentageOfNodesToFind - numAllNodes/125
 		if adaptivePercentage < opts.MinPercentageOfNodesToFind {
@@ -67,6 +87,14 @@ func CalculateNumOfFeasibleNodesToFind(numAllNodes int32) (numNodes int32) {
 
 // PrioritizeNodes returns a map whose key is node's score and value are corresponding nodes
 func PrioritizeNodes(task *api.TaskInfo, nodes []*api.NodeInfo, batchFn api.BatchNodeOrderFn, mapFn api.NodeOrderMapFn, reduceFn api.NodeOrderReduceFn) map[float64][]*api.NodeInfo {
+	return PrioritizeNodesWithParallelism(DefaultParallelism, task, nodes, batchFn, mapFn, reduceFn)
+}
+
+// PrioritizeNodesWithParallelism behaves like PrioritizeNodes but scores nodes with the given number of workers.
+func PrioritizeNodesWithParallelism(parallelism int, task *api.TaskInfo, nodes []*api.NodeInfo, batchFn api.BatchNodeOrderFn, mapFn api.NodeOrderMapFn, reduceFn api.NodeOrderReduceFn) map[float64][]*api.NodeInfo {
+	if parallelism <= 0 {
+		parallelism = DefaultParallelism
+	}
 	pluginNodeScoreMap := map[string]k8sframework.NodeScoreList{}
 	nodeOrderScoreMap := map[string]float64{}
 	nodeScores := map[float64][]*api.NodeInfo{}
@@ -93,7 +121,7 @@ func PrioritizeNodes(task *api.TaskInfo, nodes []*api.NodeInfo, batchFn api.Batc
 		nodeOrderScoreMap[node.Name] = orderScore
 		workerLock.Unlock()
 	}
-	workqueue.ParallelizeUntil(context.TODO(), 16, len(nodes), scoreNode)
+	workqueue.ParallelizeUntil(context.TODO(), parallelism, len(nodes), scoreNode)
 	reduceScores, err := reduceFn(task, pluginNodeScoreMap)
 	if err != nil {
 		klog.Errorf("Error in Calculating Priority for the node:%v", err)
@@ -145,7 +173,8 @@ func SortNodes(nodeScores map[float64][]*api.NodeInfo) []*api.NodeInfo {
 	return nodesInorder
 }
 
-// SelectBestNode returns best node whose score is highest, pick one randomly if there are many nodes with same score.
+// SelectBestNode returns best node whose score is highest, pick one randomly if there are many nodes with same score,
+// or the one with the smallest name if the node selection is deterministic.
 func SelectBestNode(nodeScores map[float64][]*api.NodeInfo) *api.NodeInfo {
 	var bestNodes []*api.NodeInfo
 	maxScore := -1.0
@@ -160,6 +189,15 @@ func SelectBestNode(nodeScores map[float64][]*api.NodeInfo) *api.NodeInfo {
 		return nil
 	}
 
+	if deterministic.Load() {
+		best := bestNodes[0]
+		for _, node := range bestNodes[1:] {
+			if node.Name < best.Name {
+				best = node
+			}
+		}
+		return best
+	}
 	return bestNodes[rand.Intn(len(bestNodes))]
 }
 
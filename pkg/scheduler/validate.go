@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"volcano.sh/volcano/pkg/scheduler/conf"
+	"volcano.sh/volcano/pkg/scheduler/framework"
+)
+
+// ValidateSchedulerConf validates the scheduler configuration strictly, all the errors found are returned:
+// the unknown keys, the unknown actions and plugins, the invalid profiles of the scheduler names, and the
+// arguments of the actions and plugins which declare the schema of their arguments.
+func ValidateSchedulerConf(confStr string, schedulerNames []string) error {
+	schedulerConf := &conf.SchedulerConfiguration{}
+	if err := yaml.UnmarshalStrict([]byte(confStr), schedulerConf); err != nil {
+		return err
+	}
+
+	var errs []error
+	if _, _, err := unmarshalSchedulerProfiles(confStr, schedulerNames); err != nil {
+		errs = append(errs, err)
+	}
+	errs = append(errs, validatePolicy(schedulerConf.Actions, schedulerConf.Tiers, schedulerConf.Configurations)...)
+	for _, p := range schedulerConf.Profiles {
+		for _, err := range validatePolicy(p.Actions, p.Tiers, p.Configurations) {
+			errs = append(errs, fmt.Errorf("profile %s: %v", p.SchedulerName, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// validatePolicy validates the actions, the plugins in tiers and the configurations of actions.
+func validatePolicy(actions string, tiers []conf.Tier, configurations []conf.Configuration) []error {
+	var errs []error
+	for _, name := range strings.Split(actions, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if _, found := framework.GetAction(name); !found {
+			errs = append(errs, fmt.Errorf("unknown action %s", name))
+		}
+	}
+
+	for i, tier := range tiers {
+		for _, plugin := range tier.Plugins {
+			if _, found := framework.GetPluginBuilder(plugin.Name); !found {
+				errs = append(errs, fmt.Errorf("tier %d: unknown plugin %s", i, plugin.Name))
+				continue
+			}
+			if schema, found := framework.GetPluginArgumentsSchema(plugin.Name); found {
+				if err := schema.Validate(plugin.Arguments); err != nil {
+					errs = append(errs, fmt.Errorf("tier %d: plugin %s: %v", i, plugin.Name, err))
+				}
+			}
+		}
+	}
+
+	for _, configuration := range configurations {
+		if _, found := framework.GetAction(configuration.Name); !found {
+			errs = append(errs, fmt.Errorf("configurations: unknown action %s", configuration.Name))
+			continue
+		}
+		if schema, found := framework.GetActionArgumentsSchema(configuration.Name); found {
+			if err := schema.Validate(configuration.Arguments); err != nil {
+				errs = append(errs, fmt.Errorf("configurations: action %s: %v", configuration.Name, err))
+			}
+		}
+	}
+	return errs
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2026 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSchedulerConf(t *testing.T) {
+	for _, file := range []string{
+		"../../installer/helm/chart/volcano/config/volcano-scheduler.conf",
+		"../../installer/helm/chart/volcano/config/volcano-scheduler-ci.conf",
+	} {
+		confStr, err := os.ReadFile(file)
+		if assert.NoError(t, err) {
+			assert.NoError(t, ValidateSchedulerConf(string(confStr), []string{"volcano"}), file)
+		}
+	}
+	assert.NoError(t, ValidateSchedulerConf(DefaultSchedulerConf, []string{"volcano"}))
+
+	cases := []struct {
+		name      string
+		conf      string
+		expectErr string
+	}{
+		{
+			name: "valid configuration",
+			conf: `
+actions: "enqueue, allocate, backfill"
+tiers:
+- plugins:
+  - name: gang
+    enablePreemptable: false
+  - name: binpack
+    arguments:
+      binpack.weight: 10
+      binpack.resources: nvidia.com/gpu
+      binpack.resources.nvidia.com/gpu: 2
+configurations:
+- name: allocate
+  arguments:
+    predicateErrorCacheEnable: false
+`,
+		},
+		{
+			name: "unknown option of plugin",
+			conf: `
+actions: "allocate"
+tiers:
+- plugins:
+  - name: gang
+    enablePreemptible: false
+`,
+			expectErr: "field enablePreemptible not found",
+		},
+		{
+			name: "unknown action and plugin",
+			conf: `
+actions: "allocate, alocate"
+tiers:
+- plugins:
+  - name: gang
+  - name: gnag
+configurations:
+- name: reclam
+`,
+			expectErr: "[unknown action alocate, tier 0: unknown plugin gnag, configurations: unknown action reclam]",
+		},
+		{
+			name: "invalid arguments",
+			conf: `
+actions: "allocate"
+tiers:
+- plugins:
+  - name: binpack
+    arguments:
+      binpack.weight: high
+      binpack.wieght: 10
+configurations:
+- name: allocate
+  arguments:
+    predicateErrorCacheEnable: "no"
+`,
+			expectErr: "[tier 0: plugin binpack: [argument binpack.weight: high is not int, unknown argument binpack.wieght], " +
+				"configurations: action allocate: argument predicateErrorCacheEnable: no is not bool]",
+		},
+		{
+			name: "invalid profile",
+			conf: `
+actions: "allocate"
+tiers:
+- plugins:
+  - name: gang
+profiles:
+- schedulerName: online
+  actions: "allocate, prempt"
+- schedulerName: online
+`,
+			expectErr: "[duplicated profile of scheduler online, profile online: unknown action prempt]",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := ValidateSchedulerConf(c.conf, []string{"volcano", "online"})
+			if c.expectErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), c.expectErr)
+			}
+		})
+	}
+}
+
+func TestSchedulerLoadsConf(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "volcano-scheduler.conf")
+	pc := &Scheduler{schedulerConf: path, schedulerNames: []string{"volcano"}}
+
+	// the initial configuration which can't be unmarshalled is refused, NewScheduler fails with the error
+	assert.NoError(t, os.WriteFile(path, []byte("actions: [allocate"), 0600))
+	assert.Error(t, pc.loadSchedulerConf(false))
+
+	// the initial configuration is loaded leniently, e.g. with unknown keys after an upgrade
+	assert.NoError(t, os.WriteFile(path, []byte("actions: \"allocate\"\nenablePreemptible: true"), 0600))
+	assert.NoError(t, pc.loadSchedulerConf(false))
+	actions, _ := pc.profiles[0].getSchedulerConf()
+	assert.Equal(t, []string{"allocate"}, actions)
+
+	// the previous configuration is kept on hot reload
+	assert.NoError(t, os.WriteFile(path, []byte("actions: \"allocate, prempt\""), 0600))
+	if err := pc.loadSchedulerConf(true); assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "unknown action prempt")
+	}
+	actions, _ = pc.profiles[0].getSchedulerConf()
+	assert.Equal(t, []string{"allocate"}, actions)
+
+	assert.NoError(t, os.WriteFile(path, []byte("actions: \"allocate, backfill\""), 0600))
+	assert.NoError(t, pc.loadSchedulerConf(true))
+	actions, _ = pc.profiles[0].getSchedulerConf()
+	assert.Equal(t, []string{"allocate", "backfill"}, actions)
+}
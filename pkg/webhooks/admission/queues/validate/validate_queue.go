@@ -17,6 +17,7 @@ limitations under the License.
 package validate
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -64,6 +65,13 @@ var service = &router.AdmissionService{
 
 var config = &router.AdmissionServiceConfig{}
 
+const (
+	// borrowingLimitAnnotationKey and lendingLimitAnnotationKey are the annotations of queue with the resource
+	// limits of borrowing and lending in the capacity plugin, e.g. {"cpu": "4", "memory": "8Gi"}.
+	borrowingLimitAnnotationKey = "volcano.sh/borrowing-limit"
+	lendingLimitAnnotationKey   = "volcano.sh/lending-limit"
+)
+
 // AdmitQueues is to admit queues and return response.
 func AdmitQueues(ar admissionv1.AdmissionReview) *admissionv1.AdmissionResponse {
 	klog.V(3).Infof("Admitting %s queue %s.", ar.Request.Operation, ar.Request.Name)
@@ -117,6 +125,7 @@ func validateQueue(queue *schedulingv1beta1.Queue) error {
 	errs = append(errs, validateStateOfQueue(queue.Status.State, resourcePath.Child("spec").Child("state"))...)
 	errs = append(errs, validateWeightOfQueue(queue.Spec.Weight, resourcePath.Child("spec").Child("weight"))...)
 	errs = append(errs, validateHierarchicalAttributes(queue, resourcePath.Child("metadata").Child("annotations"))...)
+	errs = append(errs, validateResourceLimits(queue, resourcePath.Child("metadata").Child("annotations"))...)
 
 	if len(errs) > 0 {
 		return errs.ToAggregate()
@@ -129,6 +138,15 @@ func validateHierarchicalAttributes(queue *schedulingv1beta1.Queue, fldPath *fie
 	hierarchy := queue.Annotations[schedulingv1beta1.KubeHierarchyAnnotationKey]
 	hierarchicalWeights := queue.Annotations[schedulingv1beta1.KubeHierarchyWeightAnnotationKey]
 	if hierarchy != "" || hierarchicalWeights != "" {
+		// The annotations are deprecated by spec.parent, the two can't define the hierarchy of one queue together.
+		if queue.Spec.Parent != "" && queue.Spec.Parent != "root" {
+			return append(errs, field.Invalid(fldPath, hierarchy,
+				fmt.Sprintf("%s and %s are deprecated and can not be used together with spec.parent",
+					schedulingv1beta1.KubeHierarchyAnnotationKey,
+					schedulingv1beta1.KubeHierarchyWeightAnnotationKey,
+				)))
+		}
+
 		paths := strings.Split(hierarchy, "/")
 		weights := strings.Split(hierarchicalWeights, "/")
 		// path length must be the same with weights length
@@ -182,6 +200,30 @@ func validateHierarchicalAttributes(queue *schedulingv1beta1.Queue, fldPath *fie
 	return errs
 }
 
+// validateResourceLimits validates the borrowing and lending limits of the queue are resource lists of
+// non-negative quantities.
+func validateResourceLimits(queue *schedulingv1beta1.Queue, fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+	for _, key := range []string{borrowingLimitAnnotationKey, lendingLimitAnnotationKey} {
+		value, found := queue.Annotations[key]
+		if !found {
+			continue
+		}
+		limit := v1.ResourceList{}
+		if err := json.Unmarshal([]byte(value), &limit); err != nil {
+			errs = append(errs, field.Invalid(fldPath.Key(key), value, fmt.Sprintf("must be a resource list: %v", err)))
+			continue
+		}
+		for name, quantity := range limit {
+			if quantity.Sign() < 0 {
+				errs = append(errs, field.Invalid(fldPath.Key(key), value,
+					fmt.Sprintf("the limit of resource %s must be non-negative", name)))
+			}
+		}
+	}
+	return errs
+}
+
 func validateStateOfQueue(value schedulingv1beta1.QueueState, fldPath *field.Path) field.ErrorList {
 	errs := field.ErrorList{}
 
@@ -249,6 +291,22 @@ func validateHierarchicalQueue(queue *schedulingv1beta1.Queue) error {
 		return fmt.Errorf("failed to get parent queue of queue %s: %v", queue.Name, err)
 	}
 
+	// The queue can not be the parent of its ancestors, which makes a cycle.
+	visited := map[string]bool{}
+	for ancestor := parentQueue; !visited[ancestor.Name]; {
+		if ancestor.Name == queue.Name {
+			return fmt.Errorf("queue %s cannot be the parent queue of queue %s because it's a descendant of queue %s",
+				parentQueue.Name, queue.Name, queue.Name)
+		}
+		visited[ancestor.Name] = true
+		if ancestor.Spec.Parent == "" || ancestor.Spec.Parent == "root" {
+			break
+		}
+		if ancestor, err = config.QueueLister.Get(ancestor.Spec.Parent); err != nil {
+			break
+		}
+	}
+
 	childQueueNames, err := listQueueChild(parentQueue.Name)
 	if err != nil {
 		return fmt.Errorf("failed to list child queues: %v", err)
@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 
 	admissionv1 "k8s.io/api/admission/v1"
@@ -295,15 +296,6 @@ func TestAdmitQueues(t *testing.T) {
 	queueInformer := informerFactory.Scheduling().V1beta1().Queues()
 	config.QueueLister = queueInformer.Lister()
 
-	stopCh := make(chan struct{})
-
-	informerFactory.Start(stopCh)
-	for informerType, ok := range informerFactory.WaitForCacheSync(stopCh) {
-		if !ok {
-			panic(fmt.Errorf("failed to sync cache: %v", informerType))
-		}
-	}
-
 	_, err = config.VolcanoClient.SchedulingV1beta1().Queues().Create(context.TODO(), &openStateForDelete, metav1.CreateOptions{})
 	if err != nil {
 		t.Errorf("Create queue with open state failed for %v.", err)
@@ -323,6 +315,16 @@ func TestAdmitQueues(t *testing.T) {
 		t.Errorf("Crate queue with positive weight failed for %v.", err)
 	}
 
+	// start the informer after the queues are created, so the queue lister is synced with them
+	stopCh := make(chan struct{})
+
+	informerFactory.Start(stopCh)
+	for informerType, ok := range informerFactory.WaitForCacheSync(stopCh) {
+		if !ok {
+			panic(fmt.Errorf("failed to sync cache: %v", informerType))
+		}
+	}
+
 	defer func() {
 		if err := config.VolcanoClient.SchedulingV1beta1().Queues().Delete(context.TODO(), openStateForDelete.Name, metav1.DeleteOptions{}); err != nil {
 			fmt.Printf("Delete queue with open state failed for %v.\n", err)
@@ -1031,19 +1033,37 @@ func TestAdmitHierarchicalQueues(t *testing.T) {
 		t.Errorf("Marshal queue with child queue failed for %v.", err)
 	}
 
+	queueWithChildUnderChild := queueWithChild.DeepCopy()
+	queueWithChildUnderChild.Spec.Parent = "child-queue"
+	queueWithChildUnderChild.Spec.Weight = 1
+	queueWithChildUnderChildJSON, err := json.Marshal(queueWithChildUnderChild)
+	if err != nil {
+		t.Errorf("Marshal queue with child queue failed for %v.", err)
+	}
+
+	annotatedChildQueue := schedulingv1beta1.Queue{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "annotated-child-queue",
+			Annotations: map[string]string{
+				schedulingv1beta1.KubeHierarchyAnnotationKey:       "root/annotated-child-queue",
+				schedulingv1beta1.KubeHierarchyWeightAnnotationKey: "1/1",
+			},
+		},
+		Spec: schedulingv1beta1.QueueSpec{
+			Parent: "queue-without-jobs",
+			Weight: 1,
+		},
+	}
+	annotatedChildQueueJSON, err := json.Marshal(annotatedChildQueue)
+	if err != nil {
+		t.Errorf("Marshal queue with annotations failed for %v.", err)
+	}
+
 	config.VolcanoClient = fakeclient.NewSimpleClientset()
 	informerFactory := informers.NewSharedInformerFactory(config.VolcanoClient, 0)
 	queueInformer := informerFactory.Scheduling().V1beta1().Queues()
 	config.QueueLister = queueInformer.Lister()
 
-	stopCh := make(chan struct{})
-	informerFactory.Start(stopCh)
-	for informerType, ok := range informerFactory.WaitForCacheSync(stopCh) {
-		if !ok {
-			panic(fmt.Errorf("failed to sync cache: %v", informerType))
-		}
-	}
-
 	queueWithJobs := schedulingv1beta1.Queue{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "queue-with-jobs",
@@ -1160,6 +1180,15 @@ func TestAdmitHierarchicalQueues(t *testing.T) {
 		t.Errorf("Create queue failed for %v.", err)
 	}
 
+	// start the informer after the queues are created, so the queue lister is synced with them
+	stopCh := make(chan struct{})
+	informerFactory.Start(stopCh)
+	for informerType, ok := range informerFactory.WaitForCacheSync(stopCh) {
+		if !ok {
+			panic(fmt.Errorf("failed to sync cache: %v", informerType))
+		}
+	}
+
 	testCases := []struct {
 		Name           string
 		AR             admissionv1.AdmissionReview
@@ -1255,6 +1284,75 @@ func TestAdmitHierarchicalQueues(t *testing.T) {
 				Allowed: true,
 			},
 		},
+		{
+			Name: "Parent Queue is a descendant of the queue",
+			AR: admissionv1.AdmissionReview{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "AdmissionReview",
+					APIVersion: "admission.k8s.io/v1beta1",
+				},
+				Request: &admissionv1.AdmissionRequest{
+					Kind: metav1.GroupVersionKind{
+						Group:   "scheduling.volcano.sh",
+						Version: "v1beta1",
+						Kind:    "Queue",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    "scheduling.volcano.sh",
+						Version:  "v1beta1",
+						Resource: "queues",
+					},
+					Name:      "queue-with-child-queues",
+					Operation: "UPDATE",
+					Object: runtime.RawExtension{
+						Raw: queueWithChildUnderChildJSON,
+					},
+					OldObject: runtime.RawExtension{
+						Raw: queueWithChildJSON,
+					},
+				},
+			},
+			reviewResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Message: "queue child-queue cannot be the parent queue of queue queue-with-child-queues because it's a descendant of queue queue-with-child-queues",
+				},
+			},
+		},
+		{
+			Name: "Deprecated hierarchy annotations with parent queue",
+			AR: admissionv1.AdmissionReview{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "AdmissionReview",
+					APIVersion: "admission.k8s.io/v1beta1",
+				},
+				Request: &admissionv1.AdmissionRequest{
+					Kind: metav1.GroupVersionKind{
+						Group:   "scheduling.volcano.sh",
+						Version: "v1beta1",
+						Kind:    "Queue",
+					},
+					Resource: metav1.GroupVersionResource{
+						Group:    "scheduling.volcano.sh",
+						Version:  "v1beta1",
+						Resource: "queues",
+					},
+					Name:      "annotated-child-queue",
+					Operation: "CREATE",
+					Object: runtime.RawExtension{
+						Raw: annotatedChildQueueJSON,
+					},
+				},
+			},
+			reviewResponse: &admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result: &metav1.Status{
+					Message: field.Invalid(field.NewPath("requestBody").Child("metadata").Child("annotations"),
+						"root/annotated-child-queue", fmt.Sprintf("%s and %s are deprecated and can not be used together with spec.parent",
+							schedulingv1beta1.KubeHierarchyAnnotationKey, schedulingv1beta1.KubeHierarchyWeightAnnotationKey)).Error(),
+				},
+			},
+		},
 		{
 			Name: "Delete queue with child queue",
 			AR: admissionv1.AdmissionReview{
@@ -1329,3 +1427,50 @@ func TestAdmitHierarchicalQueues(t *testing.T) {
 	}
 	close(stopCh)
 }
+
+func TestValidateResourceLimits(t *testing.T) {
+	testCases := []struct {
+		Name        string
+		Annotations map[string]string
+		ExpectErr   string
+	}{
+		{
+			Name:        "valid-limits",
+			Annotations: map[string]string{borrowingLimitAnnotationKey: `{"cpu": "4", "memory": "8Gi"}`, lendingLimitAnnotationKey: `{"cpu": "2"}`},
+		},
+		{
+			Name:        "invalid-json",
+			Annotations: map[string]string{borrowingLimitAnnotationKey: `{"cpu": 4`},
+			ExpectErr:   "metadata.annotations[volcano.sh/borrowing-limit]",
+		},
+		{
+			Name:        "invalid-quantity",
+			Annotations: map[string]string{lendingLimitAnnotationKey: `{"cpu": "two"}`},
+			ExpectErr:   "metadata.annotations[volcano.sh/lending-limit]",
+		},
+		{
+			Name:        "negative-quantity",
+			Annotations: map[string]string{lendingLimitAnnotationKey: `{"cpu": "-1"}`},
+			ExpectErr:   "the limit of resource cpu must be non-negative",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			queue := &schedulingv1beta1.Queue{
+				ObjectMeta: metav1.ObjectMeta{Name: testCase.Name, Annotations: testCase.Annotations},
+				Spec:       schedulingv1beta1.QueueSpec{Weight: 1},
+			}
+			err := validateQueue(queue)
+			if testCase.ExpectErr == "" {
+				if err != nil {
+					t.Errorf("Test case %s failed, expect no error, got %v", testCase.Name, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), testCase.ExpectErr) {
+				t.Errorf("Test case %s failed, expect error %q, got %v", testCase.Name, testCase.ExpectErr, err)
+			}
+		})
+	}
+}